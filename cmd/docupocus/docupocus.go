@@ -13,7 +13,9 @@ import (
 	aibackend "github.com/MRGHOSJ/docupocus/internal/ai/backend"
 	"github.com/MRGHOSJ/docupocus/internal/analyzer"
 	"github.com/MRGHOSJ/docupocus/internal/generator"
+	"github.com/MRGHOSJ/docupocus/internal/generator/manifest"
 	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
 	"github.com/MRGHOSJ/docupocus/internal/tui"
 	"github.com/MRGHOSJ/docupocus/internal/utils"
 )
@@ -29,14 +31,37 @@ func run() error {
 	// Parse command line flags
 	nonInteractive := flag.Bool("non-interactive", false, "Run in CI mode")
 	projectDirFlag := flag.String("project-dir", ".", "Project directory to analyze")
-	aiBackendFlag := flag.String("ai-backend", "openrouter", "AI backend (ollama or openrouter)")
+	aiBackendFlag := flag.String("ai-backend", "openrouter", "AI backend (ollama, openrouter, openai-compatible or replay)")
 	aiModelFlag := flag.String("ai-model", "deepseek/deepseek-chat-v3-0324:free", "AI Model to use")
 	aiEndpointFlag := flag.String("ai-endpoint", "", "Custom AI endpoint URL")
-	aiAPIKeyFlag := flag.String("ai-api-key", "", "API key for OpenRouter")
+	aiAPIKeyFlag := flag.String("ai-api-key", "", "API key for OpenRouter or an OpenAI-compatible server")
+	aiAuthHeaderFlag := flag.String("ai-auth-header", "Authorization", "Header carrying the API key; Authorization sends it as a bearer token")
+	aiHeaders := make(map[string]string)
+	flag.Func("ai-header", "Extra `header` sent to the AI backend, as \"Name: value\" (repeatable)", func(value string) error {
+		name, headerValue, ok := strings.Cut(value, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("expected \"Name: value\", got %q", value)
+		}
+		aiHeaders[strings.TrimSpace(name)] = strings.TrimSpace(headerValue)
+		return nil
+	})
+	aiTemperatureFlag := flag.Float64("ai-temperature", 0.2, "Sampling temperature of the AI model")
+	aiMaxTokensFlag := flag.Int("ai-max-tokens", 800, "Maximum number of tokens per AI response (OpenRouter and OpenAI-compatible backends)")
+	ollamaNumCtxFlag := flag.Int("ollama-num-ctx", 0, "Context window size for Ollama models (default: the model's)")
+	aiFixturesFlag := flag.String("ai-fixtures", "", "Directory of recorded responses the replay backend serves")
+	aiSyntheticFlag := flag.Bool("ai-synthetic", false, "Let the replay backend fabricate responses it has no fixture for")
+	aiRecordFlag := flag.String("ai-record", "", "Directory to record the AI backend's responses into, for the replay backend")
+	ollamaKeepAliveFlag := flag.Duration("ollama-keep-alive", 0, "How long Ollama keeps the model loaded after a request, e.g. 10m or -1s for forever (default: the server's)")
 	outputFolderFlag := flag.String("output", "docs", "Output file path")
 	generateSummaryFlag := flag.Bool("summary", false, "Generate a PR change summary")
 	baseBranchFlag := flag.String("base-branch", "main", "Base branch to compare against")
 	verboseFlag := flag.Bool("verbose", true, "Enable verbose logging")
+	goTypesFlag := flag.Bool("go-types", false, "Load Go packages with full type information (requires the go toolchain)")
+	includeFlag := flag.String("include", "", "Comma-separated globs of files to analyze (default: all)")
+	excludeFlag := flag.String("exclude", "", "Comma-separated globs of files and directories to skip")
+	fullFlag := flag.Bool("full", false, "Ignore the previous run and regenerate every page and AI doc")
+	workersFlag := flag.Int("workers", 0, "Number of files to analyze in parallel (default: number of CPUs)")
+	configFlag := flag.String("config", "", "Path to the config file (default: <project-dir>/"+analyzer.DefaultConfigFile+")")
 
 	flag.Parse()
 
@@ -49,6 +74,12 @@ func run() error {
 	verbose := *verboseFlag
 	generateSummary := *generateSummaryFlag
 	baseBranch := *baseBranchFlag
+	analyzeOpts := analyzer.Options{
+		GoTypeCheck: *goTypesFlag,
+		Include:     splitList(*includeFlag),
+		Exclude:     splitList(*excludeFlag),
+		Workers:     *workersFlag,
+	}
 
 	// If interactive, run wizard to get values instead of flags
 	if !*nonInteractive {
@@ -81,6 +112,14 @@ func run() error {
 			// Clear endpoint if switching backend
 			aiEndpoint = ""
 		}
+		if aiBackend == "openai-compatible" {
+			if m.AiEndpoint != "" {
+				aiEndpoint = m.AiEndpoint
+			}
+			if m.AiAPIKey != "" {
+				aiAPIKey = m.AiAPIKey
+			}
+		}
 		if m.OutputFolder != "" {
 			outputFolder = m.OutputFolder
 		}
@@ -92,8 +131,31 @@ func run() error {
 		return fmt.Errorf("invalid project directory: %w", err)
 	}
 
+	// Load include/exclude globs from the config file
+	configPath := *configFlag
+	if configPath == "" {
+		configPath = filepath.Join(absProjectDir, analyzer.DefaultConfigFile)
+	}
+	fileConfig, err := analyzer.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	fileConfig.Apply(&analyzeOpts)
+
 	// Setup AI client
-	aiClient, err := setupAIClient(aiBackend, aiModel, aiEndpoint, aiAPIKey, verbose)
+	aiClient, err := setupAIClient(aiBackend, aibackend.BackendConfig{
+		Model:       aiModel,
+		Endpoint:    aiEndpoint,
+		APIKey:      aiAPIKey,
+		AuthHeader:  *aiAuthHeaderFlag,
+		Headers:     aiHeaders,
+		Temperature: *aiTemperatureFlag,
+		MaxTokens:   *aiMaxTokensFlag,
+		NumCtx:      *ollamaNumCtxFlag,
+		KeepAlive:   *ollamaKeepAliveFlag,
+		Fixtures:    *aiFixturesFlag,
+		Synthetic:   *aiSyntheticFlag,
+	}, *aiRecordFlag, verbose)
 	if err != nil {
 		return fmt.Errorf("AI setup failed: %w", err)
 	}
@@ -112,16 +174,11 @@ func run() error {
 	if verbose {
 		fmt.Println("🚀 Starting documentation generation...")
 	}
-	return generateDocs(absProjectDir, outputFolder, aiClient, verbose)
+	return generateDocs(absProjectDir, outputFolder, aiClient, analyzeOpts, *fullFlag, verbose)
 }
 
-func setupAIClient(backend, Model, endpoint, apiKey string, verbose bool) (*ai.Client, error) {
-	// Create backend configuration
-	cfg := aibackend.BackendConfig{
-		Model:    Model,
-		Endpoint: endpoint,
-		APIKey:   apiKey,
-	}
+func setupAIClient(backend string, cfg aibackend.BackendConfig, recordDir string, verbose bool) (*ai.Client, error) {
+	Model := cfg.Model
 
 	// Create the appropriate backend
 	var backendImpl aibackend.Backend
@@ -135,6 +192,9 @@ func setupAIClient(backend, Model, endpoint, apiKey string, verbose bool) (*ai.C
 		}
 		if verbose {
 			fmt.Println("✅ Using Ollama backend with Model:", Model)
+			if cfg.Endpoint != "" {
+				fmt.Println("   Endpoint:", cfg.Endpoint)
+			}
 		}
 	case "openrouter":
 		backendImpl = aibackend.NewOpenRouterBackend(cfg)
@@ -144,10 +204,43 @@ func setupAIClient(backend, Model, endpoint, apiKey string, verbose bool) (*ai.C
 				fmt.Println("⚠️  Warning: Using non-free OpenRouter Model may incur costs")
 			}
 		}
+	case "openai-compatible":
+		backendImpl, err = aibackend.NewOpenAICompatibleBackend(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OpenAI-compatible backend: %w", err)
+		}
+		if verbose {
+			fmt.Printf("✅ Using OpenAI-compatible backend at %s with Model: %s\n", cfg.Endpoint, Model)
+		}
+	case "replay":
+		backendImpl, err = aibackend.NewReplayBackend(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create replay backend: %w", err)
+		}
+		if verbose {
+			switch {
+			case cfg.Fixtures == "":
+				fmt.Println("✅ Using synthetic responses, no model")
+			case cfg.Synthetic:
+				fmt.Println("✅ Replaying responses from", cfg.Fixtures, "with synthetic fallbacks")
+			default:
+				fmt.Println("✅ Replaying responses from", cfg.Fixtures)
+			}
+		}
 	default:
 		return nil, fmt.Errorf("unsupported backend: %s", backend)
 	}
 
+	if recordDir != "" {
+		backendImpl, err = aibackend.NewRecordBackend(backendImpl, recordDir, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if verbose {
+			fmt.Println("📼 Recording responses into", recordDir)
+		}
+	}
+
 	// Create the AI client
 	client := ai.NewClient(backendImpl, cfg)
 	client.ApplyDefaults()
@@ -156,13 +249,24 @@ func setupAIClient(backend, Model, endpoint, apiKey string, verbose bool) (*ai.C
 
 }
 
-func generateDocs(projectDir, outputFolder string, aiClient *ai.Client, verbose bool) error {
+func generateDocs(projectDir, outputFolder string, aiClient *ai.Client, opts analyzer.Options, rebuild, verbose bool) error {
 	if verbose {
 		fmt.Printf("🔍 Analyzing project at: %s\n", projectDir)
 	}
 
-	// Analyze project
-	result, err := analyzer.AnalyzeProject(projectDir)
+	// Analyze project, never descending into the docs we are about to write
+	opts.OutputDir = outputFolder
+
+	// Reuse files that have not changed since the last run
+	if !rebuild {
+		previous, err := manifest.Load(outputFolder)
+		if err != nil {
+			return err
+		}
+		opts.Previous = previous.Files()
+	}
+
+	result, err := analyzer.AnalyzeProject(context.Background(), projectDir, opts)
 	if err != nil {
 		return fmt.Errorf("project analysis failed: %w", err)
 	}
@@ -170,7 +274,7 @@ func generateDocs(projectDir, outputFolder string, aiClient *ai.Client, verbose
 		fmt.Printf("✅ Found %d files with documentation\n", len(result.Files))
 	}
 
-	langs := utils.DetectLanguages(projectDir)
+	langs := analyzer.DetectLanguages(projectDir, opts)
 	if verbose {
 		fmt.Printf("🧩 Detected languages: %v\n", langs)
 	}
@@ -203,6 +307,7 @@ func generateDocs(projectDir, outputFolder string, aiClient *ai.Client, verbose
 	cfg := docTypes.GeneratorConfig{
 		AIClient:  aiClient,
 		OutputDir: outputFolder,
+		Rebuild:   rebuild,
 		Project: docTypes.ProjectMeta{
 			Name:        projectName,
 			Description: projectDescription,
@@ -225,6 +330,9 @@ func generateDocs(projectDir, outputFolder string, aiClient *ai.Client, verbose
 		},
 	}
 
+	// Quick Start and Tech Stack entries declared by Dockerfiles and Makefiles
+	docUtils.DetectTooling(projectDir, result, &cfg.Project)
+
 	if err = generator.GeneratePackageDocs(result, cfg); err != nil {
 		return fmt.Errorf("document generation failed: %w", err)
 	}
@@ -299,3 +407,14 @@ func generatePRSummary(projectDir, baseBranch string, aiClient *ai.Client) error
 
 	return nil
 }
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
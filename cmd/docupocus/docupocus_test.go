@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	aibackend "github.com/MRGHOSJ/docupocus/internal/ai/backend"
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+// TestGenerateDocsOffline runs the whole pipeline on a small project with
+// synthetic AI responses, recording them, then replays the recording
+func TestGenerateDocsOffline(t *testing.T) {
+	projectDir := t.TempDir()
+	writeFile(t, filepath.Join(projectDir, "main.go"), "package main\n\n// Add returns the sum of a and b\nfunc Add(a, b int) int { return a + b }\n\nfunc main() { println(Add(1, 2)) }\n")
+	writeFile(t, filepath.Join(projectDir, "config.yaml"), "server:\n  port: 8080\n  host: localhost\n")
+
+	fixtures := t.TempDir()
+	synthetic, err := setupAIClient("replay", aibackend.BackendConfig{Synthetic: true, CacheDir: t.TempDir()}, fixtures, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := filepath.Join(t.TempDir(), "docs")
+	if err := generateDocs(projectDir, first, synthetic, analyzer.Options{}, true, false); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(first, filepath.Base(projectDir), "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Add(a int, b int)", "synthetic summary"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("page does not contain %q:\n%s", want, page)
+		}
+	}
+
+	recorded, _ := os.ReadDir(fixtures)
+	if len(recorded) == 0 {
+		t.Fatal("no fixtures recorded")
+	}
+
+	// The recorded fixtures alone reproduce the same docs
+	replay, err := setupAIClient("replay", aibackend.BackendConfig{Fixtures: fixtures, CacheDir: t.TempDir()}, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := filepath.Join(t.TempDir(), "docs")
+	if err := generateDocs(projectDir, second, replay, analyzer.Options{}, true, false); err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := os.ReadFile(filepath.Join(second, filepath.Base(projectDir), "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(replayed) != string(page) {
+		t.Errorf("replayed page differs from the recorded run:\n%s", replayed)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,10 @@
 package ai
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
 
 type Backend interface {
 	Call(ctx context.Context, prompt string) (string, error)
@@ -8,6 +12,14 @@ type Backend interface {
 	Name() string
 }
 
+// JSONBackend is a Backend that can constrain its response to JSON matching
+// a schema, so that documentation batches come back parseable
+type JSONBackend interface {
+	Backend
+
+	CallJSON(ctx context.Context, prompt string, schema json.RawMessage) (string, error)
+}
+
 type BackendConfig struct {
 	Model       string
 	Endpoint    string
@@ -17,4 +29,29 @@ type BackendConfig struct {
 	BatchSize   int
 	TokenBudget int
 	Prompt      string
+
+	// AuthHeader is the header carrying APIKey. "Authorization" (the
+	// default) sends "Bearer <key>"; any other header gets the bare key.
+	AuthHeader string
+	// Headers are sent with every request, e.g. an organization or tenant ID
+	Headers map[string]string
+	// Temperature is the sampling temperature; 0 is the most deterministic
+	Temperature float64
+	// MaxTokens caps the length of each OpenAI-compatible response (default: 800)
+	MaxTokens int
+
+	// NumCtx is the context window Ollama loads the model with (0: the
+	// model's default)
+	NumCtx int
+	// KeepAlive is how long Ollama keeps the model loaded after a request
+	// (0: the server's default, negative: forever)
+	KeepAlive time.Duration
+
+	// Fixtures is the directory the replay backend serves responses from
+	Fixtures string
+	// Synthetic makes the replay backend fabricate responses it has no
+	// fixture for
+	Synthetic bool
+	// CacheDir is where the client caches documentation (default: ai-cache)
+	CacheDir string
 }
@@ -2,40 +2,97 @@ package ai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/ollama/ollama/api"
 )
 
+// defaultOllamaPort is used for endpoints given as a bare host, as with OLLAMA_HOST
+const defaultOllamaPort = "11434"
+
 type OllamaBackend struct {
 	client *api.Client
 	config BackendConfig
 }
 
+// NewOllamaBackend connects to the Ollama server at cfg.Endpoint, or to the
+// one OLLAMA_HOST points to when no endpoint is given
 func NewOllamaBackend(cfg BackendConfig) (*OllamaBackend, error) {
-	cli, err := api.ClientFromEnvironment()
+	if cfg.Endpoint == "" {
+		cli, err := api.ClientFromEnvironment()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client: %w", err)
+		}
+		return &OllamaBackend{client: cli, config: cfg}, nil
+	}
+
+	base, err := ollamaBaseURL(cfg.Endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
+		return nil, err
 	}
 	return &OllamaBackend{
-		client: cli,
+		client: api.NewClient(base, http.DefaultClient),
 		config: cfg,
 	}, nil
 }
 
+// ollamaBaseURL parses an endpoint such as http://gpu-box:11434 or
+// localhost. A bare host gets Ollama's default port.
+func ollamaBaseURL(endpoint string) (*url.URL, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	bare := !strings.Contains(endpoint, "://")
+	if bare {
+		endpoint = "http://" + endpoint
+	}
+
+	base, err := url.Parse(endpoint)
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid Ollama endpoint %q", endpoint)
+	}
+	if bare && base.Port() == "" {
+		base.Host = net.JoinHostPort(base.Hostname(), defaultOllamaPort)
+	}
+	base.Path = strings.TrimSuffix(base.Path, "/")
+	return base, nil
+}
+
 func (b *OllamaBackend) Name() string {
 	return "ollama"
 }
 
 func (b *OllamaBackend) Call(ctx context.Context, prompt string) (string, error) {
+	return b.chat(ctx, prompt, nil)
+}
+
+// CallJSON asks for a response matching schema, or for any JSON value when
+// schema is nil
+func (b *OllamaBackend) CallJSON(ctx context.Context, prompt string, schema json.RawMessage) (string, error) {
+	if schema == nil {
+		schema = json.RawMessage(`"json"`)
+	}
+	return b.chat(ctx, prompt, schema)
+}
+
+func (b *OllamaBackend) chat(ctx context.Context, prompt string, format json.RawMessage) (string, error) {
 	var response strings.Builder
 
-	err := b.client.Generate(ctx, &api.GenerateRequest{
-		Model:  b.config.Model,
-		Prompt: prompt,
-	}, func(gr api.GenerateResponse) error {
-		response.WriteString(gr.Response)
+	req := &api.ChatRequest{
+		Model:    b.config.Model,
+		Messages: []api.Message{{Role: "user", Content: prompt}},
+		Format:   format,
+		Options:  b.options(),
+	}
+	if b.config.KeepAlive != 0 {
+		req.KeepAlive = &api.Duration{Duration: b.config.KeepAlive}
+	}
+
+	err := b.client.Chat(ctx, req, func(cr api.ChatResponse) error {
+		response.WriteString(cr.Message.Content)
 		return nil
 	})
 
@@ -44,3 +101,15 @@ func (b *OllamaBackend) Call(ctx context.Context, prompt string) (string, error)
 	}
 	return response.String(), nil
 }
+
+// options are the model options set in the config; the others keep the
+// model's defaults
+func (b *OllamaBackend) options() map[string]any {
+	options := map[string]any{
+		"temperature": b.config.Temperature,
+	}
+	if b.config.NumCtx > 0 {
+		options["num_ctx"] = b.config.NumCtx
+	}
+	return options
+}
@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ollamaServer streams reply in two chunks from /api/chat, recording the
+// last request body it received
+func ollamaServer(t *testing.T, reply string) (*httptest.Server, map[string]interface{}) {
+	t.Helper()
+	gotBody := make(map[string]interface{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("request body is not JSON: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+		half := len(reply) / 2
+		_ = enc.Encode(map[string]interface{}{"message": map[string]string{"role": "assistant", "content": reply[:half]}})
+		_ = enc.Encode(map[string]interface{}{"message": map[string]string{"role": "assistant", "content": reply[half:]}, "done": true})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, gotBody
+}
+
+func TestOllamaHonorsEndpoint(t *testing.T) {
+	t.Setenv("OLLAMA_HOST", "http://127.0.0.1:1")
+	srv, body := ollamaServer(t, "a summary")
+	b, err := NewOllamaBackend(BackendConfig{Model: "llama3", Endpoint: srv.URL, Temperature: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := b.Call(context.Background(), "summarize")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "a summary" {
+		t.Errorf("Call() = %q, want %q", got, "a summary")
+	}
+	if body["model"] != "llama3" {
+		t.Errorf("model = %v", body["model"])
+	}
+	if _, ok := body["format"]; ok {
+		t.Errorf("format = %v, want none for plain calls", body["format"])
+	}
+	options, _ := body["options"].(map[string]interface{})
+	if options["temperature"] != 0.2 {
+		t.Errorf("temperature = %v, want 0.2", options["temperature"])
+	}
+	if _, ok := options["num_ctx"]; ok {
+		t.Errorf("num_ctx = %v, want the model's default", options["num_ctx"])
+	}
+}
+
+func TestOllamaCallJSON(t *testing.T) {
+	srv, body := ollamaServer(t, `[{"summary":"x"}]`)
+	b, err := NewOllamaBackend(BackendConfig{
+		Model:     "llama3",
+		Endpoint:  srv.URL,
+		NumCtx:    16384,
+		KeepAlive: 10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := json.RawMessage(`{"type":"array"}`)
+	if _, err := b.CallJSON(context.Background(), "document", schema); err != nil {
+		t.Fatal(err)
+	}
+	format, _ := body["format"].(map[string]interface{})
+	if format["type"] != "array" {
+		t.Errorf("format = %v, want the schema", body["format"])
+	}
+	options, _ := body["options"].(map[string]interface{})
+	if options["num_ctx"] != float64(16384) {
+		t.Errorf("num_ctx = %v, want 16384", options["num_ctx"])
+	}
+	if body["keep_alive"] == nil {
+		t.Error("missing keep_alive")
+	}
+
+	if _, err := b.CallJSON(context.Background(), "document", nil); err != nil {
+		t.Fatal(err)
+	}
+	if body["format"] != "json" {
+		t.Errorf("format = %v, want \"json\" without a schema", body["format"])
+	}
+}
+
+func TestOllamaBaseURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"http://gpu-box:11434", "http://gpu-box:11434"},
+		{"https://ollama.example.com/", "https://ollama.example.com"},
+		{"localhost", "http://localhost:11434"},
+		{"10.0.0.5:8080", "http://10.0.0.5:8080"},
+		{"http://proxy/ollama/", "http://proxy/ollama"},
+	}
+	for _, tt := range tests {
+		got, err := ollamaBaseURL(tt.endpoint)
+		if err != nil {
+			t.Errorf("ollamaBaseURL(%q): %v", tt.endpoint, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ollamaBaseURL(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+
+	if _, err := ollamaBaseURL("http://"); err == nil {
+		t.Error("expected an error for an endpoint without a host")
+	}
+}
@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxTokens caps responses when BackendConfig.MaxTokens is unset
+const defaultMaxTokens = 800
+
+// OpenAICompatibleBackend talks to any server implementing the OpenAI
+// /chat/completions protocol, such as vLLM, llama.cpp or LocalAI
+type OpenAICompatibleBackend struct {
+	httpClient  *http.Client
+	config      BackendConfig
+	rateLimiter *rate.Limiter
+}
+
+// NewOpenAICompatibleBackend creates a backend for the server at
+// cfg.Endpoint, the base URL the /chat/completions path is appended to
+// (e.g. http://localhost:8000/v1). A RateLimit of 0 does not limit requests.
+func NewOpenAICompatibleBackend(cfg BackendConfig) (*OpenAICompatibleBackend, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("an endpoint is required for the openai-compatible backend")
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.AuthHeader == "" {
+		cfg.AuthHeader = "Authorization"
+	}
+	if cfg.MaxTokens <= 0 {
+		cfg.MaxTokens = defaultMaxTokens
+	}
+
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if cfg.RateLimit > 0 {
+		limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(cfg.RateLimit)), cfg.RateLimit)
+	}
+
+	return &OpenAICompatibleBackend{
+		httpClient:  &http.Client{Timeout: 120 * time.Second},
+		config:      cfg,
+		rateLimiter: limiter,
+	}, nil
+}
+
+func (b *OpenAICompatibleBackend) Name() string {
+	return "openai-compatible"
+}
+
+func (b *OpenAICompatibleBackend) Call(ctx context.Context, prompt string) (string, error) {
+	if err := b.rateLimiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
+	messages := []map[string]interface{}{
+		{"role": "user", "content": prompt},
+	}
+
+	body := map[string]interface{}{
+		"model":       b.config.Model,
+		"messages":    messages,
+		"max_tokens":  b.config.MaxTokens,
+		"temperature": b.config.Temperature,
+	}
+
+	resp, err := b.callAPI(ctx, body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("API returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+
+	var apiResponse struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(apiResponse.Choices) == 0 {
+		return "", fmt.Errorf("no response from API")
+	}
+
+	return apiResponse.Choices[0].Message.Content, nil
+}
+
+func (b *OpenAICompatibleBackend) callAPI(ctx context.Context, body interface{}) (*http.Response, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", b.config.Endpoint+"/chat/completions", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if b.config.APIKey != "" {
+		if strings.EqualFold(b.config.AuthHeader, "Authorization") {
+			req.Header.Set(b.config.AuthHeader, "Bearer "+b.config.APIKey)
+		} else {
+			req.Header.Set(b.config.AuthHeader, b.config.APIKey)
+		}
+	}
+	for name, value := range b.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	return b.httpClient.Do(req)
+}
@@ -0,0 +1,177 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// chatServer answers /v1/chat/completions with reply, recording the last
+// request it received
+func chatServer(t *testing.T, reply string) (*httptest.Server, *http.Request, map[string]interface{}) {
+	t.Helper()
+	var gotReq http.Request
+	gotBody := make(map[string]interface{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotReq = *r
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("request body is not JSON: %v", err)
+		}
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": reply}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &gotReq, gotBody
+}
+
+func TestOpenAICompatibleCall(t *testing.T) {
+	srv, req, body := chatServer(t, "hello")
+	b, err := NewOpenAICompatibleBackend(BackendConfig{
+		Model:       "qwen2.5-coder",
+		Endpoint:    srv.URL + "/v1/",
+		APIKey:      "secret",
+		Headers:     map[string]string{"X-Tenant": "docs"},
+		Temperature: 0.7,
+		MaxTokens:   2048,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := b.Call(context.Background(), "describe this")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "hello" {
+		t.Errorf("Call() = %q, want %q", got, "hello")
+	}
+
+	if auth := req.Header.Get("Authorization"); auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", auth, "Bearer secret")
+	}
+	if tenant := req.Header.Get("X-Tenant"); tenant != "docs" {
+		t.Errorf("X-Tenant = %q, want %q", tenant, "docs")
+	}
+	if body["model"] != "qwen2.5-coder" {
+		t.Errorf("model = %v", body["model"])
+	}
+	if body["temperature"] != 0.7 {
+		t.Errorf("temperature = %v, want 0.7", body["temperature"])
+	}
+	if body["max_tokens"] != float64(2048) {
+		t.Errorf("max_tokens = %v, want 2048", body["max_tokens"])
+	}
+	messages, _ := body["messages"].([]interface{})
+	if len(messages) != 1 || messages[0].(map[string]interface{})["content"] != "describe this" {
+		t.Errorf("messages = %v", body["messages"])
+	}
+}
+
+func TestOpenAICompatibleDefaults(t *testing.T) {
+	srv, req, body := chatServer(t, "ok")
+	b, err := NewOpenAICompatibleBackend(BackendConfig{Endpoint: srv.URL + "/v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Call(context.Background(), "prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization = %q, want none without an API key", auth)
+	}
+	if body["max_tokens"] != float64(defaultMaxTokens) {
+		t.Errorf("max_tokens = %v, want %d", body["max_tokens"], defaultMaxTokens)
+	}
+}
+
+func TestOpenAICompatibleAuthHeader(t *testing.T) {
+	srv, req, _ := chatServer(t, "ok")
+	b, err := NewOpenAICompatibleBackend(BackendConfig{
+		Endpoint:   srv.URL + "/v1",
+		APIKey:     "secret",
+		AuthHeader: "api-key",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Call(context.Background(), "prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if key := req.Header.Get("api-key"); key != "secret" {
+		t.Errorf("api-key = %q, want the bare key", key)
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization = %q, want none", auth)
+	}
+}
+
+func TestOpenAICompatibleErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/unauthorized/chat/completions":
+			http.Error(w, `{"error":"invalid key"}`, http.StatusUnauthorized)
+		case "/empty/chat/completions":
+			_, _ = w.Write([]byte(`{"choices":[]}`))
+		default:
+			_, _ = w.Write([]byte(`not json`))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/unauthorized", "401"},
+		{"/empty", "no response"},
+		{"/garbage", "failed to parse response"},
+	}
+	for _, tt := range tests {
+		b, err := NewOpenAICompatibleBackend(BackendConfig{Endpoint: srv.URL + tt.path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = b.Call(context.Background(), "prompt")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to mention %q", tt.path, err, tt.want)
+		}
+	}
+}
+
+func TestOpenAICompatibleRequiresEndpoint(t *testing.T) {
+	if _, err := NewOpenAICompatibleBackend(BackendConfig{}); err == nil {
+		t.Error("expected an error without an endpoint")
+	}
+}
+
+func TestOpenRouterHeaders(t *testing.T) {
+	srv, req, _ := chatServer(t, "ok")
+	b := NewOpenRouterBackend(BackendConfig{
+		Endpoint: srv.URL + "/v1",
+		APIKey:   "secret",
+		Headers:  map[string]string{"X-Title": "Custom"},
+	})
+	if b.Name() != "openrouter" {
+		t.Errorf("Name() = %q", b.Name())
+	}
+	if _, err := b.Call(context.Background(), "prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if referer := req.Header.Get("HTTP-Referer"); referer == "" {
+		t.Error("missing HTTP-Referer header")
+	}
+	if title := req.Header.Get("X-Title"); title != "Custom" {
+		t.Errorf("X-Title = %q, want the configured header to win", title)
+	}
+}
@@ -0,0 +1,384 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrNoFixture is returned by a ReplayBackend without a fixture for a prompt
+var ErrNoFixture = errors.New("no fixture for prompt")
+
+// Fixture is a recorded response, stored as <prompt hash>.json. The prompt
+// is kept to tell what a fixture answers when it goes stale.
+type Fixture struct {
+	Backend  string `json:"backend,omitempty"`
+	Model    string `json:"model,omitempty"`
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+}
+
+// PromptHash is the key of the fixture answering prompt
+func PromptHash(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// ReplayBackend answers prompts from the fixtures in cfg.Fixtures, without
+// a model. In synthetic mode, prompts without a fixture get a fabricated
+// response: deterministic text, or a value matching the requested schema
+// whose parameters are those of the signatures in the prompt.
+type ReplayBackend struct {
+	config BackendConfig
+}
+
+func NewReplayBackend(cfg BackendConfig) (*ReplayBackend, error) {
+	if cfg.Fixtures == "" && !cfg.Synthetic {
+		return nil, fmt.Errorf("a fixture directory is required for the replay backend unless it is synthetic")
+	}
+	return &ReplayBackend{config: cfg}, nil
+}
+
+func (b *ReplayBackend) Name() string {
+	return "replay"
+}
+
+func (b *ReplayBackend) Call(ctx context.Context, prompt string) (string, error) {
+	return b.CallJSON(ctx, prompt, nil)
+}
+
+func (b *ReplayBackend) CallJSON(ctx context.Context, prompt string, schema json.RawMessage) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if b.config.Fixtures != "" {
+		fixture, err := readFixture(b.config.Fixtures, prompt)
+		if err == nil {
+			return fixture.Response, nil
+		}
+		if !errors.Is(err, ErrNoFixture) || !b.config.Synthetic {
+			return "", err
+		}
+	}
+
+	if schema == nil {
+		return fmt.Sprintf("Synthetic response to prompt %s.", PromptHash(prompt)[:12]), nil
+	}
+	response, err := SyntheticJSON(schema)
+	if err != nil {
+		return "", err
+	}
+	return withSignatureParams(response, prompt), nil
+}
+
+// RecordBackend wraps a backend and saves each of its responses as a
+// fixture a ReplayBackend can serve
+type RecordBackend struct {
+	backend Backend
+	dir     string
+	model   string
+}
+
+func NewRecordBackend(backend Backend, dir string, cfg BackendConfig) (*RecordBackend, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return &RecordBackend{backend: backend, dir: dir, model: cfg.Model}, nil
+}
+
+func (b *RecordBackend) Name() string {
+	return b.backend.Name()
+}
+
+func (b *RecordBackend) Call(ctx context.Context, prompt string) (string, error) {
+	response, err := b.backend.Call(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	return response, b.record(prompt, response)
+}
+
+func (b *RecordBackend) CallJSON(ctx context.Context, prompt string, schema json.RawMessage) (string, error) {
+	jsonBackend, ok := b.backend.(JSONBackend)
+	if !ok {
+		return b.Call(ctx, prompt)
+	}
+	response, err := jsonBackend.CallJSON(ctx, prompt, schema)
+	if err != nil {
+		return "", err
+	}
+	return response, b.record(prompt, response)
+}
+
+func (b *RecordBackend) record(prompt, response string) error {
+	data, err := json.MarshalIndent(Fixture{
+		Backend:  b.backend.Name(),
+		Model:    b.model,
+		Prompt:   prompt,
+		Response: response,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+	path := filepath.Join(b.dir, PromptHash(prompt)+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+func readFixture(dir, prompt string) (Fixture, error) {
+	hash := PromptHash(prompt)
+	data, err := os.ReadFile(filepath.Join(dir, hash+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Fixture{}, fmt.Errorf("%w %s in %s", ErrNoFixture, hash, dir)
+	}
+	if err != nil {
+		return Fixture{}, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("failed to parse fixture %s: %w", hash, err)
+	}
+	return fixture, nil
+}
+
+// SyntheticJSON fabricates a value matching a JSON schema: every property,
+// minItems array items (at least one), and placeholder strings naming the
+// property they fill. The same schema always gives the same value.
+func SyntheticJSON(schema json.RawMessage) (string, error) {
+	var s map[string]interface{}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		// Not an object schema, e.g. Ollama's "json": any JSON value will do
+		return "{}", nil
+	}
+	data, err := json.Marshal(syntheticValue(s, "value"))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal synthetic response: %w", err)
+	}
+	return string(data), nil
+}
+
+func syntheticValue(schema map[string]interface{}, name string) interface{} {
+	switch schema["type"] {
+	case "object":
+		properties, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(properties))
+		for property := range properties {
+			names = append(names, property)
+		}
+		sort.Strings(names)
+
+		object := make(map[string]interface{}, len(properties))
+		for _, property := range names {
+			propertySchema, _ := properties[property].(map[string]interface{})
+			object[property] = syntheticValue(propertySchema, property)
+		}
+		return object
+	case "array":
+		count := 1
+		if minItems, ok := schema["minItems"].(float64); ok && minItems > 1 {
+			count = int(minItems)
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		array := make([]interface{}, count)
+		for i := range array {
+			array[i] = syntheticValue(items, fmt.Sprintf("%s %d", name, i+1))
+		}
+		return array
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	default:
+		return "synthetic " + name
+	}
+}
+
+// snippetPattern starts each snippet of a batch prompt
+var snippetPattern = regexp.MustCompile(`(?m)^  "Snippet \d+: `)
+
+// withSignatureParams replaces the fabricated parameters of each documented
+// snippet with those of its signature, leaving none for snippets without a
+// parameter list. Other responses are returned as is.
+func withSignatureParams(response, prompt string) string {
+	var items []map[string]interface{}
+	if err := json.Unmarshal([]byte(response), &items); err != nil || len(items) == 0 {
+		return response
+	}
+	if _, ok := items[0]["parameters"]; !ok {
+		return response
+	}
+
+	snippets := snippetPattern.Split(prompt, -1)[1:]
+	for i, item := range items {
+		params := []interface{}{}
+		if i < len(snippets) {
+			for _, p := range signatureParams(snippets[i]) {
+				params = append(params, map[string]interface{}{
+					"name":        p[0],
+					"type":        p[1],
+					"description": "synthetic description of " + p[0],
+				})
+			}
+		}
+		item["parameters"] = params
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return response
+	}
+	return string(data)
+}
+
+// signatureParams lists the name and type of the parameters of the function
+// a snippet declares, or nothing when it declares something else
+func signatureParams(snippet string) [][2]string {
+	snippet = strings.ReplaceAll(snippet, `\"`, `"`)
+	start := strings.Index(snippet, "```")
+	if start < 0 {
+		return nil
+	}
+	lang, code, _ := strings.Cut(snippet[start+3:], "\n")
+	if doc, rest, ok := strings.Cut(code, "\n\n"); ok && strings.HasPrefix(doc, "Documentation from the source:") {
+		// The documentation read from the source comes before the code
+		code = rest
+	}
+
+	var line string
+	for _, l := range strings.Split(code, "\n") {
+		if l = strings.TrimSpace(l); l != "" && !strings.HasPrefix(l, "@") {
+			line = l
+			break
+		}
+	}
+	if strings.HasPrefix(line, "func (") {
+		// Skip the Go receiver
+		if end := closingBracket(line, len("func ")); end > 0 {
+			line = "func " + line[end+1:]
+		}
+	}
+
+	open := -1
+	depth := 0
+	for i, c := range line {
+		switch c {
+		case '[', '<':
+			depth++
+		case ']', '>':
+			depth--
+		case '(':
+			if depth == 0 {
+				open = i
+			}
+		}
+		if open >= 0 {
+			break
+		}
+	}
+	if open <= 0 || !isIdentByte(line[open-1]) && line[open-1] != ']' && line[open-1] != '>' {
+		// Not a name or type parameters before the list, as in "const ("
+		return nil
+	}
+	for _, word := range strings.Fields(line[:open]) {
+		switch word {
+		case "type", "class", "interface", "enum", "struct", "message", "service":
+			return nil
+		}
+	}
+	end := closingBracket(line, open)
+	if end < 0 {
+		return nil
+	}
+
+	var params [][2]string
+	named := false
+	for _, part := range splitTopLevel(line[open+1 : end]) {
+		if part = strings.TrimSpace(part); part != "" {
+			p := parseParam(part, strings.TrimSpace(lang))
+			named = named || p[0] != ""
+			params = append(params, p)
+		}
+	}
+	if named {
+		// Go names sharing a type, as in "a, b int", take the next one's
+		for i := len(params) - 2; i >= 0; i-- {
+			if params[i][0] == "" {
+				params[i] = [2]string{params[i][1], params[i+1][1]}
+			}
+		}
+	}
+	return params
+}
+
+// parseParam splits "name: type = default", "name=default" or "name type";
+// a lone word is a type in Go and Protobuf, and a name elsewhere
+func parseParam(param, lang string) [2]string {
+	if name, typ, ok := strings.Cut(param, ":"); ok {
+		typ, _, _ = strings.Cut(typ, " = ")
+		return [2]string{strings.TrimSuffix(strings.TrimSpace(name), "?"), strings.TrimSpace(typ)}
+	}
+	param, _, _ = strings.Cut(param, "=")
+	param = strings.TrimSpace(param)
+	if name, typ, ok := strings.Cut(param, " "); ok {
+		return [2]string{name, strings.TrimSpace(typ)}
+	}
+	if strings.EqualFold(lang, "go") || strings.EqualFold(lang, "protobuf") {
+		return [2]string{"", param}
+	}
+	return [2]string{param, ""}
+}
+
+// closingBracket is the index of the bracket closing the one at open, or -1
+func closingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits a parameter list on the commas outside brackets
+func splitTopLevel(list string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(list); i++ {
+		switch list[i] {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case '>':
+			// Arrows like "=>" don't close anything
+			if depth > 0 && list[i-1] != '=' {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, list[start:])
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
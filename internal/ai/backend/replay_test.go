@@ -0,0 +1,157 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// stubBackend answers every prompt with its reply
+type stubBackend struct {
+	reply string
+	calls int
+}
+
+func (b *stubBackend) Call(ctx context.Context, prompt string) (string, error) {
+	b.calls++
+	return b.reply, nil
+}
+
+func (b *stubBackend) Name() string {
+	return "stub"
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	stub := &stubBackend{reply: `[{"summary":"recorded"}]`}
+	recorder, err := NewRecordBackend(stub, dir, BackendConfig{Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorder.CallJSON(context.Background(), "document this", json.RawMessage(`{"type":"array"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, PromptHash("document this")+".json"))
+	if err != nil {
+		t.Fatalf("fixture not written: %v", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	if fixture.Backend != "stub" || fixture.Model != "m" || fixture.Prompt != "document this" {
+		t.Errorf("fixture = %+v", fixture)
+	}
+
+	replay, err := NewReplayBackend(BackendConfig{Fixtures: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := replay.Call(context.Background(), "document this")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != stub.reply {
+		t.Errorf("replayed %q, want %q", got, stub.reply)
+	}
+	if stub.calls != 1 {
+		t.Errorf("recorded backend called %d times, want 1", stub.calls)
+	}
+}
+
+func TestReplayMissingFixture(t *testing.T) {
+	replay, err := NewReplayBackend(BackendConfig{Fixtures: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replay.Call(context.Background(), "unknown"); !errors.Is(err, ErrNoFixture) {
+		t.Errorf("error = %v, want ErrNoFixture", err)
+	}
+}
+
+func TestReplayRequiresFixturesUnlessSynthetic(t *testing.T) {
+	if _, err := NewReplayBackend(BackendConfig{}); err == nil {
+		t.Error("expected an error without fixtures")
+	}
+	if _, err := NewReplayBackend(BackendConfig{Synthetic: true}); err != nil {
+		t.Errorf("synthetic backend without fixtures: %v", err)
+	}
+}
+
+func TestSyntheticFallback(t *testing.T) {
+	replay, err := NewReplayBackend(BackendConfig{Fixtures: t.TempDir(), Synthetic: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := replay.Call(context.Background(), "summarize")
+	if err != nil || text == "" {
+		t.Fatalf("Call() = %q, %v", text, err)
+	}
+	again, _ := replay.Call(context.Background(), "summarize")
+	if again != text {
+		t.Errorf("synthetic responses differ: %q vs %q", text, again)
+	}
+
+	schema := json.RawMessage(`{
+		"type": "array", "minItems": 3, "maxItems": 3,
+		"items": {"type": "object", "properties": {
+			"summary": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"count": {"type": "integer"},
+			"deprecated": {"type": "boolean"}
+		}}
+	}`)
+	response, err := replay.CallJSON(context.Background(), "document", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []struct {
+		Summary    string   `json:"summary"`
+		Tags       []string `json:"tags"`
+		Count      int      `json:"count"`
+		Deprecated bool     `json:"deprecated"`
+	}
+	if err := json.Unmarshal([]byte(response), &items); err != nil {
+		t.Fatalf("synthetic response %s does not match the schema: %v", response, err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+	if items[0].Summary == "" || len(items[0].Tags) != 1 {
+		t.Errorf("item = %+v", items[0])
+	}
+}
+
+func TestSignatureParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    [][2]string
+	}{
+		{"go function", "Explain:\n```Go\nfunc Add(a int, opts ...string) (int, error)\n```", [][2]string{{"a", "int"}, {"opts", "...string"}}},
+		{"go shared type", "```go\nfunc Sub(a, b int) int\n```", [][2]string{{"a", "int"}, {"b", "int"}}},
+		{"go method", "```Go\nfunc (s *Store) Get[K comparable](key K, fn func(x, y int) error) string\n```", [][2]string{{"key", "K"}, {"fn", "func(x, y int) error"}}},
+		{"no parameters", "```Go\nfunc Now() int\n```", nil},
+		{"struct", "```Go\ntype Store struct {\n\tGet func(key string)\n}\n```", nil},
+		{"const block", "```Go\nconst (\n\tA = 1\n)\n```", nil},
+		{"python", "```Python\n@app.route(\\\"/\\\")\nasync def fetch(url: str, retries: int = 3, *args) -> bytes: ...\n```", [][2]string{{"url", "str"}, {"retries", "int"}, {"*args", ""}}},
+		{"python class", "```Python\nclass Client(Base):\n    def get(self): ...\n```", nil},
+		{"typescript", "```TypeScript\nexport function map<T, U>(items: T[], fn?: (item: T) => U, opts: Map<string, number> = new Map()): U[]\n```", [][2]string{{"items", "T[]"}, {"fn", "(item: T) => U"}, {"opts", "Map<string, number>"}}},
+		{"protobuf", "```Protobuf\nrpc Get(GetRequest) returns (GetReply);\n```", [][2]string{{"", "GetRequest"}}},
+		{"source documentation", "```Go\nDocumentation from the source:\nAdds (a + b)\n@param a first\n\nfunc Add(a int) int\n```", [][2]string{{"a", "int"}}},
+		{"no code fence", "Adds two numbers (a and b)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signatureParams(tt.snippet); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signatureParams() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package ai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/backend"
 	docType "github.com/MRGHOSJ/docupocus/internal/ai/types"
 )
 
@@ -178,7 +180,7 @@ func (c *Client) callBatchAPI(ctx context.Context, inputs, languages []string, i
 
 	combinedPrompt := c.buildBatchPromptCodeAssistant(prompts)
 
-	response, err := c.backend.Call(ctx, combinedPrompt)
+	response, err := c.callJSON(ctx, combinedPrompt, batchSchema(documentationSchema, len(prompts)))
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +211,7 @@ func (c *Client) callBatchYamlAPI(ctx context.Context, inputs, languages []strin
 
 	combinedPrompt := c.buildBatchPromptYamlDocumentation(prompts)
 
-	response, err := c.backend.Call(ctx, combinedPrompt)
+	response, err := c.callJSON(ctx, combinedPrompt, batchSchema(yamlDocumentationSchema, len(prompts)))
 	if err != nil {
 		return nil, err
 	}
@@ -218,3 +220,12 @@ func (c *Client) callBatchYamlAPI(ctx context.Context, inputs, languages []strin
 
 	return c.parseYAMLBatchResponse(response, len(prompts))
 }
+
+// callJSON sends a prompt answered with JSON, constrained to schema when the
+// backend supports it
+func (c *Client) callJSON(ctx context.Context, prompt string, schema json.RawMessage) (string, error) {
+	if b, ok := c.backend.(ai.JSONBackend); ok {
+		return b.CallJSON(ctx, prompt, schema)
+	}
+	return c.backend.Call(ctx, prompt)
+}
@@ -18,9 +18,12 @@ type Client struct {
 }
 
 func NewClient(backend ai.Backend, cfg ai.BackendConfig) *Client {
+	if cfg.CacheDir == "" {
+		cfg.CacheDir = "ai-cache"
+	}
 	return &Client{
 		backend: backend,
-		cache:   aiCache.NewCache("ai-cache"),
+		cache:   aiCache.NewCache(cfg.CacheDir),
 		logger:  NewStdLogger(),
 		config:  cfg,
 	}
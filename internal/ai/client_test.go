@@ -0,0 +1,69 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/backend"
+)
+
+// syntheticClient documents inputs with the synthetic replay backend
+func syntheticClient(t *testing.T) *Client {
+	t.Helper()
+	cfg := ai.BackendConfig{Synthetic: true, CacheDir: t.TempDir()}
+	backend, err := ai.NewReplayBackend(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(backend, cfg)
+	client.ApplyDefaults()
+	return client
+}
+
+func TestEnhanceDocumentationBatchOffline(t *testing.T) {
+	inputs := []string{
+		"func Add(a, b int) int { return a + b }",
+		"func Sub(a, b int) int { return a - b }",
+		"func Mul(a, b int) int { return a * b }",
+		"func Div(a, b int) int { return a / b }",
+		"func Now() int { return 0 }",
+	}
+	languages := []string{"go", "go", "go", "go", "go"}
+
+	docs, err := syntheticClient(t).EnhanceDocumentationBatch(context.Background(), inputs, languages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != len(inputs) {
+		t.Fatalf("got %d docs, want %d", len(docs), len(inputs))
+	}
+	for i, doc := range docs {
+		if doc.Summary == "" || len(doc.EdgeCases) == 0 {
+			t.Errorf("doc %d is incomplete: %+v", i, doc)
+		}
+		var params []string
+		for _, p := range doc.Parameters {
+			params = append(params, p.Name+" "+p.Type)
+		}
+		want := []string{"a int", "b int"}
+		if i == len(inputs)-1 {
+			want = nil
+		}
+		if !reflect.DeepEqual(params, want) {
+			t.Errorf("doc %d parameters = %q, want %q", i, params, want)
+		}
+	}
+}
+
+func TestEnhanceYAMLDocumentationBatchOffline(t *testing.T) {
+	inputs := []string{"server:\n  port: 8080\n  host: localhost"}
+
+	docs, err := syntheticClient(t).EnhanceYAMLDocumentationBatch(context.Background(), inputs, []string{"yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].Summary == "" || len(docs[0].Fields) == 0 {
+		t.Errorf("docs = %+v", docs)
+	}
+}
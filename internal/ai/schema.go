@@ -0,0 +1,68 @@
+package ai
+
+import "encoding/json"
+
+// documentationSchema is the JSON schema of a docType.Documentation the
+// model writes; Throws and Deprecated come from source comments instead
+var documentationSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"summary": map[string]interface{}{"type": "string"},
+		"parameters": map[string]interface{}{
+			"type":  "array",
+			"items": namedItemSchema,
+		},
+		"returns":          map[string]interface{}{"type": "string"},
+		"time_complexity":  map[string]interface{}{"type": "string"},
+		"space_complexity": map[string]interface{}{"type": "string"},
+		"usage_example":    map[string]interface{}{"type": "string"},
+		"edge_cases": map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		},
+	},
+	"required": []string{"summary", "parameters", "returns", "time_complexity", "space_complexity", "usage_example", "edge_cases"},
+}
+
+// yamlDocumentationSchema is the JSON schema of a docType.YAMLDocumentation
+var yamlDocumentationSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"summary": map[string]interface{}{"type": "string"},
+		"fields": map[string]interface{}{
+			"type":  "array",
+			"items": namedItemSchema,
+		},
+		"examples": map[string]interface{}{"type": "object"},
+		"defaults": map[string]interface{}{"type": "object"},
+		"usage":    map[string]interface{}{"type": "string"},
+		"best_practices": map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		},
+	},
+	"required": []string{"summary", "fields"},
+}
+
+// namedItemSchema is a parameter or a YAML field
+var namedItemSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"name":        map[string]interface{}{"type": "string"},
+		"type":        map[string]interface{}{"type": "string"},
+		"description": map[string]interface{}{"type": "string"},
+	},
+	"required": []string{"name", "type", "description"},
+}
+
+// batchSchema is the schema of a response to a batch: an array of exactly
+// count items
+func batchSchema(item map[string]interface{}, count int) json.RawMessage {
+	schema, _ := json.Marshal(map[string]interface{}{
+		"type":     "array",
+		"items":    item,
+		"minItems": count,
+		"maxItems": count,
+	})
+	return schema
+}
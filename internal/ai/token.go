@@ -4,23 +4,35 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"sync"
 
 	tiktoken "github.com/pkoukk/tiktoken-go"
 )
 
-var enc *tiktoken.Tiktoken
+var (
+	enc     *tiktoken.Tiktoken
+	encOnce sync.Once
+)
 
-func init() {
-	var err error
-	enc, err = tiktoken.EncodingForModel("gpt-3.5-turbo")
-	if err != nil {
-		log.Fatalf("Failed to initialize tokenizer: %v", err)
-	}
+// encoder loads the tokenizer on first use. It is nil when the encoding can
+// be neither downloaded nor found in TIKTOKEN_CACHE_DIR, e.g. offline.
+func encoder() *tiktoken.Tiktoken {
+	encOnce.Do(func() {
+		var err error
+		enc, err = tiktoken.EncodingForModel("gpt-3.5-turbo")
+		if err != nil {
+			log.Printf("⚠️  Tokenizer unavailable, estimating token counts instead: %v", err)
+		}
+	})
+	return enc
 }
 
 // CountTokens returns the token count of input text
 func CountTokens(text string) int {
-	tokens := enc.Encode(text, nil, nil)
+	var tokens []int
+	if e := encoder(); e != nil {
+		tokens = e.Encode(text, nil, nil)
+	}
 	if tokens == nil {
 		return len([]rune(text)) / 4 // fallback guess
 	}
@@ -9,6 +9,11 @@ type Documentation struct {
 	SpaceComplexity string   `json:"space_complexity"`
 	UsageExample    string   `json:"usage_example"`
 	EdgeCases       []string `json:"edge_cases"`
+
+	// Taken from source doc comments (JSDoc @throws and @deprecated)
+	Throws          []string `json:"throws,omitempty"`
+	Deprecated      bool     `json:"deprecated,omitempty"`
+	DeprecationNote string   `json:"deprecation_note,omitempty"`
 }
 
 // Param describes a function parameter
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -9,8 +10,30 @@ import (
 )
 
 type Analyzer interface {
-	Analyze(projectDir string) (*AnalyzerResult, error)
-	Supports(projectDir string) bool
+	Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error)
+	Supports(projectDir string, opts Options) bool
+}
+
+// Options tunes how AnalyzeProject inspects a project
+type Options struct {
+	// GoTypeCheck loads Go code through go/packages with full type
+	// information instead of parsing each file on its own
+	GoTypeCheck bool
+
+	// Include limits analysis to files matching these gitignore-style globs,
+	// relative to the project directory. Empty means every file.
+	Include []string
+	// Exclude skips files and directories matching these globs
+	Exclude []string
+	// OutputDir is the docs output directory, which is never analyzed
+	OutputDir string
+	// Workers bounds how many files are parsed concurrently. Zero means one
+	// worker per CPU.
+	Workers int
+	// Previous holds the results of an earlier run. Files whose content hash
+	// is unchanged are reused instead of parsed again, as long as the same
+	// analyzer produced them.
+	Previous []*AnalyzedFile
 }
 
 // AnalyzerResult holds the results of the analysis
@@ -20,24 +43,108 @@ type AnalyzerResult struct {
 
 type AnalyzedFile struct {
 	Path     string
-	Packages []Package // Replace this with actual extracted structs/functions
+	Language string            // overrides the language detected from the extension, e.g. "OpenAPI"
+	Packages []Package         // Replace this with actual extracted structs/functions
+	Hashes   map[string]string // Content hash of each source file
+	Analyzer string            // analyzer that produced the file, e.g. "TSAnalyzer"
+
+	// Reused is set when the file was taken unchanged from a previous run
+	Reused bool `json:"-"`
+}
+
+// Sources lists the source files behind an AnalyzedFile. Aggregated packages
+// record their files in Package.Files; everything else is a single file.
+func (f *AnalyzedFile) Sources() []string {
+	var sources []string
+	for _, pkg := range f.Packages {
+		sources = append(sources, pkg.Files...)
+	}
+	if len(sources) == 0 {
+		return []string{f.Path}
+	}
+	return sources
 }
 
 type Package struct {
-	Name    string
-	Path    string
-	Imports []string
-	Structs []Struct
-	Funcs   []Function
-	Files   []string
+	Name       string
+	ImportPath string
+	Path       string
+	Imports    []string
+	Structs    []Struct
+	Interfaces []Interface
+	Types      []TypeDef
+	Enums      []Enum
+	Consts     []ValueGroup
+	Vars       []ValueGroup
+	Funcs      []Function
+	Files      []string
 }
 
 type Struct struct {
-	Name    string
-	Fields  []Field
-	Methods []Function
-	Doc     ai.Documentation
-	DocYAML ai.YAMLDocumentation
+	Name       string
+	TypeParams []Parameter
+	Fields     []Field
+	Methods    []Function
+	Promoted   []Function // methods promoted from embedded fields (type-checked mode)
+	Implements []string   // interfaces satisfied by the type (type-checked mode, TypeScript)
+	Bases      []string   // base classes (Python, TypeScript), or the image a build stage starts from (Dockerfile)
+	Decorators []string   // decorators without the leading "@" (Python, TypeScript)
+	Modifiers  []string   // e.g. "abstract" (TypeScript)
+	Exported   bool       // exported from its module (JavaScript, TypeScript)
+	Kind       string     // what the struct documents, e.g. "schema" (OpenAPI), "job" (GitHub Actions), "target" (Makefile), "chart" (Helm), "play" (Ansible)
+	Labels     []string   // block type and labels, e.g. resource "aws_instance" "web" (HCL)
+	Renders    []string   // kinds of the resources a template renders, e.g. "Deployment" (Helm)
+	DependsOn  []string   // resources this one references, e.g. "Service/web" (Kubernetes)
+	UsedBy     []string   // resources referencing this one (Kubernetes)
+	Doc        ai.Documentation
+	DocYAML    ai.YAMLDocumentation
+}
+
+// Interface describes an interface type and its method set
+type Interface struct {
+	Name            string
+	TypeParams      []Parameter
+	Fields          []Field // property signatures (TypeScript)
+	Methods         []Function
+	Embeds          []string
+	Implementations []string // concrete types satisfying the interface (type-checked mode)
+	Exported        bool     // exported from its module (TypeScript)
+	Doc             ai.Documentation
+}
+
+// TypeDef describes a named non-struct type or a type alias
+type TypeDef struct {
+	Name       string
+	TypeParams []Parameter
+	Underlying string
+	Alias      bool
+	Methods    []Function
+	Implements []string // interfaces satisfied by the type (type-checked mode)
+	Exported   bool     // exported from its module (TypeScript)
+	Doc        ai.Documentation
+}
+
+// ValueGroup describes a const or var declaration block
+type ValueGroup struct {
+	Kind   string // "const" or "var"
+	Values []Value
+	Doc    ai.Documentation
+}
+
+// Enum describes an enumeration and its members (TypeScript)
+type Enum struct {
+	Name     string
+	Const    bool
+	Members  []Value
+	Exported bool
+	Doc      ai.Documentation
+}
+
+type Value struct {
+	Name  string
+	Type  string
+	Value string
+	Doc   ai.Documentation
 }
 
 type Field struct {
@@ -48,20 +155,37 @@ type Field struct {
 	DocYAML ai.YAMLDocumentation
 	Value   string
 	Fields  []Field
+
+	Modifiers []string // e.g. "private", "readonly", "static" (TypeScript)
+	DefinedIn string   // where a variable is defined, e.g. "defaults" or "vars" (Ansible)
+	Unused    bool     // defined but never referenced, e.g. a value no template reads (Helm)
+	Origin    string   // alias or merge key the value came from, e.g. "<<: *defaults" (YAML)
 }
 
 type Function struct {
 	Name       string
 	Receiver   string
+	TypeParams []Parameter
 	Parameters []Parameter
 	Results    []Parameter
+	Decorators []string // without the leading "@" (Python, TypeScript)
+	Modifiers  []string // e.g. "private", "static", "abstract", "get" (TypeScript)
+	Async      bool
+	Exported   bool     // exported from its module (JavaScript, TypeScript)
+	Security   []string // security requirements of an API operation (OpenAPI)
 	Doc        ai.Documentation
 	Calls      []string
 }
 
 type Parameter struct {
-	Name string
-	Type string
+	Name    string
+	Type    string
+	Default string // default value expression, if any
+
+	// API operation parameters and responses (OpenAPI)
+	In          string // path, query, header, cookie, formData or body
+	Required    bool
+	Description string
 }
 
 var analyzers []Analyzer
@@ -70,36 +194,102 @@ func init() {
 	analyzers = []Analyzer{
 		&GoAnalyzer{},
 		&PythonAnalyzer{},
+		&TSAnalyzer{},
 		&JSAnalyzer{},
+		&OpenAPIAnalyzer{},
+		&HelmAnalyzer{},
+		&AnsibleAnalyzer{},
 		&YAMLAnalyzer{},
+		&HCLAnalyzer{},
+		&ProtoAnalyzer{},
+		&DockerfileAnalyzer{},
+		&MakefileAnalyzer{},
 		// More...
 	}
 }
 
-func AnalyzeProject(projectDir string) (*AnalyzerResult, error) {
+// AnalyzeProject runs every analyzer that supports projectDir and merges their
+// results into a single AnalyzerResult. Analyzers are consulted in registration
+// order and each file is claimed by the first analyzer that reports it, so a
+// file is never documented twice.
+func AnalyzeProject(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	result := &AnalyzerResult{}
+	claimed := make(map[string]string)
+	matched := 0
+
 	for _, a := range analyzers {
 		analyzerName := strings.TrimPrefix(fmt.Sprintf("%T", a), "*analyzer.")
 		fmt.Printf("🔍 Checking with %s analyzer... ", analyzerName)
 
-		if a.Supports(projectDir) {
-			fmt.Printf("MATCH\n")
-			result, err := a.Analyze(projectDir)
-			if err != nil {
-				fmt.Printf("❌ Analysis failed: %v\n", err)
-				return nil, err
-			}
+		if !a.Supports(projectDir, opts) {
+			fmt.Printf("skip\n")
+			continue
+		}
+
+		fmt.Printf("MATCH\n")
+		matched++
+
+		analyzerOpts := opts
+		analyzerOpts.Previous = producedBy(opts.Previous, analyzerName)
+		partial, err := a.Analyze(ctx, projectDir, analyzerOpts)
+		if err != nil {
+			fmt.Printf("❌ Analysis failed: %v\n", err)
+			return nil, fmt.Errorf("%s analyzer: %w", analyzerName, err)
+		}
 
-			fmt.Printf("\n📦 Analysis Results (%d files):\n", len(result.Files))
-			for _, file := range result.Files {
-				yamlPrintFileAnalysis(file)
+		var files []*AnalyzedFile
+		for _, file := range partial.Files {
+			if owner, ok := claimedBy(claimed, file); ok {
+				fmt.Printf("  ↪ %s already claimed by %s analyzer, skipping\n", file.Path, owner)
+				continue
 			}
-			return result, nil
-		} else {
-			fmt.Printf("skip\n")
+			for _, src := range file.Sources() {
+				claimed[src] = analyzerName
+			}
+			file.Analyzer = analyzerName
+			if err := hashSources(file); err != nil {
+				return nil, fmt.Errorf("failed to hash %s: %w", file.Path, err)
+			}
+			files = append(files, file)
 		}
+
+		fmt.Printf("\n📦 Analysis Results (%d files):\n", len(files))
+		for _, file := range files {
+			yamlPrintFileAnalysis(file)
+		}
+		result.Merge(&AnalyzerResult{Files: files})
+	}
+
+	if matched == 0 {
+		return nil, fmt.Errorf("no analyzer found for project in: %s", projectDir)
 	}
-	return nil, fmt.Errorf("no analyzer found for project in: %s", projectDir)
+	linkKubernetesResources(result)
+	return result, nil
 }
+
+// producedBy returns the previous results of the named analyzer. A file that
+// another analyzer handled last time, e.g. a .ts file parsed before
+// TSAnalyzer took over, is analyzed afresh.
+func producedBy(previous []*AnalyzedFile, analyzerName string) []*AnalyzedFile {
+	var files []*AnalyzedFile
+	for _, file := range previous {
+		if file.Analyzer == analyzerName {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// claimedBy reports which analyzer, if any, already owns one of file's sources
+func claimedBy(claimed map[string]string, file *AnalyzedFile) (string, bool) {
+	for _, src := range file.Sources() {
+		if owner, ok := claimed[src]; ok {
+			return owner, true
+		}
+	}
+	return "", false
+}
+
 func yamlPrintFileAnalysis(file *AnalyzedFile) {
 	fmt.Printf("\n📄 %s\n", filepath.Base(file.Path))
 
@@ -0,0 +1,453 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ansiblePackageName = "Ansible"
+	ansibleImportPath  = "ansible"
+)
+
+// AnsibleAnalyzer documents Ansible playbooks and roles. Each play of a
+// playbook becomes a Struct of kind "play" and each import_playbook one of
+// kind "import"; playbooks share the Ansible page. A role directory is one
+// unit: a Struct of kind "role" with its meta/main.yml, one of kind
+// "variables" with its defaults/ and vars/, and one of kind "tasks" or
+// "handlers" per task file.
+// Tasks are fields whose Type is their module, with "tags", "notify" and
+// "when" as nested fields.
+type AnsibleAnalyzer struct{}
+
+// roleDirs are the directories of a role layout
+var roleDirs = map[string]bool{"tasks": true, "handlers": true, "defaults": true, "vars": true, "meta": true}
+
+// taskKeywords are the task keys that are not the module being called
+var taskKeywords = map[string]bool{
+	"name": true, "tags": true, "when": true, "notify": true, "register": true, "become": true,
+	"become_user": true, "become_method": true, "loop": true, "loop_control": true, "vars": true,
+	"ignore_errors": true, "changed_when": true, "failed_when": true, "delegate_to": true,
+	"run_once": true, "environment": true, "no_log": true, "listen": true, "args": true,
+	"retries": true, "delay": true, "until": true, "check_mode": true, "diff": true,
+	"any_errors_fatal": true, "timeout": true, "throttle": true, "async": true, "poll": true,
+	"collections": true, "module_defaults": true, "debugger": true, "connection": true,
+	"ignore_unreachable": true, "remote_user": true, "delegate_facts": true,
+}
+
+func (a *AnsibleAnalyzer) Supports(projectDir string, opts Options) bool {
+	roles, _, playbooks, err := a.sources(projectDir, opts)
+	return err == nil && (len(roles) > 0 || len(playbooks) > 0)
+}
+
+func (a *AnsibleAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	roles, sources, playbooks, err := a.sources(projectDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reused, remaining := reuseUnits("Ansible", roles, sources, opts.Previous)
+	analyzed, err := analyzeFiles(ctx, remaining, opts, func(dir string) (*AnalyzedFile, error) {
+		return a.analyzeRole(dir, sources[dir])
+	})
+	if err != nil {
+		return nil, err
+	}
+	byDir := make(map[string]*AnalyzedFile)
+	for _, file := range append(reused, analyzed...) {
+		byDir[file.Path] = file
+	}
+
+	result := &AnalyzerResult{}
+	for _, dir := range roles {
+		result.Files = append(result.Files, byDir[dir])
+	}
+	files, err := analyzeFiles(ctx, playbooks, opts, a.analyzePlaybook)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, files...)
+	return result, nil
+}
+
+// sources finds the role directories with their YAML files, and the
+// playbooks outside of any role
+func (a *AnsibleAnalyzer) sources(projectDir string, opts Options) ([]string, map[string][]string, []string, error) {
+	paths, err := walkFiles(projectDir, opts, ".yaml", ".yml")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var roles []string
+	for _, path := range paths {
+		if dir, ok := roleDirOf(path); ok && !containsString(roles, dir) {
+			roles = append(roles, dir)
+		}
+	}
+
+	sources := make(map[string][]string)
+	var playbooks []string
+	for _, path := range paths {
+		if dir, ok := roleFileOf(path, roles); ok {
+			sources[dir] = append(sources[dir], path)
+			continue
+		}
+		if isPlaybookFile(path) {
+			playbooks = append(playbooks, path)
+		}
+	}
+	return roles, sources, playbooks, nil
+}
+
+// roleDirOf recognizes the main.yml of a role directory, such as
+// roles/web/tasks/main.yml, and returns the role directory
+func roleDirOf(path string) (string, bool) {
+	base := filepath.Base(path)
+	if base != "main.yml" && base != "main.yaml" {
+		return "", false
+	}
+	sub := filepath.Dir(path)
+	if !roleDirs[filepath.Base(sub)] {
+		return "", false
+	}
+	dir := filepath.Dir(sub)
+	// Outside roles/, only a tasks/main.yml makes a directory a role
+	if filepath.Base(filepath.Dir(dir)) != "roles" && filepath.Base(sub) != "tasks" {
+		return "", false
+	}
+	return dir, true
+}
+
+// roleFileOf returns the role a file belongs to, if it sits in one of the
+// role's layout directories
+func roleFileOf(path string, roles []string) (string, bool) {
+	for _, dir := range roles {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) > 1 && roleDirs[parts[0]] {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// isPlaybookFile reports whether a YAML file is a list of plays
+func isPlaybookFile(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil || len(root.Content) == 0 {
+		return false
+	}
+	limitYAMLAliases(&root)
+	return (&YAMLAnalyzer{}).isAnsiblePlaybook(root.Content[0])
+}
+
+// isAnsiblePlaybook reports whether node is a list of plays, each with
+// hosts, or imports of other playbooks
+func (y *YAMLAnalyzer) isAnsiblePlaybook(node *yaml.Node) bool {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return false
+	}
+	for _, play := range node.Content {
+		play = resolveYAMLAlias(play)
+		if play.Kind != yaml.MappingNode {
+			return false
+		}
+		if !y.hasKey(play, "hosts") && !y.hasKey(play, "import_playbook") && !y.hasKey(play, "ansible.builtin.import_playbook") {
+			return false
+		}
+	}
+	return true
+}
+
+// analyzePlaybook documents every play of a playbook
+func (a *AnsibleAnalyzer) analyzePlaybook(path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	limitYAMLAliases(&root)
+
+	y := &YAMLAnalyzer{}
+	pkg := Package{Name: ansiblePackageName, ImportPath: ansibleImportPath, Path: path}
+	if len(root.Content) == 0 {
+		return nil, fmt.Errorf("%s: empty playbook", path)
+	}
+	comment := y.extractYAMLComment(&root)
+	for i, play := range root.Content[0].Content {
+		play = resolveYAMLAlias(play)
+		s := y.analyzePlay(play, i)
+		if i == 0 && s.Doc.Summary == "" {
+			// The comment at the top of the file describes the first play
+			s.Doc.Summary = comment
+		}
+		pkg.Structs = append(pkg.Structs, s)
+	}
+
+	return &AnalyzedFile{
+		Path:     path,
+		Language: "Ansible",
+		Packages: []Package{pkg},
+	}, nil
+}
+
+// analyzePlay documents one play: its hosts, variables, roles, tasks and handlers
+func (y *YAMLAnalyzer) analyzePlay(play *yaml.Node, index int) Struct {
+	for _, key := range []string{"import_playbook", "ansible.builtin.import_playbook"} {
+		if imported := y.getValue(play, key); imported != "" {
+			return Struct{
+				Name: imported,
+				Kind: "import",
+				Doc:  ai.Documentation{Summary: y.getValue(play, "name")},
+			}
+		}
+	}
+
+	name := y.getValue(play, "name")
+	if name == "" {
+		name = fmt.Sprintf("play %d", index+1)
+	}
+	// A comment right above "- name:" belongs to the play itself
+	s := Struct{Name: name, Kind: "play", Doc: ai.Documentation{Summary: yamlComment(play.HeadComment)}}
+
+	for _, pair := range y.mappingPairs(play) {
+		key, value := pair.Key, resolveYAMLAlias(pair.Value)
+		if key.Value == "name" {
+			if comment := fieldComment(key, pair.Value); comment != "" {
+				s.Doc.Summary = comment
+			}
+			continue
+		}
+		field := Field{
+			Name:    key.Value,
+			Type:    y.nodeKindToString(value.Kind),
+			DocYAML: ai.YAMLDocumentation{Summary: fieldComment(key, pair.Value)},
+			Origin:  pair.Origin,
+		}
+		switch key.Value {
+		case "pre_tasks", "tasks", "post_tasks", "handlers":
+			field.Fields = y.ansibleTasks(value, nil)
+		case "vars":
+			field.Fields = y.ansibleVariables(value, "vars")
+		case "roles":
+			field.Fields = y.ansibleRoles(value)
+		case "hosts", "tags", "vars_files":
+			field.Value = strings.Join(ansibleList(value), ", ")
+		default:
+			if value.Kind == yaml.ScalarNode {
+				field.Value = value.Value
+			} else {
+				field.Fields = y.extractYAMLFields(value, 1)
+			}
+		}
+		s.Fields = append(s.Fields, field)
+	}
+	return s
+}
+
+// ansibleRoles lists the roles a play applies, in the short or the mapping form
+func (y *YAMLAnalyzer) ansibleRoles(node *yaml.Node) []Field {
+	var roles []Field
+	for _, item := range node.Content {
+		item = resolveYAMLAlias(item)
+		if item.Kind == yaml.ScalarNode {
+			roles = append(roles, Field{Name: item.Value, Type: "role"})
+			continue
+		}
+		name := y.getValue(item, "role")
+		if name == "" {
+			name = y.getValue(item, "name")
+		}
+		role := Field{Name: name, Type: "role"}
+		if tags := y.getValueNode(item, "tags"); tags != nil {
+			role.Fields = append(role.Fields, Field{Name: "tags", Type: "array", Value: strings.Join(ansibleList(tags), ", ")})
+		}
+		if when := y.getValue(item, "when"); when != "" {
+			role.Fields = append(role.Fields, Field{Name: "when", Type: "scalar", Value: when})
+		}
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+// ansibleTasks lists the tasks of a task file or play section. Tasks inside
+// block, rescue and always are listed in order and inherit the block's tags.
+func (y *YAMLAnalyzer) ansibleTasks(node *yaml.Node, inherited []string) []Field {
+	var tasks []Field
+	for _, item := range resolveYAMLAlias(node).Content {
+		item = resolveYAMLAlias(item)
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+
+		tags := inherited
+		if node := y.getValueNode(item, "tags"); node != nil {
+			tags = append(append([]string{}, inherited...), ansibleList(node)...)
+		}
+		if y.hasKey(item, "block") {
+			for _, section := range []string{"block", "rescue", "always"} {
+				if children := y.getValueNode(item, section); children != nil {
+					tasks = append(tasks, y.ansibleTasks(children, tags)...)
+				}
+			}
+			continue
+		}
+
+		task := Field{Name: y.getValue(item, "name"), Type: "task"}
+		for _, pair := range y.mappingPairs(item) {
+			key, value := pair.Key, resolveYAMLAlias(pair.Value)
+			switch {
+			case key.Value == "name":
+				task.DocYAML.Summary = fieldComment(key, pair.Value)
+			case key.Value == "notify" || key.Value == "listen":
+				task.Fields = append(task.Fields, Field{Name: key.Value, Type: "array", Value: strings.Join(ansibleList(value), ", ")})
+			case key.Value == "when":
+				task.Fields = append(task.Fields, Field{Name: "when", Type: "scalar", Value: strings.Join(ansibleList(value), " and ")})
+			case !taskKeywords[key.Value] && !strings.HasPrefix(key.Value, "with_") && task.Type == "task":
+				// The first other key is the module, e.g. ansible.builtin.copy
+				task.Type = key.Value
+				if value.Kind == yaml.ScalarNode {
+					task.Value = value.Value
+				} else {
+					task.Value = moduleArgs(y, value)
+				}
+			}
+		}
+		if len(tags) > 0 {
+			task.Fields = append(task.Fields, Field{Name: "tags", Type: "array", Value: strings.Join(tags, ", ")})
+		}
+		if task.Name == "" {
+			// Unnamed tasks are known by their module
+			task.Name = task.Type
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// moduleArgs summarizes the arguments of a module call, e.g. "src=a dest=b"
+func moduleArgs(y *YAMLAnalyzer, node *yaml.Node) string {
+	var args []string
+	for _, pair := range y.mappingPairs(node) {
+		value := resolveYAMLAlias(pair.Value)
+		if value.Kind == yaml.ScalarNode {
+			args = append(args, pair.Key.Value+"="+value.Value)
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// ansibleVariables lists the variables of a vars mapping with their default
+// values; source records where they are defined, e.g. "defaults"
+func (y *YAMLAnalyzer) ansibleVariables(node *yaml.Node, source string) []Field {
+	var vars []Field
+	for _, pair := range y.mappingPairs(resolveYAMLAlias(node)) {
+		value := resolveYAMLAlias(pair.Value)
+		vars = append(vars, Field{
+			Name:      pair.Key.Value,
+			Type:      yamlValueType(value),
+			Value:     yamlDefault(value),
+			DocYAML:   ai.YAMLDocumentation{Summary: fieldComment(pair.Key, pair.Value)},
+			Origin:    pair.Origin,
+			DefinedIn: source,
+		})
+	}
+	return vars
+}
+
+// ansibleList reads a scalar, or a list of scalars, as a list
+func ansibleList(node *yaml.Node) []string {
+	node = resolveYAMLAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		if node.Value == "" {
+			return nil
+		}
+		return []string{node.Value}
+	}
+	var items []string
+	for _, item := range node.Content {
+		if item = resolveYAMLAlias(item); item.Kind == yaml.ScalarNode {
+			items = append(items, item.Value)
+		}
+	}
+	return items
+}
+
+// analyzeRole documents a role directory as one unit
+func (a *AnsibleAnalyzer) analyzeRole(dir string, sources []string) (*AnalyzedFile, error) {
+	y := &YAMLAnalyzer{}
+	name := filepath.Base(dir)
+	role := Struct{Name: name, Kind: "role"}
+	vars := Struct{Name: "variables", Kind: "variables"}
+	var taskFiles []Struct
+
+	for _, src := range sources {
+		content, err := os.ReadFile(src)
+		if err != nil {
+			return nil, err
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(content, &root); err != nil {
+			return nil, fmt.Errorf("%s: %w", src, err)
+		}
+		limitYAMLAliases(&root)
+		if len(root.Content) == 0 {
+			continue
+		}
+		node := root.Content[0]
+		rel, _ := filepath.Rel(dir, src)
+		rel = filepath.ToSlash(rel)
+
+		switch sub := strings.Split(rel, "/")[0]; sub {
+		case "meta":
+			role.Doc.Summary = y.extractYAMLComment(&root)
+			if info := y.getValueNode(node, "galaxy_info"); info != nil && role.Doc.Summary == "" {
+				role.Doc.Summary = y.getValue(info, "description")
+			}
+			role.Fields = append(role.Fields, y.extractYAMLFields(node, 0)...)
+		case "defaults", "vars":
+			vars.Fields = append(vars.Fields, y.ansibleVariables(node, sub)...)
+		case "tasks", "handlers":
+			taskFiles = append(taskFiles, Struct{
+				Name:   rel,
+				Kind:   sub,
+				Doc:    ai.Documentation{Summary: y.extractYAMLComment(&root)},
+				Fields: y.ansibleTasks(node, nil),
+			})
+		}
+	}
+
+	pkg := Package{
+		Name:       name,
+		ImportPath: ansibleImportPath + "/roles/" + name,
+		Path:       dir,
+		Files:      sources,
+		Structs:    []Struct{role},
+	}
+	if len(vars.Fields) > 0 {
+		pkg.Structs = append(pkg.Structs, vars)
+	}
+	pkg.Structs = append(pkg.Structs, taskFiles...)
+
+	return &AnalyzedFile{
+		Path:     dir,
+		Language: "Ansible",
+		Packages: []Package{pkg},
+	}, nil
+}
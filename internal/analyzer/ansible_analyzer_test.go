@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// taskSummary flattens a task into its name, module and nested settings
+func taskSummary(task Field) []string {
+	summary := []string{task.Name, task.Type}
+	for _, f := range task.Fields {
+		summary = append(summary, f.Name+"="+f.Value)
+	}
+	return summary
+}
+
+func TestAnsiblePlaybook(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "site.yml", `# Configures the fleet
+- name: Web servers
+  hosts: [web, api]
+  become: true
+  vars:
+    port: 8080 # Listen port
+  roles:
+    - common
+    - {role: nginx, tags: [web]}
+  tasks:
+    - name: Install packages
+      ansible.builtin.apt: {name: nginx, state: present}
+      notify: restart nginx
+    - block:
+        - name: Copy config
+          template: src=nginx.conf dest=/etc/nginx
+      when: configure
+      tags: [config]
+  handlers:
+    - name: restart nginx
+      service: name=nginx state=restarted
+# Reloads the database
+- hosts: db
+  tasks:
+    - command: pg_ctl reload
+- import_playbook: other.yml
+`)
+	if !isPlaybookFile(path) {
+		t.Fatal("site.yml is not recognized as a playbook")
+	}
+
+	a := &AnsibleAnalyzer{}
+	file, err := a.analyzePlaybook(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plays := file.Packages[0].Structs
+
+	var kinds []string
+	for _, s := range plays {
+		kinds = append(kinds, s.Kind+" "+s.Name)
+	}
+	if want := []string{"play Web servers", "play play 2", "import other.yml"}; !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("plays = %v, want %v", kinds, want)
+	}
+
+	web := plays[0]
+	if web.Doc.Summary != "Configures the fleet" {
+		t.Errorf("first play summary = %q", web.Doc.Summary)
+	}
+	if hosts := findField(web.Fields, "hosts"); hosts == nil || hosts.Value != "web, api" {
+		t.Errorf("hosts = %+v", hosts)
+	}
+	port := findField(web.Fields, "vars").Fields[0]
+	if port.Name != "port" || port.Value != "8080" || port.DefinedIn != "vars" || port.DocYAML.Summary != "Listen port" {
+		t.Errorf("port = %+v", port)
+	}
+
+	var roles []string
+	for _, r := range findField(web.Fields, "roles").Fields {
+		roles = append(roles, r.Name)
+	}
+	if want := []string{"common", "nginx"}; !reflect.DeepEqual(roles, want) {
+		t.Errorf("roles = %v, want %v", roles, want)
+	}
+
+	tests := []struct {
+		section string
+		tasks   [][]string
+	}{
+		{"tasks", [][]string{
+			{"Install packages", "ansible.builtin.apt", "notify=restart nginx"},
+			{"Copy config", "template", "tags=config"},
+		}},
+		{"handlers", [][]string{
+			{"restart nginx", "service"},
+		}},
+	}
+	for _, tt := range tests {
+		var got [][]string
+		for _, task := range findField(web.Fields, tt.section).Fields {
+			got = append(got, taskSummary(task))
+		}
+		if !reflect.DeepEqual(got, tt.tasks) {
+			t.Errorf("%s = %v, want %v", tt.section, got, tt.tasks)
+		}
+	}
+
+	if plays[1].Doc.Summary != "Reloads the database" {
+		t.Errorf("second play summary = %q", plays[1].Doc.Summary)
+	}
+
+	// Unnamed tasks are known by their module
+	reload := findField(plays[1].Fields, "tasks").Fields[0]
+	if reload.Name != "command" || reload.Value != "pg_ctl reload" {
+		t.Errorf("unnamed task = %+v", reload)
+	}
+}
+
+func TestAnsibleRole(t *testing.T) {
+	dir := t.TempDir()
+	role := filepath.Join(dir, "roles", "web")
+	var sources []string
+	for name, content := range map[string]string{
+		"meta/main.yml":     "galaxy_info:\n  description: Serves the site\n  license: MIT\ndependencies: [common]\n",
+		"defaults/main.yml": "# Listen port\nweb_port: 80\nweb_user: www\n",
+		"vars/main.yml":     "web_root: /srv/www\n",
+		"tasks/main.yml":    "- name: Install\n  apt: name=nginx\n  tags: [install]\n",
+		"handlers/main.yml": "- name: reload\n  service: name=nginx state=reloaded\n",
+	} {
+		sources = append(sources, writeTestFile(t, role, name, content))
+	}
+	sort.Strings(sources)
+
+	if dir, ok := roleDirOf(filepath.Join(role, "tasks", "main.yml")); !ok || dir != role {
+		t.Errorf("roleDirOf = %q, %v", dir, ok)
+	}
+
+	a := &AnsibleAnalyzer{}
+	file, err := a.analyzeRole(role, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := file.Packages[0]
+	if pkg.ImportPath != "ansible/roles/web" {
+		t.Errorf("role page = %s", pkg.ImportPath)
+	}
+
+	var kinds []string
+	for _, s := range pkg.Structs {
+		kinds = append(kinds, s.Kind+" "+s.Name)
+	}
+	want := []string{"role web", "variables variables", "handlers handlers/main.yml", "tasks tasks/main.yml"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("structs = %v, want %v", kinds, want)
+	}
+	if pkg.Structs[0].Doc.Summary != "Serves the site" {
+		t.Errorf("role summary = %q", pkg.Structs[0].Doc.Summary)
+	}
+
+	wantVars := []Field{
+		{Name: "web_port", Type: "int", Value: "80", DefinedIn: "defaults"},
+		{Name: "web_user", Type: "string", Value: "www", DefinedIn: "defaults"},
+		{Name: "web_root", Type: "string", Value: "/srv/www", DefinedIn: "vars"},
+	}
+	wantVars[0].DocYAML.Summary = "Listen port"
+	if got := pkg.Structs[1].Fields; !reflect.DeepEqual(got, wantVars) {
+		t.Errorf("variables = %+v, want %+v", got, wantVars)
+	}
+}
+
+func TestRoleDirOf(t *testing.T) {
+	tests := []struct {
+		path string
+		dir  string
+		ok   bool
+	}{
+		{"roles/web/tasks/main.yml", "roles/web", true},
+		{"roles/web/defaults/main.yaml", "roles/web", true},
+		{"roles/web/tasks/install.yml", "", false},
+		{"deploy/tasks/main.yml", "deploy", true},
+		{"deploy/defaults/main.yml", "", false},
+	}
+	for _, tt := range tests {
+		dir, ok := roleDirOf(filepath.FromSlash(tt.path))
+		if ok != tt.ok || dir != filepath.FromSlash(tt.dir) {
+			t.Errorf("roleDirOf(%s) = %q, %v, want %q, %v", tt.path, dir, ok, tt.dir, tt.ok)
+		}
+	}
+}
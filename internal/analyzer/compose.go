@@ -0,0 +1,269 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"gopkg.in/yaml.v3"
+)
+
+// composeResources are the top-level sections services refer to, with the
+// kind of their structs
+var composeResources = []struct{ key, kind string }{
+	{"networks", "network"},
+	{"volumes", "volume"},
+	{"secrets", "secret"},
+	{"configs", "config"},
+}
+
+// ComposePort is a port a service publishes or exposes
+type ComposePort struct {
+	HostIP    string
+	Published string // host port, empty for ports only exposed to other services
+	Target    string // container port
+	Protocol  string
+}
+
+// ComposeMount is a volume, bind mount or tmpfs of a service
+type ComposeMount struct {
+	Type     string // "volume", "bind" or "tmpfs"
+	Source   string // volume name or host path, empty for anonymous volumes
+	Target   string
+	ReadOnly bool
+}
+
+// ComposeRef is a reference from a service to another service, network,
+// secret or config, with a detail such as a depends_on condition
+type ComposeRef struct {
+	Name   string
+	Detail string
+}
+
+// isComposeFile reports whether path has one of the file names Compose
+// looks for, such as docker-compose.yml or compose.override.yaml
+func isComposeFile(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	return (strings.HasPrefix(base, "docker-compose") || strings.HasPrefix(base, "compose")) &&
+		hasExtension(base, []string{".yml", ".yaml"})
+}
+
+// isDockerCompose reports whether node is a Compose file: a services
+// mapping whose services define an image or a build, or any services
+// mapping in a file named like a Compose file
+func (y *YAMLAnalyzer) isDockerCompose(node *yaml.Node, path string) bool {
+	services := resolveYAMLAlias(y.getValueNode(node, "services"))
+	if services == nil || services.Kind != yaml.MappingNode {
+		return false
+	}
+	if isComposeFile(path) {
+		return true
+	}
+	for _, pair := range y.mappingPairs(services) {
+		service := resolveYAMLAlias(pair.Value)
+		if y.hasKey(service, "image") || y.hasKey(service, "build") {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeDockerCompose describes a Compose file as a Struct of kind
+// "compose" for the file itself, one of kind "service" per service, and one
+// per top-level network, volume, secret and config.
+func (y *YAMLAnalyzer) analyzeDockerCompose(root *yaml.Node, path string) []Struct {
+	comment := y.extractYAMLComment(root)
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if comment == "" && len(node.Content) > 0 {
+		// A comment right above the first key describes the whole file
+		comment = yamlComment(node.Content[0].HeadComment)
+		node.Content[0].HeadComment = ""
+	}
+
+	stack := Struct{
+		Name: y.getValue(node, "name"),
+		Kind: "compose",
+		Doc:  ai.Documentation{Summary: comment},
+	}
+	if stack.Name == "" {
+		stack.Name = filepath.Base(path)
+	}
+	for _, pair := range y.mappingPairs(node) {
+		if key := pair.Key.Value; key == "version" || key == "name" || key == "include" || strings.HasPrefix(key, "x-") {
+			value := resolveYAMLAlias(pair.Value)
+			stack.Fields = append(stack.Fields, Field{
+				Name:    key,
+				Type:    y.nodeKindToString(value.Kind),
+				Value:   scalarOrEmpty(value),
+				DocYAML: ai.YAMLDocumentation{Summary: fieldComment(pair.Key, pair.Value)},
+				Fields:  y.extractYAMLFields(value, 1),
+			})
+		}
+	}
+
+	structs := []Struct{stack}
+	for _, pair := range y.mappingPairs(y.getValueNode(node, "services")) {
+		config := resolveYAMLAlias(pair.Value)
+		service := Struct{
+			Name:   pair.Key.Value,
+			Kind:   "service",
+			Doc:    ai.Documentation{Summary: fieldComment(pair.Key, pair.Value)},
+			Fields: y.extractYAMLFields(config, 1),
+		}
+		structs = append(structs, service)
+	}
+	for _, resource := range composeResources {
+		for _, pair := range y.mappingPairs(y.getValueNode(node, resource.key)) {
+			structs = append(structs, Struct{
+				Name:   pair.Key.Value,
+				Kind:   resource.kind,
+				Doc:    ai.Documentation{Summary: fieldComment(pair.Key, pair.Value)},
+				Fields: y.extractYAMLFields(resolveYAMLAlias(pair.Value), 1),
+			})
+		}
+	}
+	return structs
+}
+
+func scalarOrEmpty(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	return ""
+}
+
+// ComposeServiceDependencies returns the services a service depends on,
+// with their condition, and the ones it links to
+func ComposeServiceDependencies(fields []Field) []ComposeRef {
+	var refs []ComposeRef
+	if dependsOn := fieldAt(fields, "depends_on"); dependsOn != nil {
+		for _, dep := range dependsOn.Fields {
+			if dep.Value != "" {
+				// Short syntax: a list of service names
+				refs = append(refs, ComposeRef{Name: dep.Value})
+				continue
+			}
+			refs = append(refs, ComposeRef{Name: dep.Name, Detail: fieldValue(dep.Fields, "condition")})
+		}
+	}
+	for _, link := range children(fieldAt(fields, "links")) {
+		name, alias, _ := strings.Cut(link.Value, ":")
+		detail := "link"
+		if alias != "" {
+			detail = "link as " + alias
+		}
+		refs = append(refs, ComposeRef{Name: name, Detail: detail})
+	}
+	return refs
+}
+
+// ComposeServiceRefs returns the networks, secrets or configs a service
+// uses, in the short or long syntax. The detail is the target of a secret
+// or config, or the aliases of the service on a network.
+func ComposeServiceRefs(fields []Field, key string) []ComposeRef {
+	var refs []ComposeRef
+	for _, item := range children(fieldAt(fields, key)) {
+		switch {
+		case item.Value != "" && strings.HasPrefix(item.Name, "["):
+			refs = append(refs, ComposeRef{Name: item.Value})
+		case strings.HasPrefix(item.Name, "["):
+			refs = append(refs, ComposeRef{Name: fieldValue(item.Fields, "source"), Detail: fieldValue(item.Fields, "target")})
+		default:
+			// networks: {backend: {aliases: [...]}}
+			var aliases []string
+			for _, alias := range children(fieldAt(item.Fields, "aliases")) {
+				aliases = append(aliases, alias.Value)
+			}
+			refs = append(refs, ComposeRef{Name: item.Name, Detail: strings.Join(aliases, ", ")})
+		}
+	}
+	return refs
+}
+
+// ComposePorts returns the ports a service publishes, then the ones it
+// only exposes to other services
+func ComposePorts(fields []Field) []ComposePort {
+	var ports []ComposePort
+	for _, item := range children(fieldAt(fields, "ports")) {
+		if item.Value == "" {
+			ports = append(ports, ComposePort{
+				HostIP:    fieldValue(item.Fields, "host_ip"),
+				Published: fieldValue(item.Fields, "published"),
+				Target:    fieldValue(item.Fields, "target"),
+				Protocol:  fieldValue(item.Fields, "protocol"),
+			})
+			continue
+		}
+		ports = append(ports, parseComposePort(item.Value))
+	}
+	for _, item := range children(fieldAt(fields, "expose")) {
+		port := parseComposePort(item.Value)
+		port.Published = ""
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+// parseComposePort reads "[host_ip:][published:]target[/protocol]"
+func parseComposePort(spec string) ComposePort {
+	var port ComposePort
+	spec, port.Protocol, _ = strings.Cut(spec, "/")
+	parts := strings.Split(spec, ":")
+	port.Target = parts[len(parts)-1]
+	if len(parts) > 1 {
+		port.Published = parts[len(parts)-2]
+	}
+	if len(parts) > 2 {
+		port.HostIP = strings.Join(parts[:len(parts)-2], ":")
+	}
+	return port
+}
+
+// ComposeMounts returns the volumes, bind mounts and tmpfs mounts of a service
+func ComposeMounts(fields []Field) []ComposeMount {
+	var mounts []ComposeMount
+	for _, item := range children(fieldAt(fields, "volumes")) {
+		if item.Value == "" {
+			mount := ComposeMount{
+				Type:     fieldValue(item.Fields, "type"),
+				Source:   fieldValue(item.Fields, "source"),
+				Target:   fieldValue(item.Fields, "target"),
+				ReadOnly: fieldValue(item.Fields, "read_only") == "true",
+			}
+			if mount.Type == "" {
+				mount.Type = "volume"
+			}
+			mounts = append(mounts, mount)
+			continue
+		}
+		mounts = append(mounts, parseComposeMount(item.Value))
+	}
+	for _, item := range children(fieldAt(fields, "tmpfs")) {
+		mounts = append(mounts, ComposeMount{Type: "tmpfs", Target: item.Value})
+	}
+	if tmpfs := fieldAt(fields, "tmpfs"); tmpfs != nil && tmpfs.Value != "" {
+		mounts = append(mounts, ComposeMount{Type: "tmpfs", Target: tmpfs.Value})
+	}
+	return mounts
+}
+
+// parseComposeMount reads "[source:]target[:mode]". Sources that look like
+// paths are bind mounts; the others name volumes.
+func parseComposeMount(spec string) ComposeMount {
+	parts := strings.Split(spec, ":")
+	mount := ComposeMount{Type: "volume", Target: parts[0]}
+	if len(parts) > 1 {
+		mount.Source, mount.Target = parts[0], parts[1]
+	}
+	if len(parts) > 2 {
+		mount.ReadOnly = strings.Contains(parts[2], "ro")
+	}
+	if strings.HasPrefix(mount.Source, "/") || strings.HasPrefix(mount.Source, ".") ||
+		strings.HasPrefix(mount.Source, "~") || strings.HasPrefix(mount.Source, "$") {
+		mount.Type = "bind"
+	}
+	return mount
+}
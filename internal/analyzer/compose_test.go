@@ -0,0 +1,176 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// composeService extracts the fields of a service the way the analyzer does
+func composeService(t *testing.T, src string) []Field {
+	t.Helper()
+	y := &YAMLAnalyzer{}
+	return y.extractYAMLFields(parseYAMLTest(t, src), 1)
+}
+
+func TestAnalyzeDockerCompose(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "compose.yaml", `# The local stack
+name: shop
+x-common: &common
+  restart: always
+services:
+  # Serves the site
+  web:
+    <<: *common
+    image: nginx
+    depends_on: [api]
+  api:
+    build: .
+    networks: [backend]
+networks:
+  backend: {}
+volumes:
+  data: # Database files
+    driver: local
+`)
+	y := &YAMLAnalyzer{}
+	file, err := y.analyzeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Language != "Docker Compose" {
+		t.Fatalf("language = %q", file.Language)
+	}
+	structs := file.Packages[0].Structs
+
+	var kinds []string
+	for _, s := range structs {
+		kinds = append(kinds, s.Kind+" "+s.Name)
+	}
+	want := []string{"compose shop", "service web", "service api", "network backend", "volume data"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("structs = %v, want %v", kinds, want)
+	}
+
+	docs := []string{"The local stack", "Serves the site", "", "", "Database files"}
+	for i, s := range structs {
+		if s.Doc.Summary != docs[i] {
+			t.Errorf("%s doc = %q, want %q", s.Name, s.Doc.Summary, docs[i])
+		}
+	}
+	if restart := findField(structs[1].Fields, "restart"); restart == nil || restart.Value != "always" || restart.Origin != "<<: *common" {
+		t.Errorf("merged restart = %+v", restart)
+	}
+}
+
+func TestIsDockerCompose(t *testing.T) {
+	tests := []struct {
+		path string
+		src  string
+		want bool
+	}{
+		{"docker-compose.yml", "services:\n  db: {}\n", true},
+		{"compose.override.yaml", "services: {}\n", true},
+		{"stack.yml", "services:\n  web: {image: nginx}\n", true},
+		{"stack.yml", "services:\n  web: {build: .}\n", true},
+		{"config.yml", "services:\n  web: {enabled: true}\n", false},
+		{"docker-compose.yml", "services: [web]\n", false},
+	}
+	y := &YAMLAnalyzer{}
+	for _, tt := range tests {
+		if got := y.isDockerCompose(parseYAMLTest(t, tt.src), tt.path); got != tt.want {
+			t.Errorf("isDockerCompose(%s, %q) = %v, want %v", tt.path, tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestComposeServiceDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []ComposeRef
+	}{
+		{"short syntax", "depends_on: [db, cache]\n", []ComposeRef{{Name: "db"}, {Name: "cache"}}},
+		{
+			"long syntax",
+			"depends_on:\n  db: {condition: service_healthy}\n  cache: {}\n",
+			[]ComposeRef{{Name: "db", Detail: "service_healthy"}, {Name: "cache"}},
+		},
+		{"links", "links: [db, cache:redis]\n", []ComposeRef{{Name: "db", Detail: "link"}, {Name: "cache", Detail: "link as redis"}}},
+		{"none", "image: nginx\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComposeServiceDependencies(composeService(t, tt.src)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeServiceRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		key  string
+		want []ComposeRef
+	}{
+		{"network list", "networks: [front, back]\n", "networks", []ComposeRef{{Name: "front"}, {Name: "back"}}},
+		{"network aliases", "networks:\n  back: {aliases: [db, primary]}\n", "networks", []ComposeRef{{Name: "back", Detail: "db, primary"}}},
+		{"secret short", "secrets: [token]\n", "secrets", []ComposeRef{{Name: "token"}}},
+		{"secret long", "secrets:\n  - {source: token, target: /run/token}\n", "secrets", []ComposeRef{{Name: "token", Detail: "/run/token"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComposeServiceRefs(composeService(t, tt.src), tt.key); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposePorts(t *testing.T) {
+	src := `ports:
+  - "80"
+  - "8080:80"
+  - "127.0.0.1:5432:5432/tcp"
+  - "53:53/udp"
+  - {target: 443, published: 8443, host_ip: 0.0.0.0, protocol: tcp}
+expose: ["9000"]
+`
+	want := []ComposePort{
+		{Target: "80"},
+		{Published: "8080", Target: "80"},
+		{HostIP: "127.0.0.1", Published: "5432", Target: "5432", Protocol: "tcp"},
+		{Published: "53", Target: "53", Protocol: "udp"},
+		{HostIP: "0.0.0.0", Published: "8443", Target: "443", Protocol: "tcp"},
+		{Target: "9000"},
+	}
+	if got := ComposePorts(composeService(t, src)); !reflect.DeepEqual(got, want) {
+		t.Errorf("ports = %+v, want %+v", got, want)
+	}
+}
+
+func TestComposeMounts(t *testing.T) {
+	src := `volumes:
+  - /var/lib/data
+  - data:/var/lib/postgresql
+  - ./conf:/etc/app:ro
+  - ${HOME}/.cache:/cache:rw
+  - {type: bind, source: /tmp, target: /tmp, read_only: true}
+  - {source: logs, target: /logs}
+tmpfs: /run
+`
+	want := []ComposeMount{
+		{Type: "volume", Target: "/var/lib/data"},
+		{Type: "volume", Source: "data", Target: "/var/lib/postgresql"},
+		{Type: "bind", Source: "./conf", Target: "/etc/app", ReadOnly: true},
+		{Type: "bind", Source: "${HOME}/.cache", Target: "/cache"},
+		{Type: "bind", Source: "/tmp", Target: "/tmp", ReadOnly: true},
+		{Type: "volume", Source: "logs", Target: "/logs"},
+		{Type: "tmpfs", Target: "/run"},
+	}
+	if got := ComposeMounts(composeService(t, src)); !reflect.DeepEqual(got, want) {
+		t.Errorf("mounts = %+v, want %+v", got, want)
+	}
+}
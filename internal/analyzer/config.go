@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFile is read from the project directory when no --config is given
+const DefaultConfigFile = ".docupocus.yaml"
+
+// FileConfig holds the analysis settings that can be kept in a config file:
+//
+//	include:
+//	  - "internal/**"
+//	exclude:
+//	  - "**/*_gen.go"
+//	  - "examples/"
+type FileConfig struct {
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// LoadConfig reads a config file. A missing file is not an error and yields
+// an empty config.
+func LoadConfig(path string) (*FileConfig, error) {
+	cfg := &FileConfig{}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Apply adds the config file globs to opts, ahead of any given on the command line
+func (c *FileConfig) Apply(opts *Options) {
+	opts.Include = append(append([]string{}, c.Include...), opts.Include...)
+	opts.Exclude = append(append([]string{}, c.Exclude...), opts.Exclude...)
+}
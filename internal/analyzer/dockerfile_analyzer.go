@@ -0,0 +1,302 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+// DockerfileAnalyzer documents Dockerfiles. Each build stage becomes a
+// Struct of kind "stage", based on its image, whose fields are its
+// instructions in order; ENV, ARG, LABEL and EXPOSE also break their arguments down into
+// nested fields.
+type DockerfileAnalyzer struct{}
+
+func (d *DockerfileAnalyzer) Supports(projectDir string, opts Options) bool {
+	paths, err := newFileWalker(projectDir, opts).walk(IsDockerfile)
+	return err == nil && len(paths) > 0
+}
+
+func (d *DockerfileAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	paths, err := newFileWalker(projectDir, opts).walk(IsDockerfile)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := analyzeFiles(ctx, paths, opts, d.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyzerResult{Files: files}, nil
+}
+
+// IsDockerfile reports whether path names a Dockerfile, such as Dockerfile,
+// Dockerfile.dev, api.dockerfile or Containerfile
+func IsDockerfile(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	return base == "dockerfile" || base == "containerfile" ||
+		strings.HasPrefix(base, "dockerfile.") || strings.HasSuffix(base, ".dockerfile")
+}
+
+// dockerInstruction is one logical instruction, continuation lines joined
+type dockerInstruction struct {
+	Keyword string
+	Args    string
+	Doc     string
+}
+
+// analyzeFile extracts the build stages of a single Dockerfile
+func (d *DockerfileAnalyzer) analyzeFile(path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := Package{
+		Name: filepath.Base(filepath.Dir(path)),
+		Path: path,
+	}
+
+	var stage *Struct
+	stages := 0
+	for _, inst := range parseDockerfile(string(content)) {
+		if inst.Keyword == "FROM" {
+			if stage != nil {
+				pkg.Structs = append(pkg.Structs, *stage)
+			}
+			image, alias := dockerFrom(inst.Args)
+			stage = &Struct{
+				Name:    alias,
+				Kind:    "stage",
+				Bases:   []string{image},
+				DocYAML: ai.YAMLDocumentation{Summary: inst.Doc},
+			}
+			if alias == "" {
+				stage.Name = fmt.Sprintf("stage %d", stages)
+			}
+			stages++
+		}
+		if stage == nil {
+			// ARG before the first FROM configures the base images
+			stage = &Struct{Name: "global", Kind: "global"}
+		}
+		stage.Fields = append(stage.Fields, dockerField(inst))
+	}
+	if stage != nil {
+		pkg.Structs = append(pkg.Structs, *stage)
+	}
+
+	return &AnalyzedFile{
+		Path:     path,
+		Packages: []Package{pkg},
+	}, nil
+}
+
+// parseDockerfile splits a Dockerfile into logical instructions, honoring
+// the escape parser directive, line continuations and RUN/COPY heredocs.
+// Comments directly above an instruction become its doc.
+func parseDockerfile(src string) []dockerInstruction {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	escape := "\\"
+	var insts []dockerInstruction
+	var doc []string
+	directives := true
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "#") {
+			text := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			if directives {
+				if key, value, ok := strings.Cut(text, "="); ok && strings.EqualFold(strings.TrimSpace(key), "escape") {
+					escape = strings.TrimSpace(value)
+					continue
+				}
+				if key, _, ok := strings.Cut(text, "="); ok && strings.EqualFold(strings.TrimSpace(key), "syntax") {
+					continue
+				}
+			}
+			doc = append(doc, text)
+			continue
+		}
+		directives = false
+		if line == "" {
+			doc = nil
+			continue
+		}
+
+		// Join continuation lines, skipping comments between them
+		for strings.HasSuffix(line, escape) && i+1 < len(lines) {
+			line = strings.TrimSpace(strings.TrimSuffix(line, escape))
+			i++
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "#") {
+				i++
+			}
+			if i < len(lines) {
+				line += " " + strings.TrimSpace(lines[i])
+			}
+		}
+
+		keyword, args := line, ""
+		if idx := strings.IndexAny(line, " \t"); idx >= 0 {
+			keyword, args = line[:idx], line[idx+1:]
+		}
+		inst := dockerInstruction{Keyword: strings.ToUpper(keyword), Args: strings.TrimSpace(args), Doc: strings.Join(doc, "\n")}
+		doc = nil
+
+		// Heredocs: RUN <<EOF ... EOF. An unterminated heredoc is left as
+		// written rather than swallowing the rest of the file.
+		switch inst.Keyword {
+		case "RUN", "COPY", "ADD":
+			if marker := dockerHeredocMarker(inst.Args); marker != "" {
+				for j := i + 1; j < len(lines); j++ {
+					if strings.TrimSpace(lines[j]) == marker {
+						inst.Args += "\n" + strings.Join(lines[i+1:j+1], "\n")
+						i = j
+						break
+					}
+				}
+			}
+		}
+		insts = append(insts, inst)
+	}
+	return insts
+}
+
+// dockerHeredocMarker returns the terminator of a heredoc opened in args,
+// ignoring "<<" inside quoted text
+func dockerHeredocMarker(args string) string {
+	idx := -1
+	var quote byte
+	for i := 0; i+1 < len(args) && idx < 0; i++ {
+		switch c := args[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '<' && args[i+1] == '<':
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return ""
+	}
+	marker := strings.TrimPrefix(args[idx+2:], "-")
+	if end := strings.IndexAny(marker, " \t>|&;"); end >= 0 {
+		marker = marker[:end]
+	}
+	marker = strings.Trim(marker, `"'`)
+	for _, r := range marker {
+		if !(r == '_' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return ""
+		}
+	}
+	return marker
+}
+
+// dockerFrom returns the image and stage name of "FROM [--platform=x] image [AS name]"
+func dockerFrom(args string) (string, string) {
+	var words []string
+	for _, w := range strings.Fields(args) {
+		if !strings.HasPrefix(w, "--") {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 {
+		return "", ""
+	}
+	if len(words) >= 3 && strings.EqualFold(words[1], "as") {
+		return words[0], words[2]
+	}
+	return words[0], ""
+}
+
+// dockerField turns an instruction into a field named after its keyword
+func dockerField(inst dockerInstruction) Field {
+	field := Field{
+		Name:    inst.Keyword,
+		Type:    "instruction",
+		Value:   inst.Args,
+		DocYAML: ai.YAMLDocumentation{Summary: inst.Doc},
+	}
+
+	switch inst.Keyword {
+	case "ENV", "LABEL", "ARG":
+		field.Type = "map"
+		field.Fields = dockerPairs(inst.Args, inst.Keyword == "ENV")
+	case "EXPOSE":
+		field.Type = "array"
+		for i, port := range strings.Fields(inst.Args) {
+			field.Fields = append(field.Fields, Field{Name: fmt.Sprintf("[%d]", i), Type: "port", Value: port})
+		}
+	case "ENTRYPOINT", "CMD", "SHELL":
+		field.Type = "shell"
+		var exec []string
+		if json.Unmarshal([]byte(inst.Args), &exec) == nil {
+			field.Type = "exec"
+			for i, arg := range exec {
+				field.Fields = append(field.Fields, Field{Name: fmt.Sprintf("[%d]", i), Type: "string", Value: arg})
+			}
+		}
+	}
+	return field
+}
+
+// dockerPairs parses "KEY=value KEY2=\"two words\"" as well as the legacy
+// "KEY value" form allowed by ENV. ARG may omit the value.
+func dockerPairs(args string, legacy bool) []Field {
+	words := splitShellWords(args)
+	if legacy && len(words) > 0 && !strings.Contains(words[0], "=") {
+		key, value, _ := strings.Cut(args, " ")
+		return []Field{{Name: key, Type: "string", Value: strings.TrimSpace(value)}}
+	}
+
+	var fields []Field
+	for _, w := range words {
+		key, value, _ := strings.Cut(w, "=")
+		fields = append(fields, Field{Name: key, Type: "string", Value: value})
+	}
+	return fields
+}
+
+// splitShellWords splits on unquoted whitespace and removes the quotes
+func splitShellWords(s string) []string {
+	var words []string
+	var b strings.Builder
+	var quote byte
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		// Bytes are copied as they are, so multi-byte characters stay intact
+		c := s[i]
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+			inWord = true
+		case c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+			inWord = true
+		case quote == 0 && (c == ' ' || c == '\t'):
+			if inWord {
+				words = append(words, b.String())
+				b.Reset()
+				inWord = false
+			}
+		default:
+			b.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, b.String())
+	}
+	return words
+}
@@ -0,0 +1,161 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDockerfile(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []dockerInstruction
+	}{
+		{
+			name: "comments and blank lines",
+			src:  "# syntax=docker/dockerfile:1\n# The base image\nFROM alpine\n\n# Not attached\n\nRUN true\n",
+			want: []dockerInstruction{
+				{Keyword: "FROM", Args: "alpine", Doc: "The base image"},
+				{Keyword: "RUN", Args: "true"},
+			},
+		},
+		{
+			name: "continuation lines",
+			src:  "RUN apk add \\\n    # the compiler\n    gcc \\\n    musl-dev\nCMD [\"sh\"]\n",
+			want: []dockerInstruction{
+				{Keyword: "RUN", Args: "apk add gcc musl-dev"},
+				{Keyword: "CMD", Args: `["sh"]`},
+			},
+		},
+		{
+			name: "escape directive",
+			src:  "# escape=`\nFROM mcr.microsoft.com/windows\nRUN dir `\n    C:\\\nWORKDIR C:\\app\n",
+			want: []dockerInstruction{
+				{Keyword: "FROM", Args: "mcr.microsoft.com/windows"},
+				{Keyword: "RUN", Args: `dir C:\`},
+				{Keyword: "WORKDIR", Args: `C:\app`},
+			},
+		},
+		{
+			name: "heredoc",
+			src:  "RUN <<EOF\napk add git\nEOF\nCOPY <<-\"CONF\" /etc/app.conf\n  port=80\n  CONF\nEXPOSE 80\n",
+			want: []dockerInstruction{
+				{Keyword: "RUN", Args: "<<EOF\napk add git\nEOF"},
+				{Keyword: "COPY", Args: "<<-\"CONF\" /etc/app.conf\n  port=80\n  CONF"},
+				{Keyword: "EXPOSE", Args: "80"},
+			},
+		},
+		{
+			name: "quoted heredoc marker",
+			src:  "RUN echo \"see <<END for details\"\nEXPOSE 80\nCMD [\"app\"]\n",
+			want: []dockerInstruction{
+				{Keyword: "RUN", Args: `echo "see <<END for details"`},
+				{Keyword: "EXPOSE", Args: "80"},
+				{Keyword: "CMD", Args: `["app"]`},
+			},
+		},
+		{
+			name: "heredoc marker outside RUN, COPY and ADD",
+			src:  "LABEL note=<<END\nEXPOSE 80\n",
+			want: []dockerInstruction{
+				{Keyword: "LABEL", Args: "note=<<END"},
+				{Keyword: "EXPOSE", Args: "80"},
+			},
+		},
+		{
+			name: "unterminated heredoc",
+			src:  "RUN cat <<EOF\nEXPOSE 80\nCMD app\n",
+			want: []dockerInstruction{
+				{Keyword: "RUN", Args: "cat <<EOF"},
+				{Keyword: "EXPOSE", Args: "80"},
+				{Keyword: "CMD", Args: "app"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseDockerfile(tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDockerfile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "Dockerfile", `ARG GO_VERSION=1.22
+# Compiles the binary
+FROM --platform=$BUILDPLATFORM golang:${GO_VERSION} AS build
+RUN go build -o /app
+FROM build
+FROM gcr.io/distroless/static
+COPY --from=build /app /app
+`)
+	d := &DockerfileAnalyzer{}
+	file, err := d.analyzeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		kind   string
+		bases  []string
+		doc    string
+		fields []string
+	}{
+		{"global", "global", nil, "", []string{"ARG"}},
+		{"build", "stage", []string{"golang:${GO_VERSION}"}, "Compiles the binary", []string{"FROM", "RUN"}},
+		{"stage 1", "stage", []string{"build"}, "", []string{"FROM"}},
+		{"stage 2", "stage", []string{"gcr.io/distroless/static"}, "", []string{"FROM", "COPY"}},
+	}
+	structs := file.Packages[0].Structs
+	if len(structs) != len(tests) {
+		t.Fatalf("stages = %+v", structs)
+	}
+	for i, tt := range tests {
+		s := structs[i]
+		var fields []string
+		for _, f := range s.Fields {
+			fields = append(fields, f.Name)
+		}
+		if s.Name != tt.name || s.Kind != tt.kind || !reflect.DeepEqual(s.Bases, tt.bases) || s.DocYAML.Summary != tt.doc || !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("stage %d = %s %s %v %q %v, want %s %s %v %q %v",
+				i, s.Name, s.Kind, s.Bases, s.DocYAML.Summary, fields, tt.name, tt.kind, tt.bases, tt.doc, tt.fields)
+		}
+	}
+	if arg := structs[0].Fields[0].Fields; len(arg) != 1 || arg[0].Name != "GO_VERSION" || arg[0].Value != "1.22" {
+		t.Errorf("global ARG = %+v", arg)
+	}
+}
+
+func TestDockerField(t *testing.T) {
+	tests := []struct {
+		name   string
+		inst   dockerInstruction
+		typ    string
+		values []string
+	}{
+		{"exec CMD", dockerInstruction{Keyword: "CMD", Args: `["serve", "--port", "80"]`}, "exec", []string{"serve", "--port", "80"}},
+		{"shell CMD", dockerInstruction{Keyword: "CMD", Args: "serve --port 80"}, "shell", nil},
+		{"exec ENTRYPOINT", dockerInstruction{Keyword: "ENTRYPOINT", Args: `["/app"]`}, "exec", []string{"/app"}},
+		{"EXPOSE", dockerInstruction{Keyword: "EXPOSE", Args: "80 443/tcp"}, "array", []string{"80", "443/tcp"}},
+		{"ENV", dockerInstruction{Keyword: "ENV", Args: `PATH=/bin MODE="two words"`}, "map", []string{"/bin", "two words"}},
+		{"legacy ENV", dockerInstruction{Keyword: "ENV", Args: "GREETING hello world"}, "map", []string{"hello world"}},
+		{"ARG without default", dockerInstruction{Keyword: "ARG", Args: "VERSION"}, "map", []string{""}},
+		{"LABEL", dockerInstruction{Keyword: "LABEL", Args: `description="Café ☕" version=1`}, "map", []string{"Café ☕", "1"}},
+		{"WORKDIR", dockerInstruction{Keyword: "WORKDIR", Args: "/src"}, "instruction", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := dockerField(tt.inst)
+			var values []string
+			for _, sub := range f.Fields {
+				values = append(values, sub.Value)
+			}
+			if f.Name != tt.inst.Keyword || f.Value != tt.inst.Args || f.Type != tt.typ || !reflect.DeepEqual(values, tt.values) {
+				t.Errorf("got %s %s %q %v, want %s %q %v", f.Name, f.Type, f.Value, values, tt.typ, tt.inst.Args, tt.values)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package analyzer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type esTokenKind int
+
+const (
+	esIdent esTokenKind = iota // identifiers and keywords, including #private names
+	esNumber
+	esString
+	esTemplate
+	esRegex
+	esPunct
+	esEOF
+)
+
+// esToken is one JavaScript/TypeScript token. Doc holds the /** ... */
+// comment directly preceding the token, if any.
+type esToken struct {
+	Kind          esTokenKind
+	Text          string
+	Start         int
+	End           int
+	Line          int
+	NewlineBefore bool
+	Doc           string
+}
+
+// Longest punctuators first
+var esPunctuators = []string{
+	">>>=", "...", "===", "!==", "**=", "<<=", ">>=", ">>>", "&&=", "||=", "??=",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "??", "?.", "++", "--", "+=", "-=",
+	"*=", "/=", "%=", "&=", "|=", "^=", "<<", ">>", "**",
+}
+
+// Keywords after which a "/" starts a regular expression rather than a division
+var esRegexAfter = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "case": true,
+	"do": true, "else": true, "yield": true, "await": true,
+}
+
+// lexES tokenizes JavaScript or TypeScript source. It never fails: malformed
+// input such as JSX text with stray quotes degrades into odd tokens instead
+// of an error, since function bodies are skipped by brace matching anyway.
+func lexES(src string) []esToken {
+	var toks []esToken
+	line := 1
+	newline := false
+	doc := ""
+	i := 0
+
+	emit := func(kind esTokenKind, start, end int) {
+		toks = append(toks, esToken{
+			Kind:          kind,
+			Text:          src[start:end],
+			Start:         start,
+			End:           end,
+			Line:          line,
+			NewlineBefore: newline,
+			Doc:           doc,
+		})
+		line += strings.Count(src[start:end], "\n")
+		newline = false
+		doc = ""
+	}
+
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			newline = true
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src)
+			} else {
+				end += i + 4
+			}
+			comment := src[i:end]
+			if strings.HasPrefix(comment, "/**") && comment != "/**/" {
+				doc = comment
+			} else {
+				doc = ""
+			}
+			if n := strings.Count(comment, "\n"); n > 0 {
+				line += n
+				newline = true
+			}
+			i = end
+
+		case c == '"' || c == '\'':
+			end := scanESString(src, i)
+			emit(esString, i, end)
+			i = end
+
+		case c == '`':
+			end := scanESTemplate(src, i)
+			emit(esTemplate, i, end)
+			i = end
+
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			start := i
+			for i < len(src) {
+				ch := src[i]
+				if (ch == '+' || ch == '-') && (src[i-1] == 'e' || src[i-1] == 'E') && !strings.HasPrefix(strings.ToLower(src[start:i]), "0x") {
+					i++
+					continue
+				}
+				if !(ch == '.' || ch == '_' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z') {
+					break
+				}
+				i++
+			}
+			emit(esNumber, start, i)
+
+		case c == '#' || isESIdentStart(src, i):
+			start := i
+			i++
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if !(r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					break
+				}
+				i += size
+			}
+			emit(esIdent, start, i)
+
+		case c == '/' && regexAllowed(toks):
+			if end, ok := scanESRegex(src, i); ok {
+				emit(esRegex, i, end)
+				i = end
+				continue
+			}
+			emit(esPunct, i, i+1)
+			i++
+
+		default:
+			size := 1
+			for _, p := range esPunctuators {
+				if strings.HasPrefix(src[i:], p) {
+					size = len(p)
+					break
+				}
+			}
+			if size == 1 && c >= 0x80 {
+				_, size = utf8.DecodeRuneInString(src[i:])
+			}
+			emit(esPunct, i, i+size)
+			i += size
+		}
+	}
+
+	toks = append(toks, esToken{Kind: esEOF, Start: len(src), End: len(src), Line: line, NewlineBefore: true})
+	return toks
+}
+
+func isESIdentStart(src string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(src[i:])
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+// regexAllowed reports whether a "/" after the previous token starts a
+// regular expression literal
+func regexAllowed(toks []esToken) bool {
+	if len(toks) == 0 {
+		return true
+	}
+	prev := toks[len(toks)-1]
+	switch prev.Kind {
+	case esIdent:
+		return esRegexAfter[prev.Text]
+	case esNumber, esString, esTemplate, esRegex:
+		return false
+	}
+	return prev.Text != ")" && prev.Text != "]" && prev.Text != "}"
+}
+
+// scanESString returns the end of a quoted string. An unterminated string
+// ends at the line break.
+func scanESString(src string, i int) int {
+	quote := src[i]
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1
+		case '\n':
+			return j
+		}
+	}
+	return len(src)
+}
+
+// scanESTemplate returns the end of a template literal, skipping over
+// ${...} substitutions with their own strings and nested templates
+func scanESTemplate(src string, i int) int {
+	for j := i + 1; j < len(src); j++ {
+		switch {
+		case src[j] == '\\':
+			j++
+		case src[j] == '`':
+			return j + 1
+		case strings.HasPrefix(src[j:], "${"):
+			j = skipESSubstitution(src, j+2) - 1
+		}
+	}
+	return len(src)
+}
+
+// skipESSubstitution returns the offset just past the "}" closing a
+// template substitution that starts at i
+func skipESSubstitution(src string, i int) int {
+	depth := 1
+	for j := i; j < len(src); j++ {
+		switch c := src[j]; {
+		case c == '"' || c == '\'':
+			j = scanESString(src, j) - 1
+		case c == '`':
+			j = scanESTemplate(src, j) - 1
+		case strings.HasPrefix(src[j:], "//"):
+			for j < len(src) && src[j] != '\n' {
+				j++
+			}
+		case strings.HasPrefix(src[j:], "/*"):
+			end := strings.Index(src[j+2:], "*/")
+			if end < 0 {
+				return len(src)
+			}
+			j += end + 3
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return j + 1
+			}
+		}
+	}
+	return len(src)
+}
+
+// scanESRegex returns the end of a regular expression literal, including
+// its flags. A "/" with no closing slash on the same line is not a regex.
+func scanESRegex(src string, i int) (int, bool) {
+	inClass := false
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '\n':
+			return 0, false
+		case '/':
+			if inClass {
+				continue
+			}
+			j++
+			for j < len(src) && (src[j] >= 'a' && src[j] <= 'z' || src[j] >= 'A' && src[j] <= 'Z') {
+				j++
+			}
+			return j, true
+		}
+	}
+	return 0, false
+}
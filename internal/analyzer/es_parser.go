@@ -0,0 +1,1158 @@
+package analyzer
+
+import (
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+// esParser extracts top-level declarations from JavaScript or TypeScript.
+// Function and method bodies are skipped by bracket matching; only the calls
+// they make are recorded. TypeScript-only syntax (interfaces, type aliases,
+// enums, annotations) is recognized when ts is set.
+type esParser struct {
+	src  string
+	toks []esToken
+	pos  int
+	ts   bool
+	pkg  *Package
+
+	// Names exported apart from their declaration, as in "export { a }"
+	// or "module.exports = { a }"
+	exported map[string]bool
+}
+
+// Modifiers that may precede a class member
+var esMemberModifiers = map[string]bool{
+	"public": true, "private": true, "protected": true, "static": true,
+	"readonly": true, "abstract": true, "override": true, "declare": true,
+	"accessor": true,
+}
+
+// parseES extracts the declarations of a module into pkg
+func parseES(src string, ts bool, pkg *Package) {
+	p := &esParser{src: src, toks: lexES(src), ts: ts, pkg: pkg, exported: make(map[string]bool)}
+	p.parseStatements("", false)
+	p.markExported()
+
+	// Only calls between functions of this module are worth documenting
+	known := make(map[string]bool)
+	for _, fn := range pkg.Funcs {
+		known[fn.Name] = true
+	}
+	for i := range pkg.Funcs {
+		pkg.Funcs[i].Calls = filterCalls(pkg.Funcs[i].Calls, known, pkg.Funcs[i].Name)
+	}
+	for si := range pkg.Structs {
+		for mi := range pkg.Structs[si].Methods {
+			m := &pkg.Structs[si].Methods[mi]
+			m.Calls = filterCalls(m.Calls, known, "")
+		}
+	}
+}
+
+// markExported flags declarations named in separate export statements
+func (p *esParser) markExported() {
+	for i := range p.pkg.Funcs {
+		p.pkg.Funcs[i].Exported = p.pkg.Funcs[i].Exported || p.exported[p.pkg.Funcs[i].Name]
+	}
+	for i := range p.pkg.Structs {
+		p.pkg.Structs[i].Exported = p.pkg.Structs[i].Exported || p.exported[p.pkg.Structs[i].Name]
+	}
+	for i := range p.pkg.Interfaces {
+		p.pkg.Interfaces[i].Exported = p.pkg.Interfaces[i].Exported || p.exported[p.pkg.Interfaces[i].Name]
+	}
+	for i := range p.pkg.Types {
+		p.pkg.Types[i].Exported = p.pkg.Types[i].Exported || p.exported[p.pkg.Types[i].Name]
+	}
+	for i := range p.pkg.Enums {
+		p.pkg.Enums[i].Exported = p.pkg.Enums[i].Exported || p.exported[p.pkg.Enums[i].Name]
+	}
+}
+
+func (p *esParser) peek() esToken {
+	return p.toks[p.pos]
+}
+
+func (p *esParser) peekAt(n int) esToken {
+	if p.pos+n >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.pos+n]
+}
+
+func (p *esParser) next() esToken {
+	tok := p.toks[p.pos]
+	if tok.Kind != esEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *esParser) is(text string) bool {
+	tok := p.peek()
+	return (tok.Kind == esPunct || tok.Kind == esIdent) && tok.Text == text
+}
+
+func (p *esParser) accept(text string) bool {
+	if p.is(text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// parseStatements parses declarations until EOF or, inside a block, the
+// closing brace. Names are prefixed with prefix (for namespaces).
+func (p *esParser) parseStatements(prefix string, inBlock bool) {
+	for {
+		tok := p.peek()
+		if tok.Kind == esEOF {
+			return
+		}
+		if inBlock && p.is("}") {
+			p.next()
+			return
+		}
+		if p.accept(";") {
+			continue
+		}
+
+		doc := parseJSDoc(tok.Doc)
+		decorators := p.parseDecorators()
+
+		var modifiers []string
+		exported, isDefault := false, false
+		for {
+			switch {
+			case p.is("export"):
+				p.next()
+				exported = true
+				continue
+			case exported && p.is("default"):
+				p.next()
+				isDefault = true
+				continue
+			case p.is("declare") && p.peekAt(1).Kind == esIdent:
+				p.next()
+				continue
+			case p.is("abstract") && p.peekAt(1).Text == "class":
+				modifiers = append(modifiers, p.next().Text)
+				continue
+			}
+			break
+		}
+
+		switch {
+		case p.is("function") || p.is("async") && p.peekAt(1).Text == "function" && !p.peekAt(1).NewlineBefore:
+			if fn, ok := p.parseFunction(doc, decorators); ok {
+				fn.Name = prefix + fn.Name
+				fn.Exported = exported
+				p.addFunc(fn)
+			}
+		case p.is("class"):
+			if s, ok := p.parseClass(doc.Documentation, decorators, modifiers); ok {
+				s.Name = prefix + s.Name
+				s.Exported = exported
+				p.pkg.Structs = append(p.pkg.Structs, s)
+			}
+		case isDefault:
+			p.parseDefaultExport(prefix, doc)
+		case exported && (p.is("{") || p.is("*")):
+			p.parseExportList()
+		case p.isCommonJSExport():
+			p.parseCommonJSExport(prefix, doc)
+		case p.ts && p.is("interface") && p.peekAt(1).Kind == esIdent:
+			iface := p.parseInterface(doc.Documentation)
+			iface.Name = prefix + iface.Name
+			iface.Exported = exported
+			p.pkg.Interfaces = append(p.pkg.Interfaces, iface)
+		case p.ts && p.is("type") && p.peekAt(1).Kind == esIdent && (p.peekAt(2).Text == "=" || p.peekAt(2).Text == "<"):
+			t := p.parseTypeAlias(doc.Documentation)
+			t.Name = prefix + t.Name
+			t.Exported = exported
+			p.pkg.Types = append(p.pkg.Types, t)
+		case p.ts && (p.is("enum") || p.is("const") && p.peekAt(1).Text == "enum"):
+			e := p.parseEnum(doc.Documentation)
+			e.Name = prefix + e.Name
+			e.Exported = exported
+			p.pkg.Enums = append(p.pkg.Enums, e)
+		case p.ts && (p.is("namespace") || p.is("module")) && (p.peekAt(1).Kind == esIdent || p.peekAt(1).Kind == esString):
+			p.next()
+			name := strings.Trim(p.next().Text, `"'`)
+			for p.accept(".") {
+				name += "." + p.next().Text
+			}
+			if p.accept("{") {
+				p.parseStatements(prefix+name+".", true)
+			}
+		case p.is("const") || p.is("let") || p.is("var"):
+			p.parseVariables(prefix, doc, exported)
+		default:
+			p.skipStatement()
+		}
+	}
+}
+
+func (p *esParser) addFunc(fn Function) {
+	// Keep the first of several overload signatures; a getter and setter
+	// pair differ in their modifiers
+	for _, existing := range p.pkg.Funcs {
+		if existing.Name == fn.Name && equalModifiers(existing.Modifiers, fn.Modifiers) {
+			return
+		}
+	}
+	p.pkg.Funcs = append(p.pkg.Funcs, fn)
+}
+
+func equalModifiers(a, b []string) bool {
+	return strings.Join(a, " ") == strings.Join(b, " ")
+}
+
+// parseDefaultExport parses what follows "export default" when it is not a
+// function or class declaration
+func (p *esParser) parseDefaultExport(prefix string, doc jsDoc) {
+	if p.peek().Kind == esIdent && (p.peekAt(1).Text == ";" || p.peekAt(1).NewlineBefore || p.peekAt(1).Kind == esEOF) {
+		p.exported[prefix+p.next().Text] = true
+		p.accept(";")
+		return
+	}
+	if fn, ok := p.tryArrow(); ok {
+		fn.Name = prefix + "default"
+		fn.Exported = true
+		doc.annotate(&fn)
+		p.addFunc(fn)
+		p.accept(";")
+		return
+	}
+	if p.is("{") {
+		p.parseObjectLiteral(prefix, true, true)
+		p.accept(";")
+		return
+	}
+	p.skipStatement()
+}
+
+// parseExportList parses "export { a, b as c }" and "export * from ..."
+func (p *esParser) parseExportList() {
+	if p.accept("*") {
+		if p.accept("as") {
+			p.next()
+		}
+	} else if p.accept("{") {
+		for !p.is("}") && p.peek().Kind != esEOF {
+			p.accept("type")
+			p.exported[p.next().Text] = true
+			if p.accept("as") {
+				p.next()
+			}
+			if !p.accept(",") {
+				break
+			}
+		}
+		p.accept("}")
+	}
+	// Re-exports ("from ...") name nothing declared here
+	if p.accept("from") {
+		p.next()
+	}
+	p.accept(";")
+}
+
+// isCommonJSExport reports whether the statement assigns to module.exports
+// or exports.name
+func (p *esParser) isCommonJSExport() bool {
+	if p.is("module") && p.peekAt(1).Text == "." && p.peekAt(2).Text == "exports" {
+		return true
+	}
+	return p.is("exports") && p.peekAt(1).Text == "." && p.peekAt(2).Kind == esIdent
+}
+
+// parseCommonJSExport parses "module.exports = ...", "module.exports.name = ..."
+// and "exports.name = ..."
+func (p *esParser) parseCommonJSExport(prefix string, doc jsDoc) {
+	if p.accept("module") {
+		p.next() // .
+	}
+	p.next() // exports
+
+	name := ""
+	if p.accept(".") {
+		name = p.next().Text
+	}
+	if !p.accept("=") {
+		p.skipStatement()
+		return
+	}
+
+	switch {
+	case p.is("class"):
+		if s, ok := p.parseClass(doc.Documentation, nil, nil); ok {
+			if name != "" {
+				s.Name = name
+			}
+			s.Name = prefix + s.Name
+			s.Exported = true
+			p.pkg.Structs = append(p.pkg.Structs, s)
+		}
+	case p.is("{") && name == "":
+		p.parseObjectLiteral(prefix, true, true)
+	case p.peek().Kind == esIdent && !p.is("function") && !p.is("async") && p.peekAt(1).Text != "=>" &&
+		(p.peekAt(1).Text == ";" || p.peekAt(1).NewlineBefore || p.peekAt(1).Kind == esEOF):
+		p.exported[prefix+p.next().Text] = true
+	default:
+		fn, ok := p.tryFunctionExpression()
+		if !ok {
+			p.exprText(";")
+			break
+		}
+		switch {
+		case name != "":
+			fn.Name = name
+		case fn.Name == "":
+			fn.Name = "default"
+		}
+		fn.Name = prefix + fn.Name
+		fn.Exported = true
+		doc.annotate(&fn)
+		p.addFunc(fn)
+	}
+	p.accept(";")
+}
+
+// parseObjectLiteral records the methods of an object literal as functions
+// named prefix+key. An exports object (module.exports = { ... }) also
+// exports the names its shorthand and identifier-valued properties refer to.
+func (p *esParser) parseObjectLiteral(prefix string, exported, exportsObject bool) {
+	p.next() // {
+	for !p.is("}") && p.peek().Kind != esEOF {
+		if p.accept(",") {
+			continue
+		}
+		if p.accept("...") {
+			p.exprText(",", "}")
+			continue
+		}
+
+		doc := parseJSDoc(p.peek().Doc)
+		var modifiers []string
+		async := false
+		for {
+			after := p.peekAt(1).Text
+			named := after == "(" || after == ":" || after == "," || after == "}"
+			switch {
+			case (p.is("get") || p.is("set")) && !named:
+				modifiers = append(modifiers, p.next().Text)
+				continue
+			case p.is("async") && !named:
+				p.next()
+				async = true
+				continue
+			case p.is("*"):
+				p.next()
+				modifiers = append(modifiers, "*")
+				continue
+			}
+			break
+		}
+
+		start := p.pos
+		if p.is("[") {
+			p.skipBalanced()
+		} else {
+			p.next()
+		}
+		key := strings.Trim(p.text(start, p.pos), `"'`)
+
+		fn := Function{Modifiers: modifiers, Async: async}
+		switch {
+		case p.is("(") || p.is("<"):
+			p.parseSignature(&fn)
+			if p.is("{") {
+				fn.Calls = p.skipBody()
+			}
+		case p.accept(":"):
+			value, ok := p.tryFunctionExpression()
+			if !ok {
+				if exportsObject && p.peek().Kind == esIdent && (p.peekAt(1).Text == "," || p.peekAt(1).Text == "}") {
+					p.exported[p.peek().Text] = true
+				}
+				p.exprText(",", "}")
+				continue
+			}
+			value.Modifiers = append(modifiers, value.Modifiers...)
+			fn = value
+		default:
+			// Shorthand property "{ name }"
+			if exportsObject {
+				p.exported[key] = true
+			}
+			continue
+		}
+
+		fn.Name = prefix + key
+		fn.Exported = exported
+		doc.annotate(&fn)
+		p.addFunc(fn)
+	}
+	p.accept("}")
+}
+
+// parseDecorators consumes "@name(...)" decorators
+func (p *esParser) parseDecorators() []string {
+	var decorators []string
+	for p.is("@") && p.peekAt(1).Kind == esIdent {
+		p.next()
+		start := p.pos
+		p.next()
+		for p.is(".") && p.peekAt(1).Kind == esIdent {
+			p.next()
+			p.next()
+		}
+		if p.is("(") {
+			p.skipBalanced()
+		}
+		decorators = append(decorators, p.text(start, p.pos))
+	}
+	return decorators
+}
+
+// parseFunction parses a function declaration, with or without a body
+func (p *esParser) parseFunction(doc jsDoc, decorators []string) (Function, bool) {
+	fn := Function{Decorators: decorators}
+	if p.accept("async") {
+		fn.Async = true
+	}
+	p.next() // function
+	if p.accept("*") {
+		fn.Modifiers = append(fn.Modifiers, "*")
+	}
+
+	switch {
+	case p.peek().Kind == esIdent:
+		fn.Name = p.next().Text
+	case p.is("("):
+		fn.Name = "default"
+	default:
+		p.skipStatement()
+		return fn, false
+	}
+
+	p.parseSignature(&fn)
+	doc.annotate(&fn)
+	if p.is("{") {
+		fn.Calls = p.skipBody()
+	} else {
+		p.accept(";")
+	}
+	return fn, true
+}
+
+// parseSignature parses type parameters, parameters and the return type
+func (p *esParser) parseSignature(fn *Function) {
+	if p.is("<") {
+		fn.TypeParams = p.parseTypeParams()
+	}
+	if p.is("(") {
+		fn.Parameters = p.parseParams()
+	}
+	if p.accept(":") {
+		if ret := p.typeText("{", ";", "=>", "}"); ret != "" {
+			fn.Results = []Parameter{{Type: ret}}
+		}
+	}
+}
+
+// parseTypeParams parses "<T extends X = Y, U>"
+func (p *esParser) parseTypeParams() []Parameter {
+	p.next() // <
+	var params []Parameter
+	for !p.is(">") && p.peek().Kind != esEOF {
+		p.accept("const")
+		param := Parameter{Name: p.next().Text}
+		if p.accept("extends") {
+			param.Type = p.typeText(",", ">", "=")
+		}
+		if p.accept("=") {
+			param.Default = p.typeText(",", ">")
+		}
+		params = append(params, param)
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.accept(">")
+	return params
+}
+
+// parseParams parses a parenthesized parameter list. TypeScript parameter
+// properties keep their modifiers in the returned fields.
+func (p *esParser) parseParams() []Parameter {
+	params, _ := p.parseParamsWithProperties()
+	return params
+}
+
+func (p *esParser) parseParamsWithProperties() ([]Parameter, []Field) {
+	p.next() // (
+	var params []Parameter
+	var props []Field
+
+	for !p.is(")") && p.peek().Kind != esEOF {
+		p.parseDecorators()
+
+		var modifiers []string
+		for esMemberModifiers[p.peek().Text] && p.peekAt(1).Kind == esIdent {
+			modifiers = append(modifiers, p.next().Text)
+		}
+
+		var param Parameter
+		start := p.pos
+		switch {
+		case p.accept("..."):
+			param.Name = "..." + p.next().Text
+		case p.is("{") || p.is("["):
+			// Destructuring pattern
+			p.skipBalanced()
+			param.Name = p.text(start, p.pos)
+		default:
+			param.Name = p.next().Text
+		}
+		if p.accept("?") {
+			param.Name += "?"
+		}
+		if p.accept(":") {
+			param.Type = p.typeText(",", ")", "=")
+		}
+		if p.accept("=") {
+			param.Default = p.exprText(",", ")")
+		}
+		params = append(params, param)
+
+		if len(modifiers) > 0 {
+			props = append(props, Field{
+				Name:      strings.TrimSuffix(param.Name, "?"),
+				Type:      param.Type,
+				Value:     param.Default,
+				Modifiers: modifiers,
+			})
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.accept(")")
+	return params, props
+}
+
+// parseClass parses a class declaration and its members
+func (p *esParser) parseClass(doc ai.Documentation, decorators, modifiers []string) (Struct, bool) {
+	p.next() // class
+	s := Struct{
+		Decorators: decorators,
+		Modifiers:  modifiers,
+		Doc:        doc,
+		Fields:     []Field{},
+		Methods:    []Function{},
+	}
+	if p.peek().Kind == esIdent && !p.is("extends") && !p.is("implements") {
+		s.Name = p.next().Text
+	} else {
+		s.Name = "default"
+	}
+	if p.is("<") {
+		s.TypeParams = p.parseTypeParams()
+	}
+	if p.accept("extends") {
+		s.Bases = append(s.Bases, p.exprText("implements", "{"))
+	}
+	if p.accept("implements") {
+		for {
+			s.Implements = append(s.Implements, p.typeText(",", "{"))
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	if !p.accept("{") {
+		p.skipStatement()
+		return s, false
+	}
+
+	for !p.is("}") && p.peek().Kind != esEOF {
+		if p.accept(";") {
+			continue
+		}
+		p.parseMember(&s)
+	}
+	p.accept("}")
+	return s, true
+}
+
+// parseMember parses one class member: a field, method, accessor or constructor
+func (p *esParser) parseMember(s *Struct) {
+	doc := parseJSDoc(p.peek().Doc)
+	decorators := p.parseDecorators()
+
+	var modifiers []string
+	async := false
+	for {
+		tok := p.peek()
+		after := p.peekAt(1)
+		// A modifier keyword followed by "(", ":" or "=" is the member's name
+		named := after.Text == "(" || after.Text == ":" || after.Text == "=" || after.Text == ";" ||
+			after.Text == "?" || after.Text == "<" || after.Text == "}" || after.NewlineBefore
+		switch {
+		case esMemberModifiers[tok.Text] && !named:
+			modifiers = append(modifiers, p.next().Text)
+			continue
+		case (tok.Text == "get" || tok.Text == "set") && !named:
+			modifiers = append(modifiers, p.next().Text)
+			continue
+		case tok.Text == "async" && !named:
+			p.next()
+			async = true
+			continue
+		case tok.Text == "*":
+			p.next()
+			modifiers = append(modifiers, "*")
+			continue
+		}
+		break
+	}
+
+	if p.is("static") && p.peekAt(1).Text == "{" {
+		// Static initialization block
+		p.next()
+		p.skipBalanced()
+		return
+	}
+
+	start := p.pos
+	if p.is("[") {
+		p.skipBalanced()
+	} else {
+		p.next()
+	}
+	name := p.text(start, p.pos)
+	optional := ""
+	if p.accept("?") {
+		optional = "?"
+	} else {
+		p.accept("!")
+	}
+
+	if p.is("(") || p.is("<") {
+		fn := Function{
+			Name:       name + optional,
+			Receiver:   s.Name,
+			Decorators: decorators,
+			Modifiers:  modifiers,
+			Async:      async,
+		}
+		if p.is("<") {
+			fn.TypeParams = p.parseTypeParams()
+		}
+		var props []Field
+		if p.is("(") {
+			fn.Parameters, props = p.parseParamsWithProperties()
+		}
+		if p.accept(":") {
+			if ret := p.typeText("{", ";", "}"); ret != "" {
+				fn.Results = []Parameter{{Type: ret}}
+			}
+		}
+		doc.annotate(&fn)
+		if p.is("{") {
+			fn.Calls = p.skipBody()
+		} else {
+			p.accept(";")
+		}
+		s.Fields = append(s.Fields, props...)
+		s.Methods = append(s.Methods, fn)
+		return
+	}
+
+	field := Field{Name: name + optional, Modifiers: modifiers, Doc: doc.Documentation}
+	if p.accept(":") {
+		field.Type = p.typeText(";", "=", "}")
+	}
+	if p.accept("=") {
+		if fn, ok := p.tryArrow(); ok {
+			// Arrow function properties behave like methods
+			fn.Name = field.Name
+			fn.Receiver = s.Name
+			fn.Decorators = decorators
+			fn.Modifiers = modifiers
+			doc.annotate(&fn)
+			s.Methods = append(s.Methods, fn)
+			p.accept(";")
+			return
+		}
+		field.Value = p.exprText(";", "}")
+	}
+	p.accept(";")
+	s.Fields = append(s.Fields, field)
+}
+
+// parseInterface parses an interface declaration
+func (p *esParser) parseInterface(doc ai.Documentation) Interface {
+	p.next() // interface
+	iface := Interface{Name: p.next().Text, Doc: doc}
+	if p.is("<") {
+		iface.TypeParams = p.parseTypeParams()
+	}
+	if p.accept("extends") {
+		for {
+			iface.Embeds = append(iface.Embeds, p.typeText(",", "{"))
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	if !p.accept("{") {
+		return iface
+	}
+	iface.Fields, iface.Methods = p.parseTypeMembers(iface.Name)
+	return iface
+}
+
+// parseTypeMembers parses the members of an interface or object type up to
+// and including the closing brace
+func (p *esParser) parseTypeMembers(receiver string) ([]Field, []Function) {
+	var fields []Field
+	var methods []Function
+
+	for !p.is("}") && p.peek().Kind != esEOF {
+		if p.accept(";") || p.accept(",") {
+			continue
+		}
+		doc := parseJSDoc(p.peek().Doc)
+
+		var modifiers []string
+		if p.is("readonly") && p.peekAt(1).Text != ":" && p.peekAt(1).Text != "?" {
+			modifiers = append(modifiers, p.next().Text)
+		}
+
+		start := p.pos
+		switch {
+		case p.is("["):
+			p.skipBalanced()
+		case p.is("(") || p.is("<"):
+			// Call signature; leave the name empty
+		case p.is("new") && (p.peekAt(1).Text == "(" || p.peekAt(1).Text == "<"):
+			p.next()
+		default:
+			p.next()
+		}
+		name := p.text(start, p.pos)
+		if p.accept("?") {
+			name += "?"
+		}
+
+		if p.is("(") || p.is("<") {
+			fn := Function{Name: name, Receiver: receiver}
+			p.parseSignature(&fn)
+			doc.annotate(&fn)
+			methods = append(methods, fn)
+			continue
+		}
+
+		field := Field{Name: name, Modifiers: modifiers, Doc: doc.Documentation}
+		if p.accept(":") {
+			field.Type = p.typeText(";", ",", "}")
+		}
+		fields = append(fields, field)
+	}
+	p.accept("}")
+	return fields, methods
+}
+
+// parseTypeAlias parses "type Name<T> = ..."
+func (p *esParser) parseTypeAlias(doc ai.Documentation) TypeDef {
+	p.next() // type
+	t := TypeDef{Name: p.next().Text, Alias: true, Doc: doc}
+	if p.is("<") {
+		t.TypeParams = p.parseTypeParams()
+	}
+	if p.accept("=") {
+		t.Underlying = p.typeText(";")
+	}
+	p.accept(";")
+	return t
+}
+
+// parseEnum parses "[const] enum Name { A = 1, B }"
+func (p *esParser) parseEnum(doc ai.Documentation) Enum {
+	e := Enum{Doc: doc}
+	if p.accept("const") {
+		e.Const = true
+	}
+	p.next() // enum
+	e.Name = p.next().Text
+	if !p.accept("{") {
+		return e
+	}
+	for !p.is("}") && p.peek().Kind != esEOF {
+		member := Value{
+			Name: strings.Trim(p.peek().Text, `"'`),
+			Doc:  parseJSDoc(p.peek().Doc).Documentation,
+		}
+		p.next()
+		if p.accept("=") {
+			member.Value = p.exprText(",", "}")
+		}
+		e.Members = append(e.Members, member)
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.accept("}")
+	return e
+}
+
+// parseVariables records "const f = (...) => {}" and "const f = function () {}"
+// as functions and skips every other declaration
+func (p *esParser) parseVariables(prefix string, doc jsDoc, exported bool) {
+	p.next() // const, let or var
+	for {
+		if p.peek().Kind != esIdent {
+			p.skipStatement()
+			return
+		}
+		name := p.next().Text
+		var declared string
+		if p.ts && p.accept(":") {
+			declared = p.typeText("=", ",", ";")
+		}
+
+		if p.accept("=") {
+			if fn, ok := p.tryFunctionExpression(); ok {
+				fn.Name = prefix + name
+				fn.Exported = exported
+				doc.annotate(&fn)
+				if declared != "" && len(fn.Results) == 0 {
+					fn.Results = []Parameter{{Type: declared}}
+				}
+				p.addFunc(fn)
+			} else if p.is("{") && p.hasObjectMethods() {
+				p.parseObjectLiteral(prefix+name+".", exported, false)
+			} else {
+				p.exprText(",", ";")
+			}
+		}
+
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.accept(";")
+}
+
+// hasObjectMethods reports whether the object literal at the current
+// position defines a method or a function-valued property
+func (p *esParser) hasObjectMethods() bool {
+	depth := 0
+	for i := p.pos; i < len(p.toks); i++ {
+		tok := p.toks[i]
+		if tok.Kind != esPunct {
+			continue
+		}
+		switch tok.Text {
+		case "(", "[", "{":
+			depth++
+			if tok.Text == "(" && depth == 2 {
+				return true
+			}
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return false
+			}
+		case "=>":
+			if depth == 1 {
+				return true
+			}
+		}
+		if depth == 1 && tok.Text == ":" && i+1 < len(p.toks) && p.toks[i+1].Text == "function" {
+			return true
+		}
+	}
+	return false
+}
+
+// tryFunctionExpression parses a function expression or an arrow function
+// at the current position, restoring the position if there is none
+func (p *esParser) tryFunctionExpression() (Function, bool) {
+	if p.is("function") || p.is("async") && p.peekAt(1).Text == "function" {
+		fn, ok := p.parseFunction(jsDoc{}, nil)
+		return fn, ok
+	}
+	return p.tryArrow()
+}
+
+// tryArrow parses "async? (params): T => body" or "async? x => body"
+func (p *esParser) tryArrow() (Function, bool) {
+	start := p.pos
+	fn := Function{}
+	if p.is("async") && !p.peekAt(1).NewlineBefore && (p.peekAt(1).Text == "(" || p.peekAt(1).Kind == esIdent || p.peekAt(1).Text == "<") {
+		p.next()
+		fn.Async = true
+	}
+
+	switch {
+	case p.peek().Kind == esIdent && p.peekAt(1).Text == "=>":
+		fn.Parameters = []Parameter{{Name: p.next().Text}}
+	case p.is("(") || p.is("<"):
+		if !p.isArrowAhead() {
+			p.pos = start
+			return Function{}, false
+		}
+		p.parseSignature(&fn)
+	default:
+		p.pos = start
+		return Function{}, false
+	}
+
+	if !p.accept("=>") {
+		p.pos = start
+		return Function{}, false
+	}
+	if p.is("{") {
+		fn.Calls = p.skipBody()
+	} else {
+		fn.Calls = p.skipExpression()
+	}
+	return fn, true
+}
+
+// isArrowAhead reports whether the balanced group at the current position
+// is followed by "=>" or a return type annotation
+func (p *esParser) isArrowAhead() bool {
+	start := p.pos
+	defer func() { p.pos = start }()
+
+	if p.is("<") {
+		p.parseTypeParams()
+	}
+	if !p.is("(") {
+		return false
+	}
+	p.skipBalanced()
+	return p.is("=>") || p.ts && p.is(":")
+}
+
+// skipStatement skips to the end of the current statement, using automatic
+// semicolon insertion heuristics when there is no semicolon
+func (p *esParser) skipStatement() {
+	depth := 0
+	first := true
+	for {
+		tok := p.peek()
+		if tok.Kind == esEOF {
+			return
+		}
+		if depth == 0 && !first {
+			if tok.Text == ";" {
+				p.next()
+				return
+			}
+			if tok.Text == "}" {
+				return
+			}
+			if tok.NewlineBefore && p.endsStatement(p.toks[p.pos-1], tok) {
+				return
+			}
+		}
+		first = false
+		switch tok.Text {
+		case "(", "[", "{":
+			if tok.Kind == esPunct {
+				depth++
+			}
+		case ")", "]", "}":
+			if tok.Kind == esPunct && depth > 0 {
+				depth--
+			}
+		}
+		p.next()
+		if depth == 0 && tok.Text == "}" && p.peek().NewlineBefore {
+			// End of a block statement such as "if (...) { ... }"
+			if next := p.peek(); next.Kind == esIdent && !isESOperatorWord(next.Text) {
+				return
+			}
+		}
+	}
+}
+
+// endsStatement reports whether a line break between prev and next ends a statement
+func (p *esParser) endsStatement(prev, next esToken) bool {
+	switch prev.Kind {
+	case esIdent, esNumber, esString, esTemplate, esRegex:
+	default:
+		if prev.Text != ")" && prev.Text != "]" && prev.Text != "}" && prev.Text != "++" && prev.Text != "--" {
+			return false
+		}
+	}
+	if prev.Kind == esIdent && isESOperatorWord(prev.Text) {
+		return false
+	}
+	switch next.Kind {
+	case esIdent:
+		return !isESOperatorWord(next.Text)
+	case esNumber, esString, esTemplate:
+		return true
+	}
+	return next.Text == "@" || next.Text == "++" || next.Text == "--" || next.Text == "!"
+}
+
+func isESOperatorWord(word string) bool {
+	switch word {
+	case "in", "of", "instanceof", "as", "satisfies", "extends", "keyof", "typeof":
+		return true
+	}
+	return false
+}
+
+// skipBalanced skips a bracketed group starting at the current token
+func (p *esParser) skipBalanced() {
+	depth := 0
+	for p.peek().Kind != esEOF {
+		tok := p.next()
+		if tok.Kind != esPunct {
+			continue
+		}
+		switch tok.Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		case "<":
+			if depth == 0 {
+				depth++
+			}
+		case ">":
+			if depth == 1 && p.toks[p.pos-1].Text == ">" && p.isAngleGroup() {
+				depth--
+			}
+		}
+		if depth <= 0 {
+			return
+		}
+	}
+}
+
+// isAngleGroup reports whether the group being skipped was opened with "<"
+func (p *esParser) isAngleGroup() bool {
+	for i := p.pos - 1; i >= 0; i-- {
+		switch p.toks[i].Text {
+		case "<":
+			return true
+		case "(", "[", "{":
+			return false
+		}
+	}
+	return false
+}
+
+// skipBody skips a "{ ... }" block and returns the plain function names it calls
+func (p *esParser) skipBody() []string {
+	start := p.pos
+	p.skipBalanced()
+	return p.callsIn(start, p.pos)
+}
+
+// skipExpression skips an expression body of an arrow function
+func (p *esParser) skipExpression() []string {
+	start := p.pos
+	p.exprText(",", ";", ")", "]", "}")
+	return p.callsIn(start, p.pos)
+}
+
+func (p *esParser) callsIn(start, end int) []string {
+	var calls []string
+	for i := start; i+1 < end; i++ {
+		if p.toks[i].Kind == esIdent && p.toks[i+1].Text == "(" && (i == 0 || p.toks[i-1].Text != ".") {
+			calls = append(calls, p.toks[i].Text)
+		}
+	}
+	return calls
+}
+
+// typeText consumes a type annotation up to a top-level stop token or the
+// end of the line, and returns its source text
+func (p *esParser) typeText(stops ...string) string {
+	return p.consumeText(true, stops)
+}
+
+// exprText consumes an expression up to a top-level stop token or the end
+// of the statement, and returns its source text
+func (p *esParser) exprText(stops ...string) string {
+	return p.consumeText(false, stops)
+}
+
+func (p *esParser) consumeText(isType bool, stops []string) string {
+	start := p.pos
+	depth, angle := 0, 0
+	for {
+		tok := p.peek()
+		if tok.Kind == esEOF {
+			break
+		}
+		if depth == 0 && angle == 0 && p.pos > start {
+			// Keywords such as "implements" may stop the text too
+			if (tok.Kind == esPunct || tok.Kind == esIdent) && containsString(stops, tok.Text) {
+				break
+			}
+			if tok.NewlineBefore && p.endsStatement(p.toks[p.pos-1], tok) {
+				break
+			}
+		} else if depth == 0 && angle == 0 && tok.Kind == esPunct && containsString(stops, tok.Text) && tok.Text != "{" && tok.Text != "(" && tok.Text != "<" {
+			break
+		}
+		if tok.Kind == esPunct {
+			switch tok.Text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				if depth == 0 {
+					return p.text(start, p.pos)
+				}
+				depth--
+			case "<":
+				if isType {
+					angle++
+				}
+			case ">", ">>", ">>>":
+				if isType {
+					angle -= len(tok.Text)
+					if angle < 0 {
+						if containsString(stops, ">") {
+							angle = 0
+							return p.text(start, p.pos)
+						}
+						angle = 0
+					}
+				}
+			}
+		}
+		p.next()
+	}
+	return p.text(start, p.pos)
+}
+
+// text returns the source between two token positions with whitespace collapsed
+func (p *esParser) text(from, to int) string {
+	if from >= to {
+		return ""
+	}
+	return strings.Join(strings.Fields(p.src[p.toks[from].Start:p.toks[to-1].End]), " ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
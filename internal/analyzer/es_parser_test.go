@@ -0,0 +1,289 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func parseTS(src string) Package {
+	var pkg Package
+	parseES(src, true, &pkg)
+	return pkg
+}
+
+func TestTSDeclarations(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		check func(t *testing.T, pkg Package)
+	}{
+		{
+			name: "generic interface",
+			src:  "/** A box */\nexport interface Box<T extends object = {}> {\n  readonly value: T;\n  size?: number;\n  map<U>(fn: (v: T) => U): Box<U>;\n}\n",
+			check: func(t *testing.T, pkg Package) {
+				box := pkg.Interfaces[0]
+				if box.Name != "Box" || !box.Exported || box.Doc.Summary != "A box" {
+					t.Errorf("Box = %+v", box)
+				}
+				if want := []Parameter{{Name: "T", Type: "object", Default: "{}"}}; !reflect.DeepEqual(box.TypeParams, want) {
+					t.Errorf("type params = %+v", box.TypeParams)
+				}
+				if len(box.Fields) != 2 || box.Fields[0].Name != "value" || box.Fields[1].Name != "size?" ||
+					!reflect.DeepEqual(box.Fields[0].Modifiers, []string{"readonly"}) {
+					t.Errorf("fields = %+v", box.Fields)
+				}
+				m := box.Methods[0]
+				if m.Name != "map" || m.TypeParams[0].Name != "U" || m.Parameters[0].Type != "(v: T) => U" || m.Results[0].Type != "Box<U>" {
+					t.Errorf("map = %+v", m)
+				}
+			},
+		},
+		{
+			name: "type aliases",
+			src:  "export type Pair<A, B> = [A, B];\ntype Handler = (e: Event) => void;\n",
+			check: func(t *testing.T, pkg Package) {
+				if len(pkg.Types) != 2 {
+					t.Fatalf("types = %+v", pkg.Types)
+				}
+				pair, handler := pkg.Types[0], pkg.Types[1]
+				if !pair.Alias || !pair.Exported || pair.Underlying != "[A, B]" || len(pair.TypeParams) != 2 {
+					t.Errorf("Pair = %+v", pair)
+				}
+				if handler.Exported || handler.Underlying != "(e: Event) => void" {
+					t.Errorf("Handler = %+v", handler)
+				}
+			},
+		},
+		{
+			name: "enums",
+			src:  "export const enum Color { Red = 1, Green, Blue = \"b\" }\nenum Plain { A }\n",
+			check: func(t *testing.T, pkg Package) {
+				color := pkg.Enums[0]
+				want := []Value{{Name: "Red", Value: "1"}, {Name: "Green"}, {Name: "Blue", Value: `"b"`}}
+				if !color.Const || !color.Exported || !reflect.DeepEqual(color.Members, want) {
+					t.Errorf("Color = %+v", color)
+				}
+				if pkg.Enums[1].Const || pkg.Enums[1].Exported {
+					t.Errorf("Plain = %+v", pkg.Enums[1])
+				}
+			},
+		},
+		{
+			name: "overloads keep the first signature",
+			src:  "export function id<T>(x: T): T;\nexport function id(x: any) { return x; }\n",
+			check: func(t *testing.T, pkg Package) {
+				if len(pkg.Funcs) != 1 || pkg.Funcs[0].Parameters[0].Type != "T" || pkg.Funcs[0].Results[0].Type != "T" {
+					t.Errorf("funcs = %+v", pkg.Funcs)
+				}
+			},
+		},
+		{
+			name: "class members",
+			src: `export abstract class Repo<T> extends Base implements Store<T>, Other {
+  private static count: number = 0;
+  constructor(private readonly db: Db, public name?: string) { super(); }
+  @log() async find(id: string, ...rest: number[]): Promise<T | undefined> { return helper(id); }
+  get size(): number { return 1 }
+  set size(v: number) {}
+  abstract save(item: T): void;
+}
+function helper(s: string) { return s; }
+`,
+			check: func(t *testing.T, pkg Package) {
+				repo := pkg.Structs[0]
+				if !reflect.DeepEqual(repo.Modifiers, []string{"abstract"}) || !reflect.DeepEqual(repo.Bases, []string{"Base"}) ||
+					!reflect.DeepEqual(repo.Implements, []string{"Store<T>", "Other"}) {
+					t.Errorf("Repo = %+v", repo)
+				}
+
+				var fields []string
+				for _, f := range repo.Fields {
+					fields = append(fields, f.Name)
+				}
+				if want := []string{"count", "db", "name"}; !reflect.DeepEqual(fields, want) {
+					t.Errorf("fields = %v, want %v", fields, want)
+				}
+				if !reflect.DeepEqual(repo.Fields[1].Modifiers, []string{"private", "readonly"}) {
+					t.Errorf("parameter property db = %+v", repo.Fields[1])
+				}
+
+				if got, want := funcNames(repo.Methods), []string{"constructor", "find", "size", "size", "save"}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("methods = %v, want %v", got, want)
+				}
+				find := repo.Methods[1]
+				if !find.Async || !reflect.DeepEqual(find.Decorators, []string{"log()"}) ||
+					!reflect.DeepEqual(paramNames(find.Parameters), []string{"id", "...rest"}) ||
+					find.Results[0].Type != "Promise<T | undefined>" || !reflect.DeepEqual(find.Calls, []string{"helper"}) {
+					t.Errorf("find = %+v", find)
+				}
+				if !reflect.DeepEqual(repo.Methods[2].Modifiers, []string{"get"}) || !reflect.DeepEqual(repo.Methods[3].Modifiers, []string{"set"}) {
+					t.Errorf("accessors = %+v, %+v", repo.Methods[2], repo.Methods[3])
+				}
+			},
+		},
+		{
+			name: "namespaces and ambient modules",
+			src:  "namespace NS.Inner { export function f(): void {} }\ndeclare module \"x\" { export function g(): string; }\n",
+			check: func(t *testing.T, pkg Package) {
+				if got, want := funcNames(pkg.Funcs), []string{"NS.Inner.f", "x.g"}; !reflect.DeepEqual(got, want) {
+					t.Errorf("funcs = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name: "generic async arrow function",
+			src:  "export const arrow = async <T,>(a: T, b = 2): Promise<T> => a;\n",
+			check: func(t *testing.T, pkg Package) {
+				fn := pkg.Funcs[0]
+				want := []Parameter{{Name: "a", Type: "T"}, {Name: "b", Default: "2"}}
+				if fn.Name != "arrow" || !fn.Async || !fn.Exported || !reflect.DeepEqual(fn.Parameters, want) || fn.Results[0].Type != "Promise<T>" {
+					t.Errorf("arrow = %+v", fn)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, parseTS(tt.src))
+		})
+	}
+}
+
+func TestJSExports(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		exported map[string]bool
+	}{
+		{
+			name:     "ES module declarations",
+			src:      "export function a() {}\nexport const b = () => 1;\nfunction c() {}\n",
+			exported: map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:     "export list",
+			src:      "function a() {}\nfunction b() {}\nexport { a as renamed, b };\nfunction c() {}\n",
+			exported: map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:     "export list without semicolon",
+			src:      "export { a }\nfunction a() {}\nfunction b() {}\n",
+			exported: map[string]bool{"a": true, "b": false},
+		},
+		{
+			name:     "re-exports",
+			src:      "export * from './x'\nexport * as ns from './y';\nexport { z } from \"z\"\nfunction a() {}\n",
+			exported: map[string]bool{"a": false},
+		},
+		{
+			name:     "export list before module.exports",
+			src:      "export { a };\nmodule.exports = { util };\nfunction util() {}\nfunction a() {}\n",
+			exported: map[string]bool{"util": true, "a": true},
+		},
+		{
+			name:     "CommonJS exports object",
+			src:      "module.exports = { util, other: function (z) {}, short(q) {} };\nfunction util() {}\nfunction hidden() {}\n",
+			exported: map[string]bool{"other": true, "short": true, "util": true, "hidden": false},
+		},
+		{
+			name:     "CommonJS named exports",
+			src:      "exports.named = (n) => n;\nmodule.exports.run = async function () {};\n",
+			exported: map[string]bool{"named": true, "run": true},
+		},
+		{
+			name:     "default export",
+			src:      "export default function () {}\n",
+			exported: map[string]bool{"default": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pkg Package
+			parseES(tt.src, false, &pkg)
+			got := make(map[string]bool)
+			for _, fn := range pkg.Funcs {
+				got[fn.Name] = fn.Exported
+			}
+			if !reflect.DeepEqual(got, tt.exported) {
+				t.Errorf("exported = %v, want %v", got, tt.exported)
+			}
+		})
+	}
+}
+
+func TestJSFunctions(t *testing.T) {
+	src := `const helper = (v) => v * 2;
+export const fetchIt = async function ({ url }, [first]) { await helper(1); };
+export default class Widget extends HTMLElement {
+  static tag = "x-w";
+  connectedCallback() { helper(2); }
+}
+`
+	var pkg Package
+	parseES(src, false, &pkg)
+
+	if got, want := funcNames(pkg.Funcs), []string{"helper", "fetchIt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("funcs = %v, want %v", got, want)
+	}
+	fetchIt := pkg.Funcs[1]
+	if !fetchIt.Async || !reflect.DeepEqual(paramNames(fetchIt.Parameters), []string{"{ url }", "[first]"}) ||
+		!reflect.DeepEqual(fetchIt.Calls, []string{"helper"}) {
+		t.Errorf("fetchIt = %+v", fetchIt)
+	}
+
+	widget := pkg.Structs[0]
+	if widget.Name != "Widget" || !widget.Exported || !reflect.DeepEqual(widget.Bases, []string{"HTMLElement"}) ||
+		len(widget.Fields) != 1 || !reflect.DeepEqual(widget.Fields[0].Modifiers, []string{"static"}) {
+		t.Errorf("Widget = %+v", widget)
+	}
+}
+
+func TestJSDocTags(t *testing.T) {
+	src := `/**
+ * Adds numbers.
+ * Second line.
+ * @param {number} a - first
+ * @param {number} [b=1] second
+ * @param {...number} rest
+ * @returns {number} the sum
+ * @throws {RangeError} when too big
+ * @deprecated use sum
+ * @example add(1, 2)
+ */
+export function add(a, b = 1, ...rest) { return a + b; }
+`
+	var pkg Package
+	parseES(src, false, &pkg)
+	fn := pkg.Funcs[0]
+
+	wantParams := []Parameter{{Name: "a", Type: "number"}, {Name: "b", Type: "number", Default: "1"}, {Name: "...rest", Type: "...number"}}
+	if !reflect.DeepEqual(fn.Parameters, wantParams) {
+		t.Errorf("params = %+v, want types borrowed from the tags", fn.Parameters)
+	}
+	if len(fn.Results) != 1 || fn.Results[0].Type != "number" {
+		t.Errorf("results = %+v", fn.Results)
+	}
+
+	doc := fn.Doc
+	tests := []struct {
+		field, got, want string
+	}{
+		{"summary", doc.Summary, "Adds numbers.\nSecond line."},
+		{"a", doc.Parameters[0].Description, "first"},
+		{"b", doc.Parameters[1].Name + " " + doc.Parameters[1].Description, "b second"},
+		{"returns", doc.Returns, "(number) the sum"},
+		{"throws", doc.Throws[0], "(RangeError) when too big"},
+		{"deprecation", doc.DeprecationNote, "use sum"},
+		{"example", doc.UsageExample, "add(1, 2)"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+	if !doc.Deprecated {
+		t.Error("not marked deprecated")
+	}
+}
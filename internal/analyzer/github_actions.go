@@ -0,0 +1,357 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"gopkg.in/yaml.v3"
+)
+
+// GitHub Actions files are documented on a single CI/CD page
+const (
+	cicdPackageName = "CI/CD"
+	cicdImportPath  = "ci-cd"
+)
+
+// secrets.NAME and secrets['NAME'] inside ${{ }} expressions
+var secretReference = regexp.MustCompile(`secrets\.([A-Za-z_][A-Za-z0-9_]*)|secrets\[\s*['"]([^'"]+)['"]\s*\]`)
+
+// isGitHubActionsFile reports whether path is a workflow under
+// .github/workflows or an action metadata file (action.yml)
+func isGitHubActionsFile(path string) bool {
+	slashed := filepath.ToSlash(path)
+	base := filepath.Base(slashed)
+	if base == "action.yml" || base == "action.yaml" {
+		return true
+	}
+	return strings.Contains(slashed, ".github/workflows/") && hasExtension(slashed, []string{".yml", ".yaml"})
+}
+
+// analyzeGitHubActions describes a workflow as one Struct of kind "workflow"
+// followed by one of kind "job" per job, or an action as one Struct of kind
+// "action". It returns nil when the document is neither.
+func (y *YAMLAnalyzer) analyzeGitHubActions(node *yaml.Node, path, content string) []Struct {
+	comment := y.extractYAMLComment(node)
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	if comment == "" && len(node.Content) > 0 {
+		// A comment right above "name:" describes the whole workflow
+		comment = yamlComment(node.Content[0].HeadComment)
+	}
+
+	switch {
+	case y.hasKey(node, "jobs"):
+		return y.analyzeWorkflow(node, path, content, comment)
+	case y.hasKey(node, "runs"):
+		return []Struct{y.analyzeAction(node, path, content)}
+	}
+	return nil
+}
+
+func (y *YAMLAnalyzer) analyzeWorkflow(node *yaml.Node, path, content, comment string) []Struct {
+	name := y.getValue(node, "name")
+	if name == "" {
+		name = filepath.Base(path)
+	}
+	workflow := Struct{
+		Name:    name,
+		Kind:    "workflow",
+		Doc:     ai.Documentation{Summary: comment},
+		DocYAML: ai.YAMLDocumentation{Summary: comment},
+	}
+
+	// yaml.v3 keeps "on" as a plain string key
+	if on := y.getValueNode(node, "on"); on != nil {
+		workflow.Fields = append(workflow.Fields, Field{Name: "on", Type: "triggers", Fields: y.workflowTriggers(on)})
+	}
+	if perms := y.getValueNode(node, "permissions"); perms != nil {
+		workflow.Fields = append(workflow.Fields, y.permissionsField(perms))
+	}
+	if env := y.getValueNode(node, "env"); env != nil {
+		workflow.Fields = append(workflow.Fields, Field{Name: "env", Type: "map", Fields: y.extractYAMLFields(env, 1)})
+	}
+	if concurrency := y.getValueNode(node, "concurrency"); concurrency != nil {
+		workflow.Fields = append(workflow.Fields, Field{Name: "concurrency", Type: y.nodeKindToString(concurrency.Kind), Value: concurrency.Value, Fields: y.extractYAMLFields(concurrency, 1)})
+	}
+	workflow.Fields = append(workflow.Fields, referenceFields(node, content)...)
+
+	structs := []Struct{workflow}
+	for _, job := range y.mappingPairs(y.getValueNode(node, "jobs")) {
+		structs = append(structs, y.analyzeJob(job.Key, job.Value))
+	}
+	return structs
+}
+
+// workflowTriggers normalizes "on: push", "on: [push, pull_request]" and
+// the mapping form into one field per event
+func (y *YAMLAnalyzer) workflowTriggers(on *yaml.Node) []Field {
+	var triggers []Field
+	switch on.Kind {
+	case yaml.ScalarNode:
+		triggers = append(triggers, Field{Name: on.Value, Type: "event"})
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			triggers = append(triggers, Field{Name: event.Value, Type: "event"})
+		}
+	case yaml.MappingNode:
+		for _, pair := range y.mappingPairs(on) {
+			key, value := pair.Key, pair.Value
+			trigger := Field{
+				Name:    key.Value,
+				Type:    "event",
+				Value:   triggerFilter(key.Value, value),
+				DocYAML: ai.YAMLDocumentation{Summary: fieldComment(key, value)},
+			}
+			if value.Kind == yaml.MappingNode {
+				trigger.Fields = y.extractYAMLFields(value, 1)
+			}
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// triggerFilter summarizes the filters of an event, e.g. "branches: main, release/*"
+func triggerFilter(event string, node *yaml.Node) string {
+	var parts []string
+	switch node.Kind {
+	case yaml.SequenceNode:
+		// schedule: a list of crons
+		for _, item := range node.Content {
+			if cron := mappingValue(item, "cron"); cron != nil {
+				parts = append(parts, "cron: "+cron.Value)
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch value.Kind {
+			case yaml.ScalarNode:
+				parts = append(parts, key+": "+value.Value)
+			case yaml.SequenceNode:
+				var items []string
+				for _, item := range value.Content {
+					items = append(items, item.Value)
+				}
+				parts = append(parts, key+": "+strings.Join(items, ", "))
+			case yaml.MappingNode:
+				// workflow_dispatch inputs, workflow_call inputs/secrets
+				parts = append(parts, key+": "+strings.Join(mappingKeys(value), ", "))
+			}
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// permissionsField records "permissions: read-all" or a scope → access map
+func (y *YAMLAnalyzer) permissionsField(node *yaml.Node) Field {
+	field := Field{Name: "permissions", Type: "permissions", Value: node.Value}
+	for _, pair := range y.mappingPairs(node) {
+		field.Fields = append(field.Fields, Field{Name: pair.Key.Value, Type: "scope", Value: pair.Value.Value})
+	}
+	return field
+}
+
+func (y *YAMLAnalyzer) analyzeJob(key, node *yaml.Node) Struct {
+	job := Struct{
+		Name: key.Value,
+		Kind: "job",
+		Doc:  ai.Documentation{Summary: fieldComment(key, node)},
+	}
+	if name := y.getValue(node, "name"); name != "" && job.Doc.Summary == "" {
+		job.Doc.Summary = name
+	}
+	job.DocYAML.Summary = job.Doc.Summary
+	if node.Kind != yaml.MappingNode {
+		return job
+	}
+
+	for _, pair := range y.mappingPairs(node) {
+		k, v := pair.Key, pair.Value
+		switch k.Value {
+		case "name":
+			continue
+		case "steps":
+			job.Fields = append(job.Fields, Field{Name: "steps", Type: "array", Fields: y.workflowSteps(v)})
+		case "permissions":
+			job.Fields = append(job.Fields, y.permissionsField(v))
+		case "needs":
+			field := Field{Name: "needs", Type: "array"}
+			if v.Kind == yaml.ScalarNode {
+				field.Fields = []Field{{Name: "[0]", Type: "scalar", Value: v.Value}}
+			}
+			for j, need := range v.Content {
+				field.Fields = append(field.Fields, Field{Name: fmt.Sprintf("[%d]", j), Type: "scalar", Value: need.Value})
+			}
+			job.Fields = append(job.Fields, field)
+		default:
+			field := Field{Name: k.Value, Type: y.nodeKindToString(v.Kind), Value: v.Value}
+			if v.Kind == yaml.SequenceNode {
+				// runs-on: [self-hosted, linux]
+				var items []string
+				for _, item := range v.Content {
+					items = append(items, item.Value)
+				}
+				field.Value = strings.Join(items, ", ")
+			}
+			if v.Kind == yaml.MappingNode {
+				field.Fields = y.extractYAMLFields(v, 1)
+			}
+			job.Fields = append(job.Fields, field)
+		}
+	}
+	return job
+}
+
+// workflowSteps describes each step by its name, action or command
+func (y *YAMLAnalyzer) workflowSteps(node *yaml.Node) []Field {
+	var steps []Field
+	for i, step := range node.Content {
+		step = resolveYAMLAlias(step)
+		field := Field{Name: fmt.Sprintf("[%d]", i), Type: "step"}
+		if step.Kind != yaml.MappingNode {
+			steps = append(steps, field)
+			continue
+		}
+		name := y.getValue(step, "name")
+		if uses := y.getValue(step, "uses"); uses != "" {
+			field.Type = "uses"
+			field.Value = uses
+		} else if run := y.getValue(step, "run"); run != "" {
+			field.Type = "run"
+			field.Value = strings.TrimSpace(run)
+		}
+		field.DocYAML = ai.YAMLDocumentation{Summary: name}
+		for _, pair := range y.mappingPairs(step) {
+			k, v := pair.Key, pair.Value
+			if k.Value == "name" || k.Value == "uses" || k.Value == "run" {
+				continue
+			}
+			sub := Field{Name: k.Value, Type: y.nodeKindToString(v.Kind), Value: v.Value}
+			if v.Kind != yaml.ScalarNode {
+				sub.Value = ""
+				sub.Fields = y.extractYAMLFields(v, 2)
+			}
+			field.Fields = append(field.Fields, sub)
+		}
+		steps = append(steps, field)
+	}
+	return steps
+}
+
+// analyzeAction describes an action's inputs, outputs and how it runs
+func (y *YAMLAnalyzer) analyzeAction(node *yaml.Node, path, content string) Struct {
+	name := y.getValue(node, "name")
+	if name == "" {
+		name = filepath.Base(filepath.Dir(path))
+	}
+	runs := y.getValueNode(node, "runs")
+	using := y.getValue(runs, "using")
+
+	action := Struct{
+		Name:    name,
+		Kind:    "action",
+		Doc:     ai.Documentation{Summary: y.getValue(node, "description")},
+		DocYAML: ai.YAMLDocumentation{Summary: y.getValue(node, "description")},
+	}
+
+	for _, section := range []string{"inputs", "outputs"} {
+		list := y.mappingPairs(y.getValueNode(node, section))
+		if len(list) == 0 {
+			continue
+		}
+		field := Field{Name: section, Type: "map"}
+		for _, pair := range list {
+			k, v := pair.Key, pair.Value
+			item := Field{
+				Name:    k.Value,
+				Type:    strings.TrimSuffix(section, "s"),
+				Value:   y.getValue(v, "default"),
+				DocYAML: ai.YAMLDocumentation{Summary: strings.TrimSpace(y.getValue(v, "description"))},
+			}
+			if section == "outputs" {
+				item.Value = y.getValue(v, "value")
+			}
+			if y.getValue(v, "required") == "true" {
+				item.Modifiers = append(item.Modifiers, "required")
+			}
+			if msg := y.getValue(v, "deprecationMessage"); msg != "" {
+				item.Modifiers = append(item.Modifiers, "deprecated")
+			}
+			field.Fields = append(field.Fields, item)
+		}
+		action.Fields = append(action.Fields, field)
+	}
+
+	if runs != nil && runs.Kind == yaml.MappingNode {
+		field := Field{Name: "runs", Type: "map", Value: using}
+		for _, pair := range y.mappingPairs(runs) {
+			k, v := pair.Key, pair.Value
+			switch {
+			case k.Value == "steps":
+				field.Fields = append(field.Fields, Field{Name: "steps", Type: "array", Fields: y.workflowSteps(v)})
+			case k.Value != "using" && v.Kind == yaml.ScalarNode:
+				// main, pre, post (JavaScript) or image, entrypoint (Docker)
+				field.Fields = append(field.Fields, Field{Name: k.Value, Type: "scalar", Value: v.Value})
+			}
+		}
+		action.Fields = append(action.Fields, field)
+	}
+	action.Fields = append(action.Fields, referenceFields(node, content)...)
+	return action
+}
+
+// referenceFields lists the actions a file uses and the secrets it reads
+func referenceFields(node *yaml.Node, content string) []Field {
+	var fields []Field
+
+	var uses []string
+	collectUses(node, &uses)
+	if len(uses) > 0 {
+		field := Field{Name: "uses", Type: "array"}
+		for i, u := range uses {
+			field.Fields = append(field.Fields, Field{Name: fmt.Sprintf("[%d]", i), Type: "action", Value: u})
+		}
+		fields = append(fields, field)
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range secretReference.FindAllStringSubmatch(content, -1) {
+		seen[match[1]+match[2]] = true
+	}
+	if len(seen) > 0 {
+		names := make([]string, 0, len(seen))
+		for name := range seen {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		field := Field{Name: "secrets", Type: "array"}
+		for i, name := range names {
+			field.Fields = append(field.Fields, Field{Name: fmt.Sprintf("[%d]", i), Type: "secret", Value: name})
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// collectUses gathers every distinct "uses:" reference, in document order
+func collectUses(node *yaml.Node, uses *[]string) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "uses" && node.Content[i+1].Kind == yaml.ScalarNode {
+				*uses = appendOnce(*uses, node.Content[i+1].Value)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		collectUses(child, uses)
+	}
+}
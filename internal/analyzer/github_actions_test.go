@@ -0,0 +1,252 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fieldValues lists the values of the nested fields of f
+func fieldValues(f *Field) []string {
+	if f == nil {
+		return nil
+	}
+	var values []string
+	for _, item := range f.Fields {
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+func TestWorkflowTriggers(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"string", "push", []string{"push="}},
+		{"list", "[push, pull_request]", []string{"push=", "pull_request="}},
+		{
+			name: "map with filters",
+			src: `push:
+  branches: [main, "release/*"]
+  paths-ignore: [docs/**]
+pull_request:
+schedule:
+  - cron: "0 3 * * *"
+workflow_dispatch:
+  inputs:
+    dry-run: {type: boolean}
+    target: {type: string}
+`,
+			want: []string{
+				"push=branches: main, release/*; paths-ignore: docs/**",
+				"pull_request=",
+				"schedule=cron: 0 3 * * *",
+				"workflow_dispatch=inputs: dry-run, target",
+			},
+		},
+	}
+	y := &YAMLAnalyzer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, trigger := range y.workflowTriggers(parseYAMLTest(t, tt.src)) {
+				if trigger.Type != "event" {
+					t.Errorf("%s type = %q", trigger.Name, trigger.Type)
+				}
+				got = append(got, trigger.Name+"="+trigger.Value)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("triggers = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeWorkflow(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, ".github/workflows/ci.yml", `# Builds and ships every commit
+name: CI
+on: [push]
+permissions:
+  contents: read
+  id-token: write
+env:
+  GO_VERSION: "1.22"
+jobs:
+  # Runs the test suite
+  test:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-latest, macos-latest]
+        go: ["1.21", "1.22"]
+    steps:
+      - uses: actions/checkout@v4
+      - name: Test
+        run: go test ./...
+        env:
+          TOKEN: ${{ secrets.API_TOKEN }}
+  build:
+    name: Build the image
+    needs: test
+    runs-on: [self-hosted, linux]
+    permissions: read-all
+    steps:
+      - uses: actions/checkout@v4
+      - uses: docker/login-action@v3
+        with:
+          password: ${{ secrets['REGISTRY_PASSWORD'] }}
+  deploy:
+    needs: [test, build]
+    uses: ./.github/workflows/deploy.yml
+    with:
+      environment: prod
+    secrets: inherit
+`)
+	if !isGitHubActionsFile(path) {
+		t.Fatal("workflow is not recognized")
+	}
+	y := &YAMLAnalyzer{}
+	file, err := y.analyzeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Language != "GitHub Actions" || file.Packages[0].ImportPath != cicdImportPath {
+		t.Fatalf("file = %s (%s)", file.Language, file.Packages[0].ImportPath)
+	}
+	structs := file.Packages[0].Structs
+
+	var kinds []string
+	for _, s := range structs {
+		kinds = append(kinds, s.Kind+" "+s.Name)
+	}
+	if want := []string{"workflow CI", "job test", "job build", "job deploy"}; !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("structs = %v, want %v", kinds, want)
+	}
+
+	workflow := structs[0]
+	if workflow.Doc.Summary != "Builds and ships every commit" {
+		t.Errorf("workflow summary = %q", workflow.Doc.Summary)
+	}
+	perms := findField(workflow.Fields, "permissions")
+	if perms == nil || perms.Type != "permissions" || len(perms.Fields) != 2 ||
+		perms.Fields[1].Name != "id-token" || perms.Fields[1].Value != "write" {
+		t.Errorf("workflow permissions = %+v", perms)
+	}
+	if want := []string{"actions/checkout@v4", "docker/login-action@v3", "./.github/workflows/deploy.yml"}; !reflect.DeepEqual(fieldValues(findField(workflow.Fields, "uses")), want) {
+		t.Errorf("uses = %v, want %v", fieldValues(findField(workflow.Fields, "uses")), want)
+	}
+	if want := []string{"API_TOKEN", "REGISTRY_PASSWORD"}; !reflect.DeepEqual(fieldValues(findField(workflow.Fields, "secrets")), want) {
+		t.Errorf("secrets = %v, want %v", fieldValues(findField(workflow.Fields, "secrets")), want)
+	}
+
+	test, build, deploy := structs[1], structs[2], structs[3]
+	if test.Doc.Summary != "Runs the test suite" || build.Doc.Summary != "Build the image" {
+		t.Errorf("job docs = %q, %q", test.Doc.Summary, build.Doc.Summary)
+	}
+	if runsOn := findField(test.Fields, "runs-on"); runsOn == nil || runsOn.Value != "${{ matrix.os }}" {
+		t.Errorf("test runs-on = %+v", runsOn)
+	}
+	matrix := findField(findField(test.Fields, "strategy").Fields, "matrix")
+	if matrix == nil || len(matrix.Fields) != 2 || matrix.Fields[0].Name != "os" || matrix.Fields[1].Name != "go" {
+		t.Errorf("matrix = %+v", matrix)
+	}
+	steps := findField(test.Fields, "steps").Fields
+	if len(steps) != 2 || steps[0].Type != "uses" || steps[0].Value != "actions/checkout@v4" ||
+		steps[1].Type != "run" || steps[1].Value != "go test ./..." || steps[1].DocYAML.Summary != "Test" {
+		t.Errorf("test steps = %+v", steps)
+	}
+
+	if runsOn := findField(build.Fields, "runs-on"); runsOn == nil || runsOn.Value != "self-hosted, linux" {
+		t.Errorf("build runs-on = %+v", runsOn)
+	}
+	if needs := fieldValues(findField(build.Fields, "needs")); !reflect.DeepEqual(needs, []string{"test"}) {
+		t.Errorf("build needs = %v", needs)
+	}
+	if perms := findField(build.Fields, "permissions"); perms == nil || perms.Value != "read-all" || len(perms.Fields) != 0 {
+		t.Errorf("build permissions = %+v", perms)
+	}
+
+	if needs := fieldValues(findField(deploy.Fields, "needs")); !reflect.DeepEqual(needs, []string{"test", "build"}) {
+		t.Errorf("deploy needs = %v", needs)
+	}
+	if uses := findField(deploy.Fields, "uses"); uses == nil || uses.Value != "./.github/workflows/deploy.yml" {
+		t.Errorf("reusable workflow = %+v", uses)
+	}
+	if with := findField(deploy.Fields, "with"); with == nil || len(with.Fields) != 1 || with.Fields[0].Value != "prod" {
+		t.Errorf("deploy with = %+v", with)
+	}
+}
+
+func TestAnalyzeCompositeAction(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "setup-tools/action.yml", `name: Setup tools
+description: Installs the toolchain
+inputs:
+  version:
+    description: Version to install
+    required: true
+  cache:
+    description: Cache downloads
+    default: "true"
+    deprecationMessage: Caching is always on
+outputs:
+  path:
+    description: Where the tools live
+    value: ${{ steps.install.outputs.path }}
+runs:
+  using: composite
+  steps:
+    - id: install
+      name: Install
+      run: ./install.sh ${{ inputs.version }}
+      shell: bash
+    - uses: actions/cache@v4
+      with:
+        key: tools-${{ secrets.CACHE_SALT }}
+`)
+	if !isGitHubActionsFile(path) {
+		t.Fatal("action.yml is not recognized")
+	}
+	y := &YAMLAnalyzer{}
+	file, err := y.analyzeFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	structs := file.Packages[0].Structs
+	if len(structs) != 1 || structs[0].Kind != "action" || structs[0].Name != "Setup tools" {
+		t.Fatalf("structs = %+v", structs)
+	}
+	action := structs[0]
+	if action.Doc.Summary != "Installs the toolchain" {
+		t.Errorf("summary = %q", action.Doc.Summary)
+	}
+
+	wantInputs := []Field{
+		{Name: "version", Type: "input", Modifiers: []string{"required"}},
+		{Name: "cache", Type: "input", Value: "true", Modifiers: []string{"deprecated"}},
+	}
+	wantInputs[0].DocYAML.Summary = "Version to install"
+	wantInputs[1].DocYAML.Summary = "Cache downloads"
+	if inputs := findField(action.Fields, "inputs"); inputs == nil || !reflect.DeepEqual(inputs.Fields, wantInputs) {
+		t.Errorf("inputs = %+v, want %+v", inputs, wantInputs)
+	}
+	wantOutputs := []Field{{Name: "path", Type: "output", Value: "${{ steps.install.outputs.path }}"}}
+	wantOutputs[0].DocYAML.Summary = "Where the tools live"
+	if outputs := findField(action.Fields, "outputs"); outputs == nil || !reflect.DeepEqual(outputs.Fields, wantOutputs) {
+		t.Errorf("outputs = %+v, want %+v", outputs, wantOutputs)
+	}
+
+	runs := findField(action.Fields, "runs")
+	if runs == nil || runs.Value != "composite" {
+		t.Fatalf("runs = %+v", runs)
+	}
+	steps := findField(runs.Fields, "steps").Fields
+	if len(steps) != 2 || steps[0].Type != "run" || steps[0].DocYAML.Summary != "Install" || steps[1].Value != "actions/cache@v4" {
+		t.Errorf("steps = %+v", steps)
+	}
+	if secrets := fieldValues(findField(action.Fields, "secrets")); !reflect.DeepEqual(secrets, []string{"CACHE_SALT"}) {
+		t.Errorf("secrets = %v", secrets)
+	}
+}
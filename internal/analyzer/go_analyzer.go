@@ -1,11 +1,14 @@
 package analyzer
 
 import (
+	"context"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
@@ -14,43 +17,63 @@ import (
 
 type GoAnalyzer struct{}
 
-func (g *GoAnalyzer) Supports(projectDir string) bool {
-	return exists(filepath.Join(projectDir, "go.mod")) || utils.HasFilesWithExtension(projectDir, ".go")
+func (g *GoAnalyzer) Supports(projectDir string, opts Options) bool {
+	return exists(filepath.Join(projectDir, "go.mod")) || hasFiles(projectDir, opts, ".go")
 }
 
-func (g *GoAnalyzer) Analyze(projectDir string) (*AnalyzerResult, error) {
-	files, err := os.ReadDir(projectDir)
+func (g *GoAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	if opts.GoTypeCheck {
+		result, err := g.analyzeTypeChecked(ctx, projectDir, opts)
+		if err == nil {
+			return result, nil
+		}
+		fmt.Printf("⚠️ Type-checked Go analysis failed, falling back to per-file parsing: %v\n", err)
+	}
+
+	files, reused, err := g.analyzeDir(ctx, projectDir, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	result := &AnalyzerResult{}
-
+	modules := make(map[string]string)
 	for _, file := range files {
-		fullPath := filepath.Join(projectDir, file.Name())
-
-		if file.IsDir() {
-			// Recursively analyze subdirectories
-			subResult, err := g.Analyze(fullPath)
-			if err != nil {
-				return nil, err
-			}
-			result.Merge(subResult)
-			continue
-		}
-
-		if strings.HasSuffix(file.Name(), ".go") {
-			fileResult, err := g.AnalyzeFile(fullPath)
-			if err != nil {
-				return nil, err
-			}
-			result.Files = append(result.Files, fileResult)
+		pkg := &file.Packages[0]
+		pkg.ImportPath = goImportPath(projectDir, filepath.Dir(file.Path), modules)
+		if strings.HasSuffix(pkg.Name, "_test") {
+			// External test packages live beside the package they test
+			pkg.ImportPath += "_test"
 		}
 	}
 
+	result := &AnalyzerResult{Files: append(groupGoPackages(files), reused...)}
+	sortByPath(result.Files)
 	return result, nil
 }
 
+// analyzeDir parses every .go file the walker accepts on a pool of workers.
+// Packages whose files are unchanged since the previous run are reused.
+func (g *GoAnalyzer) analyzeDir(ctx context.Context, projectDir string, opts Options) ([]*AnalyzedFile, []*AnalyzedFile, error) {
+	paths, err := walkFiles(projectDir, opts, ".go")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reused, paths := reuseGoPackages(paths, opts.Previous)
+	files, err := analyzeFiles(ctx, paths, opts, g.AnalyzeFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, reused, nil
+}
+
+// sortByPath orders packages by directory so reused and freshly parsed
+// packages always come out in the same order
+func sortByPath(files []*AnalyzedFile) {
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+}
+
 // Merge merges another result into this one
 func (r *AnalyzerResult) Merge(other *AnalyzerResult) {
 	r.Files = append(r.Files, other.Files...)
@@ -70,8 +93,13 @@ func (g *GoAnalyzer) AnalyzeFile(path string) (*AnalyzedFile, error) {
 		return nil, err
 	}
 
+	return g.analyzeSyntax(fset, path, node), nil
+}
+
+// analyzeSyntax extracts declarations from an already parsed Go file
+func (g *GoAnalyzer) analyzeSyntax(fset *token.FileSet, path string, node *ast.File) *AnalyzedFile {
 	pkg := Package{
-		Name: filepath.Base(filepath.Dir(path)),
+		Name: node.Name.Name,
 		Path: path,
 	}
 
@@ -99,50 +127,38 @@ func (g *GoAnalyzer) AnalyzeFile(path string) (*AnalyzedFile, error) {
 	for _, decl := range node.Decls {
 		switch d := decl.(type) {
 		case *ast.GenDecl:
-			if d.Tok == token.TYPE {
+			switch d.Tok {
+			case token.TYPE:
 				for _, spec := range d.Specs {
 					typeSpec := spec.(*ast.TypeSpec)
-					if structType, ok := typeSpec.Type.(*ast.StructType); ok {
-						s := Struct{
-							Name:    typeSpec.Name.Name,
-							Doc:     ai.Documentation{Summary: utils.DocToString(d.Doc)},
-							Fields:  []Field{},
-							Methods: []Function{},
-						}
+					doc := specDoc(d, typeSpec.Doc)
 
-						// Process struct fields
-						if structType.Fields != nil {
-							for _, field := range structType.Fields.List {
-								fieldType := utils.ExprToString(field.Type)
-								if len(field.Names) == 0 {
-									// Embedded field
-									s.Fields = append(s.Fields, Field{
-										Name: fieldType,
-										Type: fieldType,
-										Doc:  ai.Documentation{Summary: utils.DocToString(field.Doc)},
-									})
-								} else {
-									// Regular fields
-									for _, name := range field.Names {
-										s.Fields = append(s.Fields, Field{
-											Name: name.Name,
-											Type: fieldType,
-											Tag:  utils.FieldTagToString(field.Tag),
-											Doc:  ai.Documentation{Summary: utils.DocToString(field.Doc)},
-										})
-									}
-								}
-							}
-						}
-						pkg.Structs = append(pkg.Structs, s)
+					switch t := typeSpec.Type.(type) {
+					case *ast.StructType:
+						pkg.Structs = append(pkg.Structs, extractStruct(typeSpec, t, doc))
+					case *ast.InterfaceType:
+						pkg.Interfaces = append(pkg.Interfaces, extractInterface(typeSpec, t, doc))
+					default:
+						pkg.Types = append(pkg.Types, TypeDef{
+							Name:       typeSpec.Name.Name,
+							TypeParams: extractParams(typeSpec.TypeParams),
+							Underlying: utils.ExprToString(typeSpec.Type),
+							Alias:      typeSpec.Assign.IsValid(),
+							Doc:        ai.Documentation{Summary: doc},
+						})
 					}
 				}
+			case token.CONST:
+				pkg.Consts = append(pkg.Consts, extractValueGroup(fset, d))
+			case token.VAR:
+				pkg.Vars = append(pkg.Vars, extractValueGroup(fset, d))
 			}
 		case *ast.FuncDecl:
 			fn := Function{
 				Name:       d.Name.Name,
 				Doc:        ai.Documentation{Summary: utils.DocToString(d.Doc)},
 				Receiver:   utils.RecvToString(d.Recv),
+				TypeParams: extractParams(d.Type.TypeParams),
 				Parameters: extractParams(d.Type.Params),
 				Results:    extractParams(d.Type.Results),
 				Calls:      []string{},
@@ -172,13 +188,7 @@ func (g *GoAnalyzer) AnalyzeFile(path string) (*AnalyzedFile, error) {
 
 			// If this is a method, add it to the appropriate struct
 			if d.Recv != nil && len(d.Recv.List) > 0 {
-				recvType := utils.ExprToString(d.Recv.List[0].Type)
-				for i, s := range pkg.Structs {
-					if s.Name == recvType {
-						pkg.Structs[i].Methods = append(pkg.Structs[i].Methods, fn)
-						break
-					}
-				}
+				attachMethod(&pkg, receiverTypeName(fn.Receiver), fn)
 			}
 		}
 	}
@@ -186,7 +196,267 @@ func (g *GoAnalyzer) AnalyzeFile(path string) (*AnalyzedFile, error) {
 	return &AnalyzedFile{
 		Path:     path,
 		Packages: []Package{pkg},
-	}, nil
+	}
+}
+
+// goImportPath derives the import path of the package in dir from the nearest
+// enclosing go.mod, caching module lookups per directory. Code outside any
+// module is identified by its path relative to projectDir.
+func goImportPath(projectDir, dir string, modules map[string]string) string {
+	for d := dir; ; d = filepath.Dir(d) {
+		module, ok := modules[d]
+		if !ok {
+			module = utils.ParseGoMod(d)
+			modules[d] = module
+		}
+		if module != "" {
+			rel, err := filepath.Rel(d, dir)
+			if err != nil || rel == "." {
+				return module
+			}
+			return module + "/" + filepath.ToSlash(rel)
+		}
+		if d == projectDir || filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	rel, err := filepath.Rel(projectDir, dir)
+	if err != nil || rel == "." {
+		return filepath.Base(projectDir)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// groupGoPackages merges per-file results into one AnalyzedFile per import
+// path. The merged file is keyed by the package directory and lists its
+// sources in Package.Files; methods are re-attached across files.
+func groupGoPackages(files []*AnalyzedFile) []*AnalyzedFile {
+	var grouped []*AnalyzedFile
+	byImportPath := make(map[string]*AnalyzedFile)
+
+	for _, file := range files {
+		src := file.Packages[0]
+
+		merged, ok := byImportPath[src.ImportPath]
+		if !ok {
+			dir := filepath.Dir(file.Path)
+			merged = &AnalyzedFile{
+				Path: dir,
+				Packages: []Package{{
+					Name:       src.Name,
+					ImportPath: src.ImportPath,
+					Path:       dir,
+				}},
+			}
+			byImportPath[src.ImportPath] = merged
+			grouped = append(grouped, merged)
+		}
+
+		pkg := &merged.Packages[0]
+		pkg.Files = append(pkg.Files, file.Path)
+		pkg.Imports = appendUnique(pkg.Imports, src.Imports...)
+		pkg.Structs = append(pkg.Structs, src.Structs...)
+		pkg.Interfaces = append(pkg.Interfaces, src.Interfaces...)
+		pkg.Types = append(pkg.Types, src.Types...)
+		pkg.Consts = append(pkg.Consts, src.Consts...)
+		pkg.Vars = append(pkg.Vars, src.Vars...)
+		pkg.Funcs = append(pkg.Funcs, src.Funcs...)
+	}
+
+	for _, file := range grouped {
+		pkg := &file.Packages[0]
+		for i := range pkg.Structs {
+			pkg.Structs[i].Methods = []Function{}
+		}
+		for i := range pkg.Types {
+			pkg.Types[i].Methods = nil
+		}
+		for _, fn := range pkg.Funcs {
+			if fn.Receiver != "" {
+				attachMethod(pkg, receiverTypeName(fn.Receiver), fn)
+			}
+		}
+	}
+
+	return grouped
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// receiverTypeName strips pointers and type arguments from a receiver, so
+// "*Cache[T]" becomes "Cache"
+func receiverTypeName(recv string) string {
+	name := strings.TrimPrefix(recv, "*")
+	name, _, _ = strings.Cut(name, "[")
+	return name
+}
+
+// attachMethod adds fn to the method list of the struct or named type called recvType
+func attachMethod(pkg *Package, recvType string, fn Function) {
+	for i, s := range pkg.Structs {
+		if s.Name == recvType {
+			pkg.Structs[i].Methods = append(pkg.Structs[i].Methods, fn)
+			return
+		}
+	}
+	for i, t := range pkg.Types {
+		if t.Name == recvType {
+			pkg.Types[i].Methods = append(pkg.Types[i].Methods, fn)
+			return
+		}
+	}
+}
+
+// specDoc prefers the comment on a spec inside a grouped declaration and falls
+// back to the comment on the declaration itself
+func specDoc(d *ast.GenDecl, doc *ast.CommentGroup) string {
+	if text := utils.DocToString(doc); text != "" {
+		return text
+	}
+	return utils.DocToString(d.Doc)
+}
+
+func extractStruct(typeSpec *ast.TypeSpec, structType *ast.StructType, doc string) Struct {
+	s := Struct{
+		Name:       typeSpec.Name.Name,
+		TypeParams: extractParams(typeSpec.TypeParams),
+		Doc:        ai.Documentation{Summary: doc},
+		Fields:     []Field{},
+		Methods:    []Function{},
+	}
+
+	if structType.Fields == nil {
+		return s
+	}
+
+	for _, field := range structType.Fields.List {
+		fieldType := utils.ExprToString(field.Type)
+		if len(field.Names) == 0 {
+			// Embedded field
+			s.Fields = append(s.Fields, Field{
+				Name: fieldType,
+				Type: fieldType,
+				Doc:  ai.Documentation{Summary: utils.DocToString(field.Doc)},
+			})
+			continue
+		}
+
+		// Regular fields
+		for _, name := range field.Names {
+			s.Fields = append(s.Fields, Field{
+				Name: name.Name,
+				Type: fieldType,
+				Tag:  utils.FieldTagToString(field.Tag),
+				Doc:  ai.Documentation{Summary: utils.DocToString(field.Doc)},
+			})
+		}
+	}
+	return s
+}
+
+func extractInterface(typeSpec *ast.TypeSpec, ifaceType *ast.InterfaceType, doc string) Interface {
+	iface := Interface{
+		Name:       typeSpec.Name.Name,
+		TypeParams: extractParams(typeSpec.TypeParams),
+		Doc:        ai.Documentation{Summary: doc},
+		Methods:    []Function{},
+	}
+
+	if ifaceType.Methods == nil {
+		return iface
+	}
+
+	for _, m := range ifaceType.Methods.List {
+		funcType, ok := m.Type.(*ast.FuncType)
+		if !ok || len(m.Names) == 0 {
+			// Embedded interface or type constraint
+			iface.Embeds = append(iface.Embeds, utils.ExprToString(m.Type))
+			continue
+		}
+		for _, name := range m.Names {
+			iface.Methods = append(iface.Methods, Function{
+				Name:       name.Name,
+				Receiver:   iface.Name,
+				Parameters: extractParams(funcType.Params),
+				Results:    extractParams(funcType.Results),
+				Doc:        ai.Documentation{Summary: utils.DocToString(m.Doc)},
+			})
+		}
+	}
+	return iface
+}
+
+// extractValueGroup collects the names, types and values of a const or var block.
+// Constants that omit their expression repeat the previous one, as the spec does.
+func extractValueGroup(fset *token.FileSet, d *ast.GenDecl) ValueGroup {
+	group := ValueGroup{
+		Kind: d.Tok.String(),
+		Doc:  ai.Documentation{Summary: utils.DocToString(d.Doc)},
+	}
+
+	var lastType ast.Expr
+	var lastValues []ast.Expr
+
+	for index, spec := range d.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		typ, values := valueSpec.Type, valueSpec.Values
+		if d.Tok == token.CONST && typ == nil && len(values) == 0 {
+			typ, values = lastType, lastValues
+		} else {
+			lastType, lastValues = typ, values
+		}
+
+		for i, name := range valueSpec.Names {
+			v := Value{
+				Name: name.Name,
+				Type: utils.ExprToString(typ),
+				Doc:  ai.Documentation{Summary: utils.DocToString(valueSpec.Doc)},
+			}
+			if v.Doc.Summary == "" {
+				// Trailing line comments are common inside const blocks
+				v.Doc.Summary = utils.DocToString(valueSpec.Comment)
+			}
+			if i < len(values) {
+				v.Value = utils.ValueToString(fset, values[i])
+				if d.Tok == token.CONST && usesIota(values[i]) {
+					v.Value = fmt.Sprintf("%s // iota = %d", v.Value, index)
+				}
+			}
+			group.Values = append(group.Values, v)
+		}
+	}
+	return group
+}
+
+// usesIota reports whether expr references the predeclared iota identifier
+func usesIota(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+			found = true
+		}
+		return !found
+	})
+	return found
 }
 
 func extractParams(fl *ast.FieldList) []Parameter {
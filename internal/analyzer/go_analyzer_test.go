@@ -0,0 +1,238 @@
+package analyzer
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+// parseGoTest analyzes a single Go source file
+func parseGoTest(t *testing.T, src string) Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &GoAnalyzer{}
+	return g.analyzeSyntax(fset, "test.go", node).Packages[0]
+}
+
+func TestGoInterfaces(t *testing.T) {
+	pkg := parseGoTest(t, `package store
+
+// Store persists items
+type Store interface {
+	io.Closer
+	Reader
+
+	// Get loads an item
+	Get(ctx context.Context, id string) (*Item, error)
+}
+
+type Number interface {
+	~int | ~float64
+}
+`)
+	if len(pkg.Interfaces) != 2 {
+		t.Fatalf("interfaces = %+v", pkg.Interfaces)
+	}
+
+	store := pkg.Interfaces[0]
+	if store.Name != "Store" || store.Doc.Summary != "Store persists items" {
+		t.Errorf("interface = %s %q", store.Name, store.Doc.Summary)
+	}
+	if want := []string{"io.Closer", "Reader"}; !reflect.DeepEqual(store.Embeds, want) {
+		t.Errorf("embeds = %v, want %v", store.Embeds, want)
+	}
+	if got, want := funcNames(store.Methods), []string{"Get"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("methods = %v, want %v", got, want)
+	}
+	get := store.Methods[0]
+	wantParams := []Parameter{{Name: "ctx", Type: "context.Context"}, {Name: "id", Type: "string"}}
+	wantResults := []Parameter{{Type: "*Item"}, {Type: "error"}}
+	if get.Receiver != "Store" || get.Doc.Summary != "Get loads an item" ||
+		!reflect.DeepEqual(get.Parameters, wantParams) || !reflect.DeepEqual(get.Results, wantResults) {
+		t.Errorf("Get = %+v", get)
+	}
+
+	// Type sets are recorded as embedded constraints
+	if len(pkg.Interfaces[1].Methods) != 0 || !reflect.DeepEqual(pkg.Interfaces[1].Embeds, []string{"~int | ~float64"}) {
+		t.Errorf("Number = %+v", pkg.Interfaces[1])
+	}
+}
+
+func TestGoTypeDefinitions(t *testing.T) {
+	pkg := parseGoTest(t, `package ids
+
+// ID identifies a record
+type ID string
+
+// Name is another name for string
+type Name = string
+
+type (
+	Handler func(w io.Writer) error
+	Labels  = map[string]string
+)
+
+func (id ID) String() string { return string(id) }
+`)
+	tests := []struct {
+		name       string
+		underlying string
+		alias      bool
+		doc        string
+		methods    []string
+	}{
+		{"ID", "string", false, "ID identifies a record", []string{"String"}},
+		{"Name", "string", true, "Name is another name for string", nil},
+		{"Handler", "func(w io.Writer) error", false, "", nil},
+		{"Labels", "map[string]string", true, "", nil},
+	}
+	if len(pkg.Types) != len(tests) {
+		t.Fatalf("types = %+v", pkg.Types)
+	}
+	for i, tt := range tests {
+		typ := pkg.Types[i]
+		if typ.Name != tt.name || typ.Underlying != tt.underlying || typ.Alias != tt.alias || typ.Doc.Summary != tt.doc {
+			t.Errorf("type %d = %s %s alias=%v %q, want %s %s alias=%v %q",
+				i, typ.Name, typ.Underlying, typ.Alias, typ.Doc.Summary, tt.name, tt.underlying, tt.alias, tt.doc)
+		}
+		if got := funcNames(typ.Methods); !reflect.DeepEqual(got, tt.methods) {
+			t.Errorf("%s methods = %v, want %v", tt.name, got, tt.methods)
+		}
+	}
+}
+
+func TestGoValueGroups(t *testing.T) {
+	pkg := parseGoTest(t, `package weekday
+
+// Weekday values
+const (
+	Sunday Weekday = iota // First day
+	Monday
+	Tuesday
+)
+
+const (
+	_  = iota
+	KB = 1 << (10 * iota)
+	MB
+)
+
+const Greeting, Farewell = "hello", "bye"
+
+// Defaults
+var (
+	// Timeout bounds requests
+	Timeout = 5 * time.Second
+	verbose bool
+)
+`)
+	tests := []struct {
+		kind   string
+		doc    string
+		values []Value
+	}{
+		{"const", "Weekday values", []Value{
+			{Name: "Sunday", Type: "Weekday", Value: "iota // iota = 0"},
+			{Name: "Monday", Type: "Weekday", Value: "iota // iota = 1"},
+			{Name: "Tuesday", Type: "Weekday", Value: "iota // iota = 2"},
+		}},
+		{"const", "", []Value{
+			{Name: "_", Value: "iota // iota = 0"},
+			{Name: "KB", Value: "1 << (10 * iota) // iota = 1"},
+			{Name: "MB", Value: "1 << (10 * iota) // iota = 2"},
+		}},
+		{"const", "", []Value{
+			{Name: "Greeting", Value: `"hello"`},
+			{Name: "Farewell", Value: `"bye"`},
+		}},
+		{"var", "Defaults", []Value{
+			{Name: "Timeout", Value: "5 * time.Second"},
+			{Name: "verbose", Type: "bool"},
+		}},
+	}
+	tests[0].values[0].Doc.Summary = "First day"
+	tests[3].values[0].Doc.Summary = "Timeout bounds requests"
+
+	groups := append(pkg.Consts, pkg.Vars...)
+	if len(groups) != len(tests) {
+		t.Fatalf("groups = %+v", groups)
+	}
+	for i, tt := range tests {
+		g := groups[i]
+		if g.Kind != tt.kind || g.Doc.Summary != tt.doc {
+			t.Errorf("group %d = %s %q, want %s %q", i, g.Kind, g.Doc.Summary, tt.kind, tt.doc)
+		}
+		if !reflect.DeepEqual(g.Values, tt.values) {
+			t.Errorf("group %d values = %+v, want %+v", i, g.Values, tt.values)
+		}
+	}
+}
+
+func TestGoTypeParams(t *testing.T) {
+	pkg := parseGoTest(t, `package sets
+
+type Set[T comparable] interface {
+	Has(v T) bool
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type List[T any] []T
+`)
+	want := []Parameter{{Name: "T", Type: "comparable"}}
+	if len(pkg.Interfaces) != 1 || !reflect.DeepEqual(pkg.Interfaces[0].TypeParams, want) {
+		t.Errorf("Set = %+v", pkg.Interfaces)
+	}
+	want = []Parameter{{Name: "K", Type: "comparable"}, {Name: "V", Type: "any"}}
+	if len(pkg.Structs) != 1 || !reflect.DeepEqual(pkg.Structs[0].TypeParams, want) {
+		t.Errorf("Pair = %+v", pkg.Structs)
+	}
+	want = []Parameter{{Name: "T", Type: "any"}}
+	if len(pkg.Types) != 1 || !reflect.DeepEqual(pkg.Types[0].TypeParams, want) || pkg.Types[0].Underlying != "[]T" {
+		t.Errorf("List = %+v", pkg.Types)
+	}
+}
+
+func TestGoPackagePages(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "go.mod", "module example.com/proj\n")
+	writeTestFile(t, dir, "a/util/util.go", "package util\n\nfunc A() {}\n")
+	writeTestFile(t, dir, "a/util/util_internal_test.go", "package util\n\nfunc helper() {}\n")
+	writeTestFile(t, dir, "a/util/util_test.go", "package util_test\n\nfunc TestA() {}\n")
+	writeTestFile(t, dir, "b/util/util.go", "package util\n\nfunc B() {}\n")
+
+	g := &GoAnalyzer{}
+	result, err := g.Analyze(context.Background(), dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		importPath string
+		name       string
+		funcs      []string
+	}{
+		{"example.com/proj/a/util", "util", []string{"A", "helper"}},
+		{"example.com/proj/a/util_test", "util_test", []string{"TestA"}},
+		{"example.com/proj/b/util", "util", []string{"B"}},
+	}
+	if len(result.Files) != len(tests) {
+		t.Fatalf("pages = %v", result.Files)
+	}
+	for i, tt := range tests {
+		pkg := result.Files[i].Packages[0]
+		if pkg.ImportPath != tt.importPath || pkg.Name != tt.name || !reflect.DeepEqual(funcNames(pkg.Funcs), tt.funcs) {
+			t.Errorf("page %d = %s (%s) %v, want %s (%s) %v",
+				i, pkg.ImportPath, pkg.Name, funcNames(pkg.Funcs), tt.importPath, tt.name, tt.funcs)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// goLoadMode type-checks dependencies from source, so loading does not rely on
+// export data the toolchain may write in a format x/tools cannot read
+const goLoadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedTypes |
+	packages.NeedTypesInfo |
+	packages.NeedImports |
+	packages.NeedDeps |
+	packages.NeedSyntax |
+	packages.NeedModule
+
+// analyzeTypeChecked loads every package below projectDir with full type
+// information. Package names and import paths come from the loader, calls are
+// resolved to their fully qualified targets, and interface satisfaction is
+// computed across all loaded packages. Files rejected by the project walker
+// are still type-checked but not documented. Previous results are never
+// reused here, since implementations depend on every other package.
+func (g *GoAnalyzer) analyzeTypeChecked(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    goLoadMode,
+		Dir:     projectDir,
+	}
+
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no Go packages found in %s", projectDir)
+	}
+
+	paths, err := walkFiles(projectDir, opts, ".go")
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if abs, err := filepath.Abs(path); err == nil {
+			allowed[abs] = true
+		}
+	}
+
+	// Only calls into packages of this project are worth documenting
+	local := make(map[string]bool)
+	for _, p := range pkgs {
+		local[p.PkgPath] = true
+	}
+
+	result := &AnalyzerResult{}
+	for _, p := range pkgs {
+		for _, e := range p.Errors {
+			fmt.Printf("⚠️ %s: %v\n", p.PkgPath, e)
+		}
+		if p.Types == nil || p.TypesInfo == nil {
+			continue
+		}
+
+		qualifier := packageQualifier(p.Types)
+		for _, file := range p.Syntax {
+			path := p.Fset.File(file.Pos()).Name()
+			if !allowed[path] {
+				continue
+			}
+			analyzed := g.analyzeSyntax(p.Fset, path, file)
+
+			pkg := &analyzed.Packages[0]
+			pkg.Name = p.Name
+			pkg.ImportPath = p.PkgPath
+			resolveCalls(pkg, file, p.TypesInfo, local)
+			addPromotedMethods(pkg, p.Types, qualifier)
+
+			result.Files = append(result.Files, analyzed)
+		}
+	}
+
+	result.Files = groupGoPackages(result.Files)
+	sortByPath(result.Files)
+	linkImplementations(result, pkgs)
+	return result, nil
+}
+
+// packageQualifier leaves names from pkg unqualified and qualifies every other
+// type by its package name, the way it would be written in source
+func packageQualifier(pkg *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return other.Name()
+	}
+}
+
+// resolveCalls replaces the syntactic call lists of every function in file with
+// the fully qualified targets reported by the type checker
+func resolveCalls(pkg *Package, file *ast.File, info *types.Info, local map[string]bool) {
+	fi := 0
+	for _, decl := range file.Decls {
+		d, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		// analyzeSyntax appends one Function per FuncDecl, in order
+		if fi >= len(pkg.Funcs) {
+			return
+		}
+
+		// Struct method lists are rebuilt from Funcs by groupGoPackages
+		pkg.Funcs[fi].Calls = staticCalls(d.Body, info, local)
+		fi++
+	}
+}
+
+func staticCalls(body *ast.BlockStmt, info *types.Info, local map[string]bool) []string {
+	calls := []string{}
+	if body == nil {
+		return calls
+	}
+
+	seen := make(map[string]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		callee, ok := typeutil.Callee(info, call).(*types.Func)
+		if !ok || callee.Pkg() == nil || !local[callee.Pkg().Path()] {
+			return true
+		}
+		name := callee.FullName()
+		if !seen[name] {
+			seen[name] = true
+			calls = append(calls, name)
+		}
+		return true
+	})
+	return calls
+}
+
+// addPromotedMethods records the methods each struct gains from its embedded fields
+func addPromotedMethods(pkg *Package, tpkg *types.Package, qualifier types.Qualifier) {
+	for si := range pkg.Structs {
+		obj, ok := tpkg.Scope().Lookup(pkg.Structs[si].Name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+
+		methodSet := types.NewMethodSet(types.NewPointer(obj.Type()))
+		for i := 0; i < methodSet.Len(); i++ {
+			sel := methodSet.At(i)
+			if len(sel.Index()) < 2 {
+				// Declared directly on the struct
+				continue
+			}
+			method, ok := sel.Obj().(*types.Func)
+			if !ok {
+				continue
+			}
+			pkg.Structs[si].Promoted = append(pkg.Structs[si].Promoted, functionFromSignature(method, qualifier))
+		}
+	}
+}
+
+func functionFromSignature(fn *types.Func, qualifier types.Qualifier) Function {
+	sig := fn.Type().(*types.Signature)
+	f := Function{
+		Name:       fn.Name(),
+		Parameters: tupleParams(sig.Params(), sig.Variadic(), qualifier),
+		Results:    tupleParams(sig.Results(), false, qualifier),
+		Calls:      []string{},
+	}
+	if recv := sig.Recv(); recv != nil {
+		f.Receiver = types.TypeString(recv.Type(), qualifier)
+	}
+	return f
+}
+
+func tupleParams(tuple *types.Tuple, variadic bool, qualifier types.Qualifier) []Parameter {
+	var params []Parameter
+	for i := 0; i < tuple.Len(); i++ {
+		v := tuple.At(i)
+		typ := types.TypeString(v.Type(), qualifier)
+		if variadic && i == tuple.Len()-1 {
+			if slice, ok := v.Type().(*types.Slice); ok {
+				typ = "..." + types.TypeString(slice.Elem(), qualifier)
+			}
+		}
+		params = append(params, Parameter{Name: v.Name(), Type: typ})
+	}
+	return params
+}
+
+// linkImplementations checks every named concrete type against every interface
+// declared in the loaded packages and records the matches on both sides
+func linkImplementations(result *AnalyzerResult, pkgs []*packages.Package) {
+	var ifaces, concretes []*types.TypeName
+	for _, p := range pkgs {
+		if p.Types == nil {
+			continue
+		}
+		scope := p.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			if iface, ok := named.Underlying().(*types.Interface); ok {
+				if iface.IsMethodSet() && iface.NumMethods() > 0 {
+					ifaces = append(ifaces, obj)
+				}
+				continue
+			}
+			concretes = append(concretes, obj)
+		}
+	}
+
+	index := indexDeclarations(result)
+	for _, ifaceObj := range ifaces {
+		iface := ifaceObj.Type().Underlying().(*types.Interface)
+		ifaceName := qualifiedName(ifaceObj)
+
+		for _, obj := range concretes {
+			if !types.Implements(obj.Type(), iface) && !types.Implements(types.NewPointer(obj.Type()), iface) {
+				continue
+			}
+			name := qualifiedName(obj)
+
+			if target, ok := index.interfaces[ifaceName]; ok {
+				target.Implementations = append(target.Implementations, name)
+			}
+			if target, ok := index.structs[name]; ok {
+				target.Implements = append(target.Implements, ifaceName)
+			}
+			if target, ok := index.types[name]; ok {
+				target.Implements = append(target.Implements, ifaceName)
+			}
+		}
+	}
+}
+
+func qualifiedName(obj *types.TypeName) string {
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+type declarationIndex struct {
+	structs    map[string]*Struct
+	interfaces map[string]*Interface
+	types      map[string]*TypeDef
+}
+
+// indexDeclarations maps qualified type names to the analyzed declarations in result
+func indexDeclarations(result *AnalyzerResult) declarationIndex {
+	index := declarationIndex{
+		structs:    make(map[string]*Struct),
+		interfaces: make(map[string]*Interface),
+		types:      make(map[string]*TypeDef),
+	}
+	for _, file := range result.Files {
+		for pi := range file.Packages {
+			pkg := &file.Packages[pi]
+			for i := range pkg.Structs {
+				index.structs[pkg.ImportPath+"."+pkg.Structs[i].Name] = &pkg.Structs[i]
+			}
+			for i := range pkg.Interfaces {
+				index.interfaces[pkg.ImportPath+"."+pkg.Interfaces[i].Name] = &pkg.Interfaces[i]
+			}
+			for i := range pkg.Types {
+				index.types[pkg.ImportPath+"."+pkg.Types[i].Name] = &pkg.Types[i]
+			}
+		}
+	}
+	return index
+}
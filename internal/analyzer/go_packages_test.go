@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeTypeChecked(t *testing.T) {
+	g := &GoAnalyzer{}
+	result, err := g.analyzeTypeChecked(context.Background(), filepath.Join("testdata", "shop"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs := make(map[string]Package)
+	for _, file := range result.Files {
+		pkgs[file.Packages[0].ImportPath] = file.Packages[0]
+	}
+	memory, ok := pkgs["example.com/shop/memory"]
+	if !ok {
+		t.Fatalf("packages = %v", reflect.ValueOf(pkgs).MapKeys())
+	}
+	store := pkgs["example.com/shop/store"]
+
+	// Calls resolve to fully qualified targets, in this package or another one
+	calls := []struct {
+		fn   string
+		want []string
+	}{
+		{"New", []string{"(*example.com/shop/memory.Memory).reset"}},
+		{"reset", []string{}},
+		{"Get", []string{"example.com/shop/store.Normalize"}},
+	}
+	if got := funcNames(memory.Funcs); len(got) != len(calls) {
+		t.Fatalf("funcs = %v", got)
+	}
+	for i, tt := range calls {
+		fn := memory.Funcs[i]
+		if fn.Name != tt.fn || !reflect.DeepEqual(fn.Calls, tt.want) {
+			t.Errorf("%s calls %v, want %s calls %v", fn.Name, fn.Calls, tt.fn, tt.want)
+		}
+	}
+
+	if len(memory.Structs) != 1 {
+		t.Fatalf("structs = %+v", memory.Structs)
+	}
+	m := memory.Structs[0]
+	if got, want := funcNames(m.Methods), []string{"reset", "Get"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("methods = %v, want %v", got, want)
+	}
+	wantPromoted := []Function{{
+		Name:     "Close",
+		Receiver: "*store.Base",
+		Results:  []Parameter{{Type: "error"}},
+		Calls:    []string{},
+	}}
+	if !reflect.DeepEqual(m.Promoted, wantPromoted) {
+		t.Errorf("promoted = %+v, want %+v", m.Promoted, wantPromoted)
+	}
+
+	// Implementations are linked on the interface and on the concrete type
+	if want := []string{"example.com/shop/store.Store"}; !reflect.DeepEqual(m.Implements, want) {
+		t.Errorf("Memory implements %v, want %v", m.Implements, want)
+	}
+	if len(store.Interfaces) != 1 {
+		t.Fatalf("interfaces = %+v", store.Interfaces)
+	}
+	if want := []string{"example.com/shop/memory.Memory"}; !reflect.DeepEqual(store.Interfaces[0].Implementations, want) {
+		t.Errorf("Store implementations = %v, want %v", store.Interfaces[0].Implementations, want)
+	}
+	if base := store.Structs[0]; base.Name != "Base" || len(base.Implements) != 0 {
+		t.Errorf("Base = %+v", base)
+	}
+}
@@ -0,0 +1,180 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+// HCLAnalyzer documents Terraform and other HCL configuration. Each
+// top-level block becomes a Struct named after its Terraform address, with
+// the block body as fields.
+type HCLAnalyzer struct{}
+
+var hclExtensions = []string{".tf", ".tfvars", ".hcl"}
+
+func (h *HCLAnalyzer) Supports(projectDir string, opts Options) bool {
+	return hasFiles(projectDir, opts, hclExtensions...)
+}
+
+func (h *HCLAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	paths, err := walkFiles(projectDir, opts, hclExtensions...)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := analyzeFiles(ctx, paths, opts, h.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyzerResult{Files: files}, nil
+}
+
+// analyzeFile extracts the blocks of a single HCL file
+func (h *HCLAnalyzer) analyzeFile(path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, attrs, err := parseHCL(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	pkg := Package{
+		Name: filepath.Base(filepath.Dir(path)),
+		Path: path,
+	}
+
+	// Loose attributes, as in .tfvars files, form one struct for the file
+	if len(attrs) > 0 {
+		summary := "HCL attributes"
+		if strings.HasSuffix(path, ".tfvars") {
+			summary = "Terraform variable values"
+		}
+		pkg.Structs = append(pkg.Structs, Struct{
+			Name:    filepath.Base(path),
+			Fields:  attrs,
+			DocYAML: ai.YAMLDocumentation{Summary: summary},
+		})
+	}
+
+	for _, block := range blocks {
+		pkg.Structs = append(pkg.Structs, Struct{
+			Name:    hclAddress(block),
+			Labels:  append([]string{block.Type}, block.Labels...),
+			Fields:  block.Body,
+			DocYAML: ai.YAMLDocumentation{Summary: hclSummary(block)},
+		})
+	}
+
+	return &AnalyzedFile{
+		Path:     path,
+		Packages: []Package{pkg},
+	}, nil
+}
+
+// hclAddress names a block the way Terraform refers to it, e.g. var.region,
+// aws_instance.web or module.vpc
+func hclAddress(block hclBlock) string {
+	switch block.Type {
+	case "variable":
+		return "var." + strings.Join(block.Labels, ".")
+	case "resource":
+		return strings.Join(block.Labels, ".")
+	}
+	return strings.Join(append([]string{block.Type}, block.Labels...), ".")
+}
+
+// hclSummary describes a block from its kind, its description attribute
+// and the comment above it
+func hclSummary(block hclBlock) string {
+	label := strings.Join(block.Labels, ".")
+
+	var summary string
+	switch block.Type {
+	case "variable":
+		summary = fmt.Sprintf("Input variable %s", label)
+		var traits []string
+		for _, key := range []string{"type", "default"} {
+			if f, ok := hclAttribute(block.Body, key); ok {
+				traits = append(traits, key+": "+f.Value)
+			}
+		}
+		if f, ok := hclAttribute(block.Body, "sensitive"); ok && f.Value == "true" {
+			traits = append(traits, "sensitive")
+		}
+		if len(traits) > 0 {
+			summary += " (" + strings.Join(traits, ", ") + ")"
+		}
+	case "output":
+		summary = fmt.Sprintf("Output value %s", label)
+	case "resource":
+		summary = fmt.Sprintf("Terraform resource %s", label)
+	case "data":
+		summary = fmt.Sprintf("Data source %s", label)
+	case "module":
+		summary = fmt.Sprintf("Module call %s", label)
+		if f, ok := hclAttribute(block.Body, "source"); ok {
+			summary += " (source: " + unquoteHCL(f.Value) + ")"
+		}
+	case "provider":
+		summary = fmt.Sprintf("Provider configuration %s", label)
+	case "terraform":
+		summary = "Terraform settings"
+	case "locals":
+		summary = "Local values"
+	default:
+		summary = strings.TrimSpace(block.Type + " " + label)
+	}
+
+	if f, ok := hclAttribute(block.Body, "description"); ok {
+		summary += ": " + hclStringValue(f.Value)
+	}
+	if block.Doc != "" {
+		summary += "\n" + block.Doc
+	}
+	return summary
+}
+
+// hclAttribute finds an attribute of a block body by name
+func hclAttribute(body []Field, name string) (Field, bool) {
+	for _, f := range body {
+		if f.Name == name && f.Type != "block" {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// hclStringValue returns the text of a string literal or heredoc. An
+// indented heredoc (<<-EOT) loses the indentation its lines share.
+func hclStringValue(value string) string {
+	if strings.HasPrefix(value, "<<") {
+		lines := strings.Split(value, "\n")
+		if len(lines) <= 2 {
+			return ""
+		}
+		lines = lines[1 : len(lines)-1]
+		if strings.HasPrefix(value, "<<-") {
+			margin := -1
+			for _, l := range lines {
+				if trimmed := strings.TrimLeft(l, " \t"); trimmed != "" && (margin < 0 || len(l)-len(trimmed) < margin) {
+					margin = len(l) - len(trimmed)
+				}
+			}
+			for i, l := range lines {
+				if len(l) >= margin && margin > 0 {
+					lines[i] = l[margin:]
+				}
+			}
+		}
+		return strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+	return unquoteHCL(value)
+}
@@ -0,0 +1,230 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type hclTokenKind int
+
+const (
+	hclIdent hclTokenKind = iota
+	hclNumber
+	hclString
+	hclHeredoc
+	hclPunct
+	hclNewline
+	hclEOF
+)
+
+// hclToken is one HCL token. Doc holds the comment lines directly above the
+// token when it starts a line.
+type hclToken struct {
+	Kind  hclTokenKind
+	Text  string
+	Start int
+	End   int
+	Line  int
+	Doc   string
+}
+
+// Longest operators first
+var hclOperators = []string{"...", "==", "!=", "<=", ">=", "&&", "||", "=>"}
+
+// lexHCL tokenizes HCL native syntax. Line breaks are kept as tokens since
+// they end attributes; the parser ignores them inside brackets.
+func lexHCL(src string) ([]hclToken, error) {
+	var toks []hclToken
+	var doc []string
+	line := 1
+	lineHasToken := false
+	lineHasComment := false
+	i := 0
+
+	emit := func(kind hclTokenKind, start, end int) {
+		tok := hclToken{Kind: kind, Text: src[start:end], Start: start, End: end, Line: line}
+		if !lineHasToken {
+			tok.Doc = strings.Join(doc, "\n")
+		}
+		toks = append(toks, tok)
+		doc = nil
+		lineHasToken = true
+		line += strings.Count(src[start:end], "\n")
+	}
+
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\n':
+			if len(toks) > 0 && toks[len(toks)-1].Kind != hclNewline {
+				toks = append(toks, hclToken{Kind: hclNewline, Text: "\n", Start: i, End: i + 1, Line: line})
+			}
+			if !lineHasToken && !lineHasComment {
+				// A blank line detaches comments from what follows
+				doc = nil
+			}
+			line++
+			lineHasToken, lineHasComment = false, false
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+
+		case c == '#' || strings.HasPrefix(src[i:], "//"):
+			start := i
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			if !lineHasToken {
+				text := strings.TrimLeft(src[start:i], "#/")
+				doc = append(doc, strings.TrimSpace(text))
+				lineHasComment = true
+			}
+
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			comment := src[i+2 : i+2+end]
+			line += strings.Count(comment, "\n")
+			i += end + 4
+
+		case c == '"':
+			end, err := scanHCLString(src, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			emit(hclString, i, end)
+			i = end
+
+		case strings.HasPrefix(src[i:], "<<") && i+2 < len(src) && (src[i+2] == '-' || isHCLIdentStart(src, i+2)):
+			end, err := scanHCLHeredoc(src, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			emit(hclHeredoc, i, end)
+			i = end
+
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9' ||
+				src[i] == 'e' || src[i] == 'E' || (src[i] == '+' || src[i] == '-') && (src[i-1] == 'e' || src[i-1] == 'E')) {
+				i++
+			}
+			emit(hclNumber, start, i)
+
+		case isHCLIdentStart(src, i):
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if !(r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					break
+				}
+				i += size
+			}
+			emit(hclIdent, start, i)
+
+		default:
+			size := 1
+			for _, op := range hclOperators {
+				if strings.HasPrefix(src[i:], op) {
+					size = len(op)
+					break
+				}
+			}
+			if size == 1 && c >= 0x80 {
+				_, size = utf8.DecodeRuneInString(src[i:])
+			}
+			emit(hclPunct, i, i+size)
+			i += size
+		}
+	}
+
+	toks = append(toks, hclToken{Kind: hclEOF, Start: len(src), End: len(src), Line: line})
+	return toks, nil
+}
+
+func isHCLIdentStart(src string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(src[i:])
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// scanHCLString returns the end of a quoted template, skipping over ${...}
+// and %{...} sequences that may hold strings of their own
+func scanHCLString(src string, i int) (int, error) {
+	for j := i + 1; j < len(src); j++ {
+		switch {
+		case src[j] == '\\':
+			j++
+		case src[j] == '"':
+			return j + 1, nil
+		case src[j] == '\n':
+			return 0, fmt.Errorf("unterminated string")
+		case strings.HasPrefix(src[j:], "${") || strings.HasPrefix(src[j:], "%{"):
+			end, err := skipHCLInterpolation(src, j+2)
+			if err != nil {
+				return 0, err
+			}
+			j = end - 1
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// skipHCLInterpolation returns the offset just past the "}" closing an
+// interpolation whose body starts at i
+func skipHCLInterpolation(src string, i int) (int, error) {
+	depth := 1
+	for j := i; j < len(src); j++ {
+		switch src[j] {
+		case '"':
+			end, err := scanHCLString(src, j)
+			if err != nil {
+				return 0, err
+			}
+			j = end - 1
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return j + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unterminated interpolation")
+}
+
+// scanHCLHeredoc returns the end of a <<EOF or <<-EOF heredoc, just past
+// its closing marker
+func scanHCLHeredoc(src string, i int) (int, error) {
+	j := i + 2
+	if src[j] == '-' {
+		j++
+	}
+	start := j
+	for j < len(src) && src[j] != '\n' && src[j] != '\r' {
+		j++
+	}
+	marker := strings.TrimSpace(src[start:j])
+
+	for j < len(src) {
+		// j sits on the line break ending the previous line
+		next := strings.IndexByte(src[j+1:], '\n')
+		end := len(src)
+		if next >= 0 {
+			end = j + 1 + next
+		}
+		if strings.TrimSpace(src[j+1:end]) == marker {
+			return end, nil
+		}
+		if next < 0 {
+			break
+		}
+		j = end
+	}
+	return 0, fmt.Errorf("unterminated heredoc %q", marker)
+}
@@ -0,0 +1,353 @@
+package analyzer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+// hclBlock is a top-level block such as resource "aws_instance" "web"
+type hclBlock struct {
+	Type   string
+	Labels []string
+	Body   []Field
+	Doc    string
+}
+
+type hclParser struct {
+	src  string
+	toks []hclToken
+	pos  int
+}
+
+// parseHCL returns the top-level blocks and attributes of an HCL file.
+// Attribute values keep their source text; object and tuple literals are
+// also broken down into nested fields, and nested blocks become fields of
+// type "block".
+func parseHCL(src string) ([]hclBlock, []Field, error) {
+	toks, err := lexHCL(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &hclParser{src: src, toks: toks}
+
+	var blocks []hclBlock
+	var attrs []Field
+	for {
+		p.skipNewlines()
+		if p.peek().Kind == hclEOF {
+			return blocks, attrs, nil
+		}
+		doc := p.peek().Doc
+		name, labels, isBlock, err := p.parseItemHead()
+		if err != nil {
+			return nil, nil, err
+		}
+		if !isBlock {
+			attr := p.parseAttribute(name, doc)
+			attrs = append(attrs, attr)
+			continue
+		}
+		body, err := p.parseBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		blocks = append(blocks, hclBlock{Type: name, Labels: labels, Body: body, Doc: doc})
+	}
+}
+
+func (p *hclParser) peek() hclToken {
+	return p.toks[p.pos]
+}
+
+func (p *hclParser) next() hclToken {
+	tok := p.toks[p.pos]
+	if tok.Kind != hclEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *hclParser) is(text string) bool {
+	tok := p.peek()
+	return tok.Kind == hclPunct && tok.Text == text
+}
+
+func (p *hclParser) skipNewlines() {
+	for p.peek().Kind == hclNewline {
+		p.next()
+	}
+}
+
+// parseItemHead reads an attribute name followed by "=", or a block type
+// with its labels followed by "{"
+func (p *hclParser) parseItemHead() (string, []string, bool, error) {
+	tok := p.next()
+	if tok.Kind != hclIdent {
+		return "", nil, false, fmt.Errorf("line %d: expected attribute or block, found %q", tok.Line, tok.Text)
+	}
+	if p.is("=") {
+		p.next()
+		return tok.Text, nil, false, nil
+	}
+
+	var labels []string
+	for {
+		label := p.peek()
+		switch {
+		case label.Kind == hclString:
+			p.next()
+			labels = append(labels, unquoteHCL(label.Text))
+			continue
+		case label.Kind == hclIdent:
+			p.next()
+			labels = append(labels, label.Text)
+			continue
+		case p.is("{"):
+			p.next()
+			return tok.Text, labels, true, nil
+		}
+		return "", nil, false, fmt.Errorf("line %d: expected \"=\" or \"{\" after %q, found %q", label.Line, tok.Text, label.Text)
+	}
+}
+
+// parseBody parses block items up to and including the closing brace
+func (p *hclParser) parseBody() ([]Field, error) {
+	fields := []Field{}
+	for {
+		p.skipNewlines()
+		if p.is("}") {
+			p.next()
+			return fields, nil
+		}
+		if p.peek().Kind == hclEOF {
+			return nil, fmt.Errorf("line %d: unexpected end of file, expected \"}\"", p.peek().Line)
+		}
+
+		doc := p.peek().Doc
+		name, labels, isBlock, err := p.parseItemHead()
+		if err != nil {
+			return nil, err
+		}
+		if !isBlock {
+			fields = append(fields, p.parseAttribute(name, doc))
+			continue
+		}
+
+		body, err := p.parseBody()
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range labels {
+			name += " " + strconv.Quote(label)
+		}
+		fields = append(fields, Field{
+			Name:    name,
+			Type:    "block",
+			Fields:  body,
+			DocYAML: ai.YAMLDocumentation{Summary: doc},
+		})
+	}
+}
+
+// parseAttribute parses the value of "name = value"
+func (p *hclParser) parseAttribute(name, doc string) Field {
+	field := p.parseExpression(false)
+	field.Name = name
+	field.DocYAML = ai.YAMLDocumentation{Summary: doc}
+	return field
+}
+
+// parseExpression parses one value. Inside a collection, commas and the
+// closing bracket also end the value.
+func (p *hclParser) parseExpression(inCollection bool) Field {
+	start := p.pos
+
+	if (p.is("{") || p.is("[")) && !p.isForExpression() {
+		end := p.matching(p.pos)
+		if p.endsExpression(end+1, inCollection) {
+			if p.is("{") {
+				return p.parseObject()
+			}
+			return p.parseTuple()
+		}
+	}
+
+	depth := 0
+	for {
+		tok := p.peek()
+		if tok.Kind == hclEOF {
+			break
+		}
+		if depth == 0 && p.endsExpression(p.pos, inCollection) {
+			break
+		}
+		if tok.Kind == hclPunct {
+			switch tok.Text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
+			}
+		}
+		p.next()
+	}
+
+	field := Field{Type: "expression", Value: p.text(start, p.pos)}
+	if p.pos-start == 1 {
+		field.Type = hclScalarType(p.toks[start])
+	}
+	return field
+}
+
+// endsExpression reports whether the token at i ends a value
+func (p *hclParser) endsExpression(i int, inCollection bool) bool {
+	tok := p.toks[i]
+	switch tok.Kind {
+	case hclEOF, hclNewline:
+		return true
+	case hclPunct:
+		if tok.Text == "}" || tok.Text == "]" || tok.Text == ")" {
+			return true
+		}
+		return inCollection && tok.Text == ","
+	}
+	return false
+}
+
+// isForExpression reports whether the bracket at the current position opens
+// a for expression such as [for s in var.list : upper(s)]
+func (p *hclParser) isForExpression() bool {
+	for i := p.pos + 1; i < len(p.toks); i++ {
+		switch p.toks[i].Kind {
+		case hclNewline:
+			continue
+		case hclIdent:
+			return p.toks[i].Text == "for"
+		}
+		return false
+	}
+	return false
+}
+
+// matching returns the index of the bracket closing the one at i
+func (p *hclParser) matching(i int) int {
+	depth := 0
+	for ; i < len(p.toks); i++ {
+		tok := p.toks[i]
+		if tok.Kind == hclEOF {
+			return i - 1
+		}
+		if tok.Kind != hclPunct {
+			continue
+		}
+		switch tok.Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(p.toks) - 1
+}
+
+// parseObject parses "{ key = value, ... }" into a map field
+func (p *hclParser) parseObject() Field {
+	p.next() // {
+	field := Field{Type: "map", Fields: []Field{}}
+	for {
+		p.skipNewlines()
+		if p.is(",") {
+			p.next()
+			continue
+		}
+		if p.is("}") || p.peek().Kind == hclEOF {
+			p.next()
+			return field
+		}
+
+		doc := p.peek().Doc
+		keyStart := p.pos
+		for !p.is("=") && !p.is(":") && !p.endsExpression(p.pos, true) {
+			p.next()
+		}
+		key := unquoteHCL(p.text(keyStart, p.pos))
+		if !p.is("=") && !p.is(":") {
+			// Malformed item; skip it
+			if p.pos == keyStart {
+				p.next()
+			}
+			continue
+		}
+		p.next()
+
+		item := p.parseExpression(true)
+		item.Name = key
+		item.DocYAML = ai.YAMLDocumentation{Summary: doc}
+		field.Fields = append(field.Fields, item)
+	}
+}
+
+// parseTuple parses "[a, b]" into an array field with items named "[0]", "[1]"...
+func (p *hclParser) parseTuple() Field {
+	p.next() // [
+	field := Field{Type: "array", Fields: []Field{}}
+	for {
+		p.skipNewlines()
+		if p.is(",") {
+			p.next()
+			continue
+		}
+		if p.is("]") || p.peek().Kind == hclEOF {
+			p.next()
+			return field
+		}
+		start := p.pos
+		item := p.parseExpression(true)
+		if p.pos == start {
+			// Stray closing bracket; skip it
+			p.next()
+			continue
+		}
+		item.Name = fmt.Sprintf("[%d]", len(field.Fields))
+		field.Fields = append(field.Fields, item)
+	}
+}
+
+// text returns the source between two token positions
+func (p *hclParser) text(from, to int) string {
+	if from >= to {
+		return ""
+	}
+	return strings.TrimSpace(p.src[p.toks[from].Start:p.toks[to-1].End])
+}
+
+func hclScalarType(tok hclToken) string {
+	switch tok.Kind {
+	case hclString, hclHeredoc:
+		return "string"
+	case hclNumber:
+		return "number"
+	case hclIdent:
+		switch tok.Text {
+		case "true", "false":
+			return "bool"
+		case "null":
+			return "null"
+		}
+	}
+	return "expression"
+}
+
+// unquoteHCL strips the quotes from a string literal
+func unquoteHCL(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil && strings.HasPrefix(s, `"`) {
+		return unquoted
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// parseHCLBody parses the body of a single resource block
+func parseHCLBody(t *testing.T, body string) []Field {
+	t.Helper()
+	blocks, _, err := parseHCL("resource \"t\" \"r\" {\n" + body + "}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("got %d blocks", len(blocks))
+	}
+	return blocks[0].Body
+}
+
+func TestHCLValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		typ   string
+		value string
+		items []string
+	}{
+		{name: "string", body: `region = "eu-west-1"`, typ: "string", value: `"eu-west-1"`},
+		{name: "number", body: `port = 8080`, typ: "number", value: "8080"},
+		{name: "bool", body: `enabled = true`, typ: "bool", value: "true"},
+		{name: "reference", body: `ami = data.aws_ami.ubuntu.id`, typ: "expression", value: "data.aws_ami.ubuntu.id"},
+		{name: "conditional", body: `count = var.enabled ? 1 : 0`, typ: "expression", value: "var.enabled ? 1 : 0"},
+		{name: "for expression", body: `names = [for s in var.list : upper(s)]`, typ: "expression", value: "[for s in var.list : upper(s)]"},
+		{name: "tuple", body: `ports = [80, 443]`, typ: "array", items: []string{"80", "443"}},
+		{name: "object", body: `tags = { Name = "web", "Env" = var.env }`, typ: "map", items: []string{`"web"`, "var.env"}},
+		{
+			name:  "heredoc",
+			body:  "user_data = <<EOF\n#!/bin/bash\necho \"${var.region}\" }\nEOF\n",
+			typ:   "string",
+			value: "<<EOF\n#!/bin/bash\necho \"${var.region}\" }\nEOF",
+		},
+		{
+			name:  "indented heredoc",
+			body:  "  policy = <<-EOT\n    {\"a\": 1}\n  EOT\n",
+			typ:   "string",
+			value: "<<-EOT\n    {\"a\": 1}\n  EOT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := parseHCLBody(t, tt.body+"\n")
+			if len(body) != 1 {
+				t.Fatalf("body = %+v", body)
+			}
+			f := body[0]
+			if f.Type != tt.typ || f.Value != tt.value {
+				t.Errorf("got %s %q, want %s %q", f.Type, f.Value, tt.typ, tt.value)
+			}
+			var items []string
+			for _, item := range f.Fields {
+				items = append(items, item.Value)
+			}
+			if !reflect.DeepEqual(items, tt.items) {
+				t.Errorf("items = %v, want %v", items, tt.items)
+			}
+		})
+	}
+}
+
+func TestHCLBlocks(t *testing.T) {
+	src := `# The AWS region
+variable "region" {
+  type        = string
+  description = <<-EOT
+    Region to deploy into.
+      Must exist.
+  EOT
+}
+
+resource "aws_instance" "web" {
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+module "vpc" { source = "./vpc" }
+terraform_version = "1.6"
+`
+	blocks, attrs, err := parseHCL(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var addresses []string
+	for _, b := range blocks {
+		addresses = append(addresses, hclAddress(b))
+	}
+	if want := []string{"var.region", "aws_instance.web", "module.vpc"}; !reflect.DeepEqual(addresses, want) {
+		t.Errorf("addresses = %v, want %v", addresses, want)
+	}
+	if len(attrs) != 1 || attrs[0].Name != "terraform_version" {
+		t.Errorf("top-level attributes = %+v", attrs)
+	}
+
+	want := "Input variable region (type: string): Region to deploy into.\n  Must exist.\nThe AWS region"
+	if got := hclSummary(blocks[0]); got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	if got := hclSummary(blocks[2]); got != "Module call vpc (source: ./vpc)" {
+		t.Errorf("module summary = %q", got)
+	}
+
+	lifecycle := blocks[1].Body[0]
+	if lifecycle.Type != "block" || len(lifecycle.Fields) != 1 || lifecycle.Fields[0].Value != "true" {
+		t.Errorf("lifecycle = %+v", lifecycle)
+	}
+}
+
+func TestHCLUnterminatedHeredoc(t *testing.T) {
+	if _, _, err := parseHCL("a = <<EOF\nnever closed\n"); err == nil {
+		t.Error("expected an error for an unterminated heredoc")
+	}
+}
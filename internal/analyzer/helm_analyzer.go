@@ -0,0 +1,353 @@
+package analyzer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"gopkg.in/yaml.v3"
+)
+
+// HelmAnalyzer documents each Helm chart directory as one unit. Chart.yaml
+// becomes a Struct of kind "chart"; values.yaml one of kind "values" with one
+// field per key, named by its dotted path and holding its default; and every
+// file under templates/ one of kind "template" listing the values it
+// references and the resources it renders. Values nothing references are
+// marked Unused.
+type HelmAnalyzer struct{}
+
+var (
+	helmValuesRef = regexp.MustCompile(`\.Values((?:\.[A-Za-z_][A-Za-z0-9_]*)+)`)
+	helmIndexRef  = regexp.MustCompile(`index\s+\$?\.Values((?:\s+"[^"]*")+)`)
+	helmKind      = regexp.MustCompile(`^kind:\s*([A-Za-z]+)\s*$`)
+)
+
+func (h *HelmAnalyzer) Supports(projectDir string, opts Options) bool {
+	paths, err := newFileWalker(projectDir, opts).walk(isChartFile)
+	return err == nil && len(paths) > 0
+}
+
+func (h *HelmAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	walker := newFileWalker(projectDir, opts)
+	chartFiles, err := walker.walk(isChartFile)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, path := range chartFiles {
+		dirs = append(dirs, filepath.Dir(path))
+	}
+
+	// Each file belongs to the innermost chart, so subcharts stay separate
+	sources := make(map[string][]string)
+	_, err = walker.walk(func(path string) bool {
+		if dir := chartDirOf(path, dirs); dir != "" && isChartSource(dir, path) {
+			sources[dir] = append(sources[dir], path)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reused, remaining := reuseUnits("Helm", dirs, sources, opts.Previous)
+	files, err := analyzeFiles(ctx, remaining, opts, func(dir string) (*AnalyzedFile, error) {
+		return h.analyzeChart(dir, sources[dir])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the charts in walk order whether or not they were reused
+	byDir := make(map[string]*AnalyzedFile)
+	for _, file := range append(reused, files...) {
+		byDir[file.Path] = file
+	}
+	result := &AnalyzerResult{}
+	for _, dir := range dirs {
+		result.Files = append(result.Files, byDir[dir])
+	}
+	return result, nil
+}
+
+func isChartFile(path string) bool {
+	return filepath.Base(path) == "Chart.yaml"
+}
+
+// chartDirOf returns the innermost chart directory holding path, or ""
+func chartDirOf(path string, dirs []string) string {
+	found := ""
+	for _, dir := range dirs {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) && len(dir) > len(found) {
+			found = dir
+		}
+	}
+	return found
+}
+
+// isChartSource reports whether path is part of the chart in dir: its
+// Chart.yaml, its default values.yaml or one of its templates
+func isChartSource(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	return rel == "Chart.yaml" || rel == "values.yaml" || strings.HasPrefix(rel, "templates/")
+}
+
+// analyzeChart reads the metadata, values and templates of one chart
+func (h *HelmAnalyzer) analyzeChart(dir string, sources []string) (*AnalyzedFile, error) {
+	y := &YAMLAnalyzer{}
+	content, err := os.ReadFile(filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(dir, "Chart.yaml"), err)
+	}
+	limitYAMLAliases(&root)
+	node := &root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	name := y.getValue(node, "name")
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	summary := y.extractYAMLComment(&root)
+	if summary == "" && len(node.Content) > 0 {
+		// A comment right above the first key describes the whole chart
+		summary = yamlComment(node.Content[0].HeadComment)
+		node.Content[0].HeadComment = ""
+	}
+	if summary == "" {
+		summary = y.getValue(node, "description")
+	}
+	chart := Struct{
+		Name:   name,
+		Kind:   "chart",
+		Doc:    ai.Documentation{Summary: summary},
+		Fields: y.extractYAMLFields(node, 0),
+	}
+
+	pkg := Package{
+		Name:       name,
+		ImportPath: "helm/" + name,
+		Path:       dir,
+		Files:      sources,
+		Structs:    []Struct{chart},
+	}
+
+	values := Struct{Name: "values", Kind: "values"}
+	for _, src := range sources {
+		if filepath.Base(src) != "values.yaml" || filepath.Dir(src) != dir {
+			continue
+		}
+		content, err := os.ReadFile(src)
+		if err != nil {
+			return nil, err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", src, err)
+		}
+		limitYAMLAliases(&doc)
+		values.Doc.Summary = y.extractYAMLComment(&doc)
+		if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+			values.Fields = y.helmValues(doc.Content[0], "")
+		}
+	}
+
+	var templates []Struct
+	for _, src := range sources {
+		rel, _ := filepath.Rel(dir, src)
+		if !strings.HasPrefix(filepath.ToSlash(rel), "templates/") {
+			continue
+		}
+		template, err := analyzeHelmTemplate(src, filepath.ToSlash(rel))
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	// Conditions, tags and subchart values are read by Helm itself
+	refs := chartValueRefs(y, node)
+	for _, t := range templates {
+		refs = append(refs, t.Fields...)
+	}
+	markUnusedValues(values.Fields, refs)
+	if len(values.Fields) > 0 {
+		pkg.Structs = append(pkg.Structs, values)
+	}
+	pkg.Structs = append(pkg.Structs, templates...)
+
+	return &AnalyzedFile{
+		Path:     dir,
+		Language: "Helm",
+		Packages: []Package{pkg},
+	}, nil
+}
+
+// helmValues flattens values.yaml into one field per key, named by its dotted
+// path like helm-docs does. Maps are descended into; scalars, lists and empty
+// maps are leaves whose Value is the default. Commented maps are kept too so
+// their comment documents the section.
+func (y *YAMLAnalyzer) helmValues(node *yaml.Node, prefix string) []Field {
+	var fields []Field
+	for _, pair := range y.mappingPairs(resolveYAMLAlias(node)) {
+		path := pair.Key.Value
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		value := resolveYAMLAlias(pair.Value)
+		field := Field{
+			Name:    path,
+			Type:    yamlValueType(value),
+			DocYAML: ai.YAMLDocumentation{Summary: helmComment(fieldComment(pair.Key, pair.Value))},
+			Origin:  pair.Origin,
+		}
+		if value.Kind == yaml.MappingNode && len(value.Content) > 0 {
+			if field.DocYAML.Summary != "" {
+				fields = append(fields, field)
+			}
+			fields = append(fields, y.helmValues(value, path)...)
+			continue
+		}
+		field.Value = yamlDefault(value)
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// helmComment drops the "-- " marker helm-docs puts before descriptions
+func helmComment(comment string) string {
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(strings.TrimPrefix(line, "--"), " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// chartValueRefs lists the values Chart.yaml itself reads: dependency
+// conditions, tags, and the sections passed down to subcharts
+func chartValueRefs(y *YAMLAnalyzer, chart *yaml.Node) []Field {
+	var refs []Field
+	ref := func(path string) {
+		for _, f := range refs {
+			if f.Name == path {
+				return
+			}
+		}
+		refs = append(refs, Field{Name: path, Type: "reference"})
+	}
+
+	deps := y.getValueNode(chart, "dependencies")
+	if deps == nil || deps.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, dep := range deps.Content {
+		name := y.getValue(dep, "alias")
+		if name == "" {
+			name = y.getValue(dep, "name")
+		}
+		if name != "" {
+			ref(name)
+		}
+		for _, condition := range strings.Split(y.getValue(dep, "condition"), ",") {
+			if condition = strings.TrimSpace(condition); condition != "" {
+				ref(condition)
+			}
+		}
+		if tags := y.getValueNode(dep, "tags"); tags != nil {
+			for _, tag := range tags.Content {
+				ref("tags." + tag.Value)
+			}
+		}
+	}
+	return refs
+}
+
+// analyzeHelmTemplate lists the values a template references, with the
+// lines they appear on, and the kinds of resources it renders
+func analyzeHelmTemplate(path, rel string) (Struct, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Struct{}, err
+	}
+	defer file.Close()
+
+	template := Struct{Name: rel, Kind: "template"}
+	lines := make(map[string][]string)
+	var order []string
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if m := helmKind.FindStringSubmatch(strings.TrimRight(line, " \r")); m != nil {
+			template.Renders = appendOnce(template.Renders, m[1])
+		}
+		var paths []string
+		for _, m := range helmValuesRef.FindAllStringSubmatch(line, -1) {
+			paths = append(paths, strings.TrimPrefix(m[1], "."))
+		}
+		for _, m := range helmIndexRef.FindAllStringSubmatch(line, -1) {
+			var keys []string
+			for _, key := range strings.Fields(m[1]) {
+				keys = append(keys, strings.Trim(key, `"`))
+			}
+			paths = append(paths, strings.Join(keys, "."))
+		}
+		for _, p := range paths {
+			if _, ok := lines[p]; !ok {
+				order = append(order, p)
+			}
+			lines[p] = appendOnce(lines[p], strconv.Itoa(n))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Struct{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	sort.Strings(order)
+	for _, p := range order {
+		template.Fields = append(template.Fields, Field{
+			Name:  p,
+			Type:  "reference",
+			Value: "line " + strings.Join(lines[p], ", "),
+		})
+	}
+	return template, nil
+}
+
+// markUnusedValues flags the values no template or dependency references.
+// Referencing a section uses every key below it, and referencing a key uses
+// its sections.
+func markUnusedValues(values, refs []Field) {
+	for i := range values {
+		used := false
+		for _, ref := range refs {
+			if HelmValueUsed(values[i].Name, ref.Name) {
+				used = true
+				break
+			}
+		}
+		values[i].Unused = !used
+	}
+}
+
+// HelmValueUsed reports whether a template reference to ref reads the value at path
+func HelmValueUsed(path, ref string) bool {
+	return path == ref || strings.HasPrefix(path, ref+".") || strings.HasPrefix(ref, path+".")
+}
@@ -0,0 +1,191 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestHelmChart(t *testing.T) {
+	dir := t.TempDir()
+	var sources []string
+	for name, content := range map[string]string{
+		"Chart.yaml": `# The web chart
+apiVersion: v2
+name: web
+version: 1.2.0
+dependencies:
+  - name: redis
+    condition: redis.enabled
+    tags: [cache]
+`,
+		"values.yaml": `# Values of the web chart
+
+# -- Number of pods
+replicaCount: 2
+image:
+  repository: nginx # Image to run
+  tag: ""
+# Service settings
+service:
+  port: 80
+ingress: {}
+redis:
+  enabled: false
+unusedKey: true
+global:
+  env: prod
+`,
+		"templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: {{ .Values.replicaCount }}
+  template:
+    spec:
+      containers:
+        - image: "{{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}"
+`,
+		"templates/service.yaml": `kind: Service
+spec:
+  ports:
+    - port: {{ index .Values "service" "port" }}
+---
+kind: Service
+`,
+		"templates/ingress.yaml": "{{- if .Values.ingress }}\nkind: Ingress\n{{- end }}\n",
+	} {
+		sources = append(sources, writeTestFile(t, dir, name, content))
+	}
+	sort.Strings(sources)
+
+	h := &HelmAnalyzer{}
+	file, err := h.analyzeChart(dir, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := file.Packages[0]
+	if pkg.ImportPath != "helm/web" || file.Language != "Helm" {
+		t.Errorf("chart page = %s (%s)", pkg.ImportPath, file.Language)
+	}
+
+	var kinds []string
+	for _, s := range pkg.Structs {
+		kinds = append(kinds, s.Kind+" "+s.Name)
+	}
+	want := []string{
+		"chart web",
+		"values values",
+		"template templates/deployment.yaml",
+		"template templates/ingress.yaml",
+		"template templates/service.yaml",
+	}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("structs = %v, want %v", kinds, want)
+	}
+	if pkg.Structs[0].Doc.Summary != "The web chart" || pkg.Structs[1].Doc.Summary != "Values of the web chart" {
+		t.Errorf("docs = %q, %q", pkg.Structs[0].Doc.Summary, pkg.Structs[1].Doc.Summary)
+	}
+
+	values := []struct {
+		name    string
+		value   string
+		comment string
+		unused  bool
+	}{
+		{"replicaCount", "2", "Number of pods", false},
+		{"image.repository", "nginx", "Image to run", false},
+		{"image.tag", "", "", false},
+		{"service", "", "Service settings", false},
+		{"service.port", "80", "", false},
+		{"ingress", "{}", "", false},
+		{"redis.enabled", "false", "", false},
+		{"unusedKey", "true", "", true},
+		{"global.env", "prod", "", true},
+	}
+	fields := pkg.Structs[1].Fields
+	if len(fields) != len(values) {
+		t.Fatalf("values = %+v", fields)
+	}
+	for i, tt := range values {
+		f := fields[i]
+		if f.Name != tt.name || f.Value != tt.value || f.DocYAML.Summary != tt.comment || f.Unused != tt.unused {
+			t.Errorf("value %d = %s %q %q unused=%v, want %s %q %q unused=%v",
+				i, f.Name, f.Value, f.DocYAML.Summary, f.Unused, tt.name, tt.value, tt.comment, tt.unused)
+		}
+	}
+
+	deployment := pkg.Structs[2]
+	wantRefs := []Field{
+		{Name: "image.repository", Type: "reference", Value: "line 8"},
+		{Name: "image.tag", Type: "reference", Value: "line 8"},
+		{Name: "replicaCount", Type: "reference", Value: "line 4"},
+	}
+	if !reflect.DeepEqual(deployment.Fields, wantRefs) {
+		t.Errorf("deployment references = %+v, want %+v", deployment.Fields, wantRefs)
+	}
+	if !reflect.DeepEqual(deployment.Renders, []string{"Deployment"}) {
+		t.Errorf("deployment renders %v", deployment.Renders)
+	}
+	service := pkg.Structs[4]
+	if !reflect.DeepEqual(service.Renders, []string{"Service"}) || len(service.Fields) != 1 || service.Fields[0].Name != "service.port" {
+		t.Errorf("service = %+v", service)
+	}
+}
+
+func TestChartValueRefs(t *testing.T) {
+	y := &YAMLAnalyzer{}
+	chart := parseYAMLTest(t, `name: web
+dependencies:
+  - name: redis
+    condition: redis.enabled, cache.enabled
+    tags: [cache]
+  - name: postgresql
+    alias: db
+`)
+	var refs []string
+	for _, f := range chartValueRefs(y, chart) {
+		refs = append(refs, f.Name)
+	}
+	if want := []string{"redis", "redis.enabled", "cache.enabled", "tags.cache", "db"}; !reflect.DeepEqual(refs, want) {
+		t.Errorf("refs = %v, want %v", refs, want)
+	}
+	if refs := chartValueRefs(y, parseYAMLTest(t, "name: web\n")); refs != nil {
+		t.Errorf("refs without dependencies = %v", refs)
+	}
+}
+
+func TestHelmValueUsed(t *testing.T) {
+	tests := []struct {
+		path, ref string
+		want      bool
+	}{
+		{"image.tag", "image.tag", true},
+		{"image.tag", "image", true},
+		{"image", "image.tag", true},
+		{"image", "imagePullSecrets", false},
+		{"image.tag", "image.repository", false},
+	}
+	for _, tt := range tests {
+		if got := HelmValueUsed(tt.path, tt.ref); got != tt.want {
+			t.Errorf("HelmValueUsed(%q, %q) = %v, want %v", tt.path, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestIsChartSource(t *testing.T) {
+	dir := filepath.Join("charts", "web")
+	tests := map[string]bool{
+		"Chart.yaml":                true,
+		"values.yaml":               true,
+		"templates/deployment.yaml": true,
+		"templates/_helpers.tpl":    true,
+		"values.schema.json":        false,
+		"charts/redis/values.yaml":  false,
+	}
+	for rel, want := range tests {
+		if got := isChartSource(dir, filepath.Join(dir, filepath.FromSlash(rel))); got != want {
+			t.Errorf("isChartSource(%s) = %v, want %v", rel, got, want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package analyzer
+
+import (
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+// jsDoc is a parsed /** ... */ comment. ReturnType carries the {type} of
+// @returns so untyped JavaScript functions can borrow it.
+type jsDoc struct {
+	ai.Documentation
+	ReturnType string
+}
+
+// parseJSDoc splits a JSDoc comment into its description and the
+// @param, @returns, @throws, @deprecated and @example tags
+func parseJSDoc(comment string) jsDoc {
+	var doc jsDoc
+	text := jsdocText(comment)
+	if text == "" {
+		return doc
+	}
+
+	// Group lines into the description and one block per tag
+	var summary []string
+	var tags []string
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@"):
+			tags = append(tags, line)
+		case len(tags) > 0:
+			tags[len(tags)-1] += "\n" + line
+		default:
+			summary = append(summary, line)
+		}
+	}
+	doc.Summary = strings.TrimSpace(strings.Join(summary, "\n"))
+
+	for _, tag := range tags {
+		name := strings.Fields(tag)[0]
+		body := strings.TrimSpace(strings.TrimPrefix(tag, name))
+
+		switch name {
+		case "@param", "@arg", "@argument":
+			typ, rest := jsdocType(body)
+			paramName, desc, _ := strings.Cut(rest, " ")
+			paramName, _, _ = strings.Cut(paramName, "\n")
+			desc = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(rest, paramName)), "-"))
+			doc.Parameters = append(doc.Parameters, ai.Param{
+				Name:        jsdocParamName(paramName),
+				Type:        typ,
+				Description: desc,
+			})
+		case "@returns", "@return":
+			typ, desc := jsdocType(body)
+			doc.ReturnType = typ
+			doc.Returns = joinTypeDescription(typ, desc)
+		case "@throws", "@exception":
+			typ, desc := jsdocType(body)
+			doc.Throws = append(doc.Throws, joinTypeDescription(typ, desc))
+		case "@deprecated":
+			doc.Deprecated = true
+			doc.DeprecationNote = body
+		case "@example":
+			doc.UsageExample = body
+		}
+	}
+	return doc
+}
+
+// annotate attaches the doc to fn and fills in parameter and result types
+// the source leaves out
+func (d jsDoc) annotate(fn *Function) {
+	fn.Doc = d.Documentation
+	types := make(map[string]string)
+	for _, p := range d.Parameters {
+		types[p.Name] = p.Type
+	}
+	for i, p := range fn.Parameters {
+		if p.Type != "" {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(p.Name, "..."), "?")
+		fn.Parameters[i].Type = types[name]
+	}
+	if len(fn.Results) == 0 && d.ReturnType != "" {
+		fn.Results = []Parameter{{Type: d.ReturnType}}
+	}
+}
+
+// jsdocType splits a leading {type} off a tag body
+func jsdocType(body string) (string, string) {
+	if !strings.HasPrefix(body, "{") {
+		return "", body
+	}
+	depth := 0
+	for i, c := range body {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(body[1:i]), strings.TrimSpace(body[i+1:])
+			}
+		}
+	}
+	return "", body
+}
+
+// jsdocParamName strips the optional-parameter brackets and default from
+// "[name=default]"
+func jsdocParamName(name string) string {
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "["), "]")
+	name, _, _ = strings.Cut(name, "=")
+	return name
+}
+
+func joinTypeDescription(typ, desc string) string {
+	if typ == "" {
+		return desc
+	}
+	if desc == "" {
+		return "(" + typ + ")"
+	}
+	return "(" + typ + ") " + desc
+}
+
+// jsdocText strips the comment markers from a /** ... */ block
+func jsdocText(comment string) string {
+	if comment == "" {
+		return ""
+	}
+	comment = strings.TrimSuffix(strings.TrimPrefix(comment, "/**"), "*/")
+
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "*")
+		lines = append(lines, strings.TrimRight(strings.TrimPrefix(line, " "), " "))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
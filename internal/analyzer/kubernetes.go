@@ -0,0 +1,266 @@
+package analyzer
+
+import (
+	"strings"
+)
+
+// kubernetesResource is a manifest found among the analyzed YAML files
+type kubernetesResource struct {
+	file      *AnalyzedFile
+	doc       *Struct
+	kind      string
+	name      string
+	namespace string
+	podLabels map[string]string // labels of the pods a workload runs
+}
+
+// podSpecPaths locates the pod spec of each workload kind
+var podSpecPaths = map[string][]string{
+	"Pod":         {"spec"},
+	"Deployment":  {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"ReplicaSet":  {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// KubernetesID names a resource as "Kind/name", prefixed by its namespace
+// outside the default one
+func KubernetesID(kind, name, namespace string) string {
+	if namespace != "" && namespace != "default" {
+		return namespace + "/" + kind + "/" + name
+	}
+	return kind + "/" + name
+}
+
+// linkKubernetesResources records, on every Kubernetes resource, the
+// resources it depends on and the ones using it: Services and the workloads
+// their selector matches, Ingresses and their backend Services, workloads and
+// the ConfigMaps, Secrets and PVCs they mount, and HPAs and their targets.
+// References to resources outside the project are kept as dependencies.
+func linkKubernetesResources(result *AnalyzerResult) {
+	var resources []*kubernetesResource
+	for _, file := range result.Files {
+		for pi := range file.Packages {
+			for si := range file.Packages[pi].Structs {
+				s := &file.Packages[pi].Structs[si]
+				if s.Kind != "kubernetes" {
+					continue
+				}
+				resources = append(resources, newKubernetesResource(file, s))
+			}
+		}
+	}
+	if len(resources) == 0 {
+		return
+	}
+
+	dependsOn := make(map[*kubernetesResource][]string)
+	usedBy := make(map[string][]string)
+	byID := make(map[string]*kubernetesResource)
+	for _, r := range resources {
+		byID[r.id()] = r
+	}
+	for _, r := range resources {
+		for _, dep := range r.references(resources) {
+			dependsOn[r] = appendOnce(dependsOn[r], dep)
+		}
+		for _, dep := range dependsOn[r] {
+			if _, ok := byID[dep]; ok {
+				usedBy[dep] = appendOnce(usedBy[dep], r.id())
+			}
+		}
+	}
+
+	for _, r := range resources {
+		deps, users := dependsOn[r], usedBy[r.id()]
+		if !equalLinks(r.doc.DependsOn, deps) || !equalLinks(r.doc.UsedBy, users) {
+			// The page of a reused file must show the new links
+			r.file.Reused = false
+		}
+		r.doc.DependsOn, r.doc.UsedBy = deps, users
+	}
+}
+
+func newKubernetesResource(file *AnalyzedFile, s *Struct) *kubernetesResource {
+	r := &kubernetesResource{
+		file:      file,
+		doc:       s,
+		kind:      fieldValue(s.Fields, "kind"),
+		name:      fieldValue(s.Fields, "metadata", "name"),
+		namespace: fieldValue(s.Fields, "metadata", "namespace"),
+	}
+	if path, ok := podSpecPaths[r.kind]; ok {
+		// The pod template's metadata sits next to its spec
+		meta := append(append([]string{}, path[:len(path)-1]...), "metadata", "labels")
+		if r.kind == "Pod" {
+			meta = []string{"metadata", "labels"}
+		}
+		r.podLabels = fieldMap(s.Fields, meta...)
+	}
+	return r
+}
+
+func (r *kubernetesResource) id() string {
+	return KubernetesID(r.kind, r.name, r.namespace)
+}
+
+// references returns the IDs of the resources r points at
+func (r *kubernetesResource) references(all []*kubernetesResource) []string {
+	var refs []string
+	ref := func(kind, name string) {
+		if name != "" {
+			refs = append(refs, KubernetesID(kind, name, r.namespace))
+		}
+	}
+
+	fields := r.doc.Fields
+	switch r.kind {
+	case "Service":
+		selector := fieldMap(fields, "spec", "selector")
+		if len(selector) == 0 {
+			break
+		}
+		for _, w := range all {
+			if w.podLabels != nil && w.namespace == r.namespace && matchesSelector(selector, w.podLabels) {
+				refs = append(refs, w.id())
+			}
+		}
+	case "Ingress":
+		backend := func(b *Field) {
+			if b == nil {
+				return
+			}
+			// networking.k8s.io/v1, then the older extensions/v1beta1 form
+			ref("Service", fieldValue(b.Fields, "service", "name"))
+			ref("Service", fieldValue(b.Fields, "serviceName"))
+		}
+		backend(fieldAt(fields, "spec", "defaultBackend"))
+		backend(fieldAt(fields, "spec", "backend"))
+		for _, rule := range children(fieldAt(fields, "spec", "rules")) {
+			for _, path := range children(fieldAt(rule.Fields, "http", "paths")) {
+				backend(fieldAt(path.Fields, "backend"))
+			}
+		}
+		for _, tls := range children(fieldAt(fields, "spec", "tls")) {
+			ref("Secret", fieldValue(tls.Fields, "secretName"))
+		}
+	case "HorizontalPodAutoscaler":
+		ref(fieldValue(fields, "spec", "scaleTargetRef", "kind"), fieldValue(fields, "spec", "scaleTargetRef", "name"))
+	}
+
+	if path, ok := podSpecPaths[r.kind]; ok {
+		pod := fieldAt(fields, path...)
+		if pod != nil {
+			refs = append(refs, podReferences(pod.Fields, r.namespace)...)
+		}
+	}
+	return refs
+}
+
+// podReferences returns the ConfigMaps, Secrets and PVCs a pod spec mounts
+// or reads its environment from
+func podReferences(pod []Field, namespace string) []string {
+	var refs []string
+	ref := func(kind, name string) {
+		if name != "" {
+			refs = append(refs, KubernetesID(kind, name, namespace))
+		}
+	}
+
+	for _, volume := range children(fieldAt(pod, "volumes")) {
+		ref("ConfigMap", fieldValue(volume.Fields, "configMap", "name"))
+		ref("Secret", fieldValue(volume.Fields, "secret", "secretName"))
+		ref("PersistentVolumeClaim", fieldValue(volume.Fields, "persistentVolumeClaim", "claimName"))
+		for _, source := range children(fieldAt(volume.Fields, "projected", "sources")) {
+			ref("ConfigMap", fieldValue(source.Fields, "configMap", "name"))
+			ref("Secret", fieldValue(source.Fields, "secret", "name"))
+		}
+	}
+	for _, list := range []string{"initContainers", "containers"} {
+		for _, container := range children(fieldAt(pod, list)) {
+			for _, from := range children(fieldAt(container.Fields, "envFrom")) {
+				ref("ConfigMap", fieldValue(from.Fields, "configMapRef", "name"))
+				ref("Secret", fieldValue(from.Fields, "secretRef", "name"))
+			}
+			for _, env := range children(fieldAt(container.Fields, "env")) {
+				ref("ConfigMap", fieldValue(env.Fields, "valueFrom", "configMapKeyRef", "name"))
+				ref("Secret", fieldValue(env.Fields, "valueFrom", "secretKeyRef", "name"))
+			}
+		}
+	}
+	for _, secret := range children(fieldAt(pod, "imagePullSecrets")) {
+		ref("Secret", fieldValue(secret.Fields, "name"))
+	}
+	return refs
+}
+
+// matchesSelector reports whether labels carry every key/value of selector
+func matchesSelector(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldAt follows a path of field names through nested fields
+func fieldAt(fields []Field, path ...string) *Field {
+	var found *Field
+	for _, name := range path {
+		found = nil
+		for i := range fields {
+			if fields[i].Name == name {
+				found = &fields[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		fields = found.Fields
+	}
+	return found
+}
+
+func fieldValue(fields []Field, path ...string) string {
+	if f := fieldAt(fields, path...); f != nil {
+		return strings.TrimSpace(f.Value)
+	}
+	return ""
+}
+
+// fieldMap returns the scalar entries of a map field, e.g. a label selector
+func fieldMap(fields []Field, path ...string) map[string]string {
+	f := fieldAt(fields, path...)
+	if f == nil {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, entry := range f.Fields {
+		m[entry.Name] = entry.Value
+	}
+	return m
+}
+
+// children returns the items of a list field, or nil
+func children(f *Field) []Field {
+	if f == nil {
+		return nil
+	}
+	return f.Fields
+}
+
+func equalLinks(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
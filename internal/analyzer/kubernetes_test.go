@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+const kubernetesManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web}
+spec:
+  selector: {matchLabels: {app: web}}
+  template:
+    metadata: {labels: {app: web, tier: frontend}}
+    spec:
+      imagePullSecrets: [{name: registry}]
+      containers:
+        - name: web
+          envFrom: [{configMapRef: {name: web-env}}]
+          env:
+            - name: TOKEN
+              valueFrom: {secretKeyRef: {name: api-token, key: token}}
+      volumes:
+        - {name: data, persistentVolumeClaim: {claimName: web-data}}
+        - name: certs
+          projected: {sources: [{secret: {name: tls-certs}}]}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: web}
+spec: {selector: {app: web}, ports: [{port: 80}]}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: headless}
+spec: {clusterIP: None}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: other, namespace: staging}
+spec: {selector: {app: web}}
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata: {name: web}
+spec:
+  tls: [{secretName: web-tls}]
+  rules:
+    - http:
+        paths:
+          - {path: /, backend: {service: {name: web, port: {number: 80}}}}
+---
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata: {name: web}
+spec: {scaleTargetRef: {apiVersion: apps/v1, kind: Deployment, name: web}}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: web-env}
+data: {LOG_LEVEL: info}
+`
+
+func TestLinkKubernetesResources(t *testing.T) {
+	dir := t.TempDir()
+	y := &YAMLAnalyzer{}
+	file, err := y.analyzeFile(writeTestFile(t, dir, "k8s/web.yaml", kubernetesManifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Reused = true
+	linkKubernetesResources(&AnalyzerResult{Files: []*AnalyzedFile{file}})
+
+	tests := []struct {
+		id        string
+		dependsOn []string
+		usedBy    []string
+	}{
+		{
+			id: "Deployment/web",
+			dependsOn: []string{
+				"PersistentVolumeClaim/web-data", "Secret/tls-certs",
+				"ConfigMap/web-env", "Secret/api-token", "Secret/registry",
+			},
+			usedBy: []string{"Service/web", "HorizontalPodAutoscaler/web"},
+		},
+		{id: "Service/web", dependsOn: []string{"Deployment/web"}, usedBy: []string{"Ingress/web"}},
+		{id: "Service/headless"},
+		{id: "staging/Service/other"},
+		{id: "Ingress/web", dependsOn: []string{"Service/web", "Secret/web-tls"}},
+		{id: "HorizontalPodAutoscaler/web", dependsOn: []string{"Deployment/web"}},
+		{id: "ConfigMap/web-env", usedBy: []string{"Deployment/web"}},
+	}
+
+	structs := file.Packages[0].Structs
+	if len(structs) != len(tests) {
+		t.Fatalf("got %d resources, want %d", len(structs), len(tests))
+	}
+	for i, tt := range tests {
+		s := structs[i]
+		id := KubernetesID(fieldValue(s.Fields, "kind"), fieldValue(s.Fields, "metadata", "name"), fieldValue(s.Fields, "metadata", "namespace"))
+		if id != tt.id {
+			t.Errorf("resource %d = %s, want %s", i, id, tt.id)
+			continue
+		}
+		if !reflect.DeepEqual(s.DependsOn, tt.dependsOn) {
+			t.Errorf("%s depends on %v, want %v", id, s.DependsOn, tt.dependsOn)
+		}
+		if !reflect.DeepEqual(s.UsedBy, tt.usedBy) {
+			t.Errorf("%s is used by %v, want %v", id, s.UsedBy, tt.usedBy)
+		}
+	}
+	if file.Reused {
+		t.Error("a reused file whose links changed keeps its old page")
+	}
+}
+
+func TestKubernetesID(t *testing.T) {
+	tests := []struct {
+		kind, name, namespace string
+		want                  string
+	}{
+		{"Service", "web", "", "Service/web"},
+		{"Service", "web", "default", "Service/web"},
+		{"Service", "web", "staging", "staging/Service/web"},
+	}
+	for _, tt := range tests {
+		if got := KubernetesID(tt.kind, tt.name, tt.namespace); got != tt.want {
+			t.Errorf("KubernetesID(%q, %q, %q) = %q, want %q", tt.kind, tt.name, tt.namespace, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+// MakefileAnalyzer documents Makefiles. Variables form one Struct of kind
+// "variables"; each target becomes a Struct of kind "target" with its
+// prerequisites and recipe, documented by its "##" help comment or the
+// comment above it. Phony targets and the default goal are recorded as
+// modifiers.
+type MakefileAnalyzer struct{}
+
+var (
+	makeAssignment = regexp.MustCompile(`^(?:(export|override)\s+)?([A-Za-z0-9_.-]+)\s*(:::=|::=|:=|\?=|\+=|!=|=)\s*(.*)$`)
+	makeRule       = regexp.MustCompile(`^([^:=#\t][^:=#]*?)\s*(::|:|&:)(?:\s+(.*))?$`)
+)
+
+func (m *MakefileAnalyzer) Supports(projectDir string, opts Options) bool {
+	paths, err := newFileWalker(projectDir, opts).walk(IsMakefile)
+	return err == nil && len(paths) > 0
+}
+
+func (m *MakefileAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	paths, err := newFileWalker(projectDir, opts).walk(IsMakefile)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := analyzeFiles(ctx, paths, opts, m.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyzerResult{Files: files}, nil
+}
+
+// IsMakefile reports whether path names a Makefile or an included .mk file
+func IsMakefile(path string) bool {
+	switch filepath.Base(path) {
+	case "Makefile", "makefile", "GNUmakefile":
+		return true
+	}
+	return strings.HasSuffix(path, ".mk")
+}
+
+// analyzeFile extracts the variables and targets of a single Makefile
+func (m *MakefileAnalyzer) analyzeFile(path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := Package{
+		Name: filepath.Base(filepath.Dir(path)),
+		Path: path,
+	}
+	vars, targets := parseMakefile(string(content))
+	if len(vars.Fields) > 0 {
+		pkg.Structs = append(pkg.Structs, vars)
+	}
+	pkg.Structs = append(pkg.Structs, targets...)
+
+	return &AnalyzedFile{
+		Path:     path,
+		Packages: []Package{pkg},
+	}, nil
+}
+
+// parseMakefile returns the variables and the explicit targets of a
+// Makefile, in order. Pattern rules, special targets other than .PHONY and
+// define blocks are skipped.
+func parseMakefile(src string) (Struct, []Struct) {
+	vars := Struct{Name: "variables", Kind: "variables"}
+	var targets []Struct
+	index := make(map[string]int)
+	phony := make(map[string]bool)
+	defaultGoal := ""
+
+	var doc []string
+	var current []int // targets receiving recipe lines
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		raw := lines[i]
+
+		if strings.HasPrefix(raw, "\t") {
+			// Recipe line of the current rule
+			recipe := strings.TrimSpace(raw)
+			for strings.HasSuffix(recipe, "\\") && i+1 < len(lines) {
+				i++
+				recipe = strings.TrimSpace(strings.TrimSuffix(recipe, "\\")) + " " + strings.TrimSpace(lines[i])
+			}
+			for _, t := range current {
+				addMakeRecipe(&targets[t], recipe)
+			}
+			continue
+		}
+
+		line := strings.TrimSpace(raw)
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = strings.TrimSpace(strings.TrimSuffix(line, "\\")) + " " + strings.TrimSpace(lines[i])
+		}
+
+		switch {
+		case line == "":
+			doc = nil
+			current = nil
+			continue
+		case strings.HasPrefix(line, "#"):
+			doc = append(doc, strings.TrimSpace(strings.TrimLeft(line, "#")))
+			continue
+		case strings.HasPrefix(line, "define "):
+			for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "endef" {
+				i++
+			}
+			i++
+			doc = nil
+			continue
+		}
+
+		// Split off a trailing "## help" or "# comment"
+		help := ""
+		if idx := strings.Index(line, "#"); idx >= 0 && !strings.Contains(line[:idx], "\\") {
+			help = strings.TrimSpace(strings.TrimLeft(line[idx:], "#"))
+			line = strings.TrimSpace(line[:idx])
+		}
+		if help == "" {
+			help = strings.Join(doc, "\n")
+		}
+		doc = nil
+
+		if match := makeAssignment.FindStringSubmatch(line); match != nil {
+			current = nil
+			name, op, value := match[2], match[3], strings.TrimSpace(match[4])
+			if name == ".DEFAULT_GOAL" {
+				defaultGoal = value
+				continue
+			}
+			field := Field{Name: name, Type: "string", Value: value, DocYAML: ai.YAMLDocumentation{Summary: help}}
+			if match[1] != "" {
+				field.Modifiers = append(field.Modifiers, match[1])
+			}
+			if op != "=" {
+				field.Modifiers = append(field.Modifiers, op)
+			}
+			vars.Fields = append(vars.Fields, field)
+			continue
+		}
+
+		match := makeRule.FindStringSubmatch(line)
+		if match == nil {
+			// Conditionals, includes and other directives
+			current = nil
+			continue
+		}
+		names := strings.Fields(match[1])
+		prereqs := strings.Fields(match[3])
+		if len(names) == 1 && names[0] == ".PHONY" {
+			for _, p := range prereqs {
+				phony[p] = true
+			}
+			current = nil
+			continue
+		}
+
+		current = nil
+		if strings.Contains(match[3], "=") {
+			// Target-specific variable, e.g. "build: GOOS = linux"
+			continue
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, ".") || strings.Contains(name, "%") {
+				continue
+			}
+			t, ok := index[name]
+			if !ok {
+				t = len(targets)
+				index[name] = t
+				targets = append(targets, Struct{Name: name, Kind: "target"})
+				if defaultGoal == "" {
+					defaultGoal = name
+				}
+			}
+			// Help text goes in Doc, which the AI does not replace
+			if help != "" && targets[t].Doc.Summary == "" {
+				targets[t].Doc.Summary = help
+			}
+			addMakePrerequisites(&targets[t], prereqs)
+			current = append(current, t)
+		}
+	}
+
+	for i := range targets {
+		if phony[targets[i].Name] {
+			targets[i].Modifiers = append(targets[i].Modifiers, "phony")
+		}
+		if targets[i].Name == defaultGoal {
+			targets[i].Modifiers = append(targets[i].Modifiers, "default")
+		}
+	}
+	return vars, targets
+}
+
+// addMakePrerequisites appends to the target's "prerequisites" field;
+// order-only prerequisites after "|" are kept as written
+func addMakePrerequisites(target *Struct, prereqs []string) {
+	if len(prereqs) == 0 {
+		return
+	}
+	field := makeListField(target, "prerequisites")
+	for _, p := range prereqs {
+		field.Fields = append(field.Fields, Field{Name: fmt.Sprintf("[%d]", len(field.Fields)), Type: "string", Value: p})
+	}
+}
+
+func addMakeRecipe(target *Struct, recipe string) {
+	field := makeListField(target, "recipe")
+	field.Fields = append(field.Fields, Field{Name: fmt.Sprintf("[%d]", len(field.Fields)), Type: "string", Value: recipe})
+}
+
+// makeListField returns the named array field of a target, adding it if needed
+func makeListField(target *Struct, name string) *Field {
+	for i := range target.Fields {
+		if target.Fields[i].Name == name {
+			return &target.Fields[i]
+		}
+	}
+	target.Fields = append(target.Fields, Field{Name: name, Type: "array"})
+	return &target.Fields[len(target.Fields)-1]
+}
@@ -0,0 +1,156 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// makeTarget flattens a target into its doc, modifiers, prerequisites and recipe
+func makeTarget(s Struct) []string {
+	summary := []string{s.Doc.Summary}
+	summary = append(summary, s.Modifiers...)
+	for _, f := range s.Fields {
+		for _, item := range f.Fields {
+			summary = append(summary, f.Name+"="+item.Value)
+		}
+	}
+	return summary
+}
+
+func TestParseMakefileTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		targets map[string][]string
+		order   []string
+	}{
+		{
+			name: "help comments",
+			src: `# Builds the binary
+build: deps ## Compile everything
+	go build ./...
+
+# Runs the tests
+test: build
+	go test ./...
+`,
+			targets: map[string][]string{
+				"build": {"Compile everything", "default", "prerequisites=deps", "recipe=go build ./..."},
+				"test":  {"Runs the tests", "prerequisites=build", "recipe=go test ./..."},
+			},
+			order: []string{"build", "test"},
+		},
+		{
+			name: "phony targets and default goal",
+			src: `.PHONY: all clean
+.DEFAULT_GOAL := clean
+all: app
+clean:
+	rm -rf bin
+app: main.go | bin
+`,
+			targets: map[string][]string{
+				"all":   {"", "phony", "prerequisites=app"},
+				"clean": {"", "phony", "default", "recipe=rm -rf bin"},
+				"app":   {"", "prerequisites=main.go", "prerequisites=|", "prerequisites=bin"},
+			},
+			order: []string{"all", "clean", "app"},
+		},
+		{
+			name: "default goal set after the first target",
+			src:  "all: build\nbuild:\n\tgo build\n.DEFAULT_GOAL = build\n",
+			targets: map[string][]string{
+				"all":   {"", "prerequisites=build"},
+				"build": {"", "default", "recipe=go build"},
+			},
+			order: []string{"all", "build"},
+		},
+		{
+			name: "define blocks",
+			src: `define HELP
+usage: make target
+endef
+help: ## Show usage
+	@echo "$$HELP"
+`,
+			targets: map[string][]string{
+				"help": {"Show usage", "default", `recipe=@echo "$$HELP"`},
+			},
+			order: []string{"help"},
+		},
+		{
+			name: "target-specific variables",
+			src:  "release: GOOS = linux\nrelease: build\n\tgo build\n%.o: %.c\n\tcc -c $<\n",
+			targets: map[string][]string{
+				"release": {"", "default", "prerequisites=build", "recipe=go build"},
+			},
+			order: []string{"release"},
+		},
+		{
+			name: "recipe continuations",
+			src:  "lint:\n\tgolangci-lint run \\\n\t  --fix \\\n\t  ./...\n\tgo vet ./...\n",
+			targets: map[string][]string{
+				"lint": {"", "default", "recipe=golangci-lint run --fix ./...", "recipe=go vet ./..."},
+			},
+			order: []string{"lint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, targets := parseMakefile(tt.src)
+			var order []string
+			for _, s := range targets {
+				order = append(order, s.Name)
+				if s.Kind != "target" {
+					t.Errorf("%s kind = %q", s.Name, s.Kind)
+				}
+				if got := makeTarget(s); !reflect.DeepEqual(got, tt.targets[s.Name]) {
+					t.Errorf("%s = %q, want %q", s.Name, got, tt.targets[s.Name])
+				}
+			}
+			if !reflect.DeepEqual(order, tt.order) {
+				t.Errorf("targets = %v, want %v", order, tt.order)
+			}
+		})
+	}
+}
+
+func TestParseMakefileVariables(t *testing.T) {
+	vars, _ := parseMakefile(`# Output directory
+BIN := bin
+GOFLAGS ?= -trimpath
+export CGO_ENABLED = 0
+LDFLAGS += -s -w # Strip symbols
+VERSION != git describe
+define SCRIPT
+FAKE = nope
+endef
+`)
+	want := []Field{
+		{Name: "BIN", Type: "string", Value: "bin", Modifiers: []string{":="}},
+		{Name: "GOFLAGS", Type: "string", Value: "-trimpath", Modifiers: []string{"?="}},
+		{Name: "CGO_ENABLED", Type: "string", Value: "0", Modifiers: []string{"export"}},
+		{Name: "LDFLAGS", Type: "string", Value: "-s -w", Modifiers: []string{"+="}},
+		{Name: "VERSION", Type: "string", Value: "git describe", Modifiers: []string{"!="}},
+	}
+	want[0].DocYAML.Summary = "Output directory"
+	want[3].DocYAML.Summary = "Strip symbols"
+	if vars.Kind != "variables" || !reflect.DeepEqual(vars.Fields, want) {
+		t.Errorf("variables = %+v\nwant %+v", vars.Fields, want)
+	}
+}
+
+func TestIsMakefile(t *testing.T) {
+	tests := map[string]bool{
+		"Makefile":          true,
+		"sub/GNUmakefile":   true,
+		"build/rules.mk":    true,
+		"Makefile.am":       false,
+		"docs/makefile.txt": false,
+	}
+	for path, want := range tests {
+		if got := IsMakefile(path); got != want {
+			t.Errorf("IsMakefile(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,624 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIAnalyzer documents OpenAPI 3 and Swagger 2 specifications. A spec
+// becomes one package per tag holding its operations as functions, plus an
+// overview package with the API info, schemas and security schemes. It runs
+// before YAMLAnalyzer so specs are not documented as generic YAML.
+type OpenAPIAnalyzer struct{}
+
+var openAPIExtensions = []string{".yaml", ".yml", ".json"}
+
+// A top-level "openapi:"/"swagger:" key in YAML, or its JSON equivalent
+var openAPIMarker = regexp.MustCompile(`(?m)^["']?(openapi|swagger)["']?\s*:|"(openapi|swagger)"\s*:\s*"`)
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+func (o *OpenAPIAnalyzer) Supports(projectDir string, opts Options) bool {
+	specs, err := findOpenAPISpecs(projectDir, opts)
+	return err == nil && len(specs) > 0
+}
+
+func (o *OpenAPIAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	paths, err := findOpenAPISpecs(projectDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := analyzeFiles(ctx, paths, opts, func(path string) (*AnalyzedFile, error) {
+		return o.analyzeFile(projectDir, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyzerResult{Files: files}, nil
+}
+
+// findOpenAPISpecs lists the YAML and JSON files that look like API specs
+func findOpenAPISpecs(root string, opts Options) ([]string, error) {
+	return newFileWalker(root, opts).walk(func(path string) bool {
+		if !hasExtension(path, openAPIExtensions) {
+			return false
+		}
+		data, err := os.ReadFile(path)
+		return err == nil && openAPIMarker.Match(data)
+	})
+}
+
+// analyzeFile extracts the operations, schemas and security schemes of a
+// spec. Files that turn out not to be specs give nil, leaving them to the
+// YAML analyzer.
+func (o *OpenAPIAnalyzer) analyzeFile(projectDir, path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	limitYAMLAliases(&doc)
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	spec := &openAPISpec{root: doc.Content[0]}
+	if mappingValue(spec.root, "openapi") == nil && mappingValue(spec.root, "swagger") == nil {
+		// The marker matched something nested; not a spec after all
+		return nil, nil
+	}
+
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil {
+		rel = filepath.Base(path)
+	}
+	return &AnalyzedFile{Path: path, Language: "OpenAPI", Packages: spec.packages(path, rel)}, nil
+}
+
+type openAPISpec struct {
+	root *yaml.Node
+}
+
+func (s *openAPISpec) swagger() bool {
+	return mappingValue(s.root, "swagger") != nil
+}
+
+// packages builds the overview package followed by one package per tag.
+// Pages live under api/ at the spec's path relative to the project, so
+// specs sharing a file name get their own pages.
+func (s *openAPISpec) packages(path, rel string) []Package {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	importPath := "api"
+	for _, segment := range strings.Split(filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel))), "/") {
+		if segment != "" && segment != "." && segment != ".." {
+			importPath += "/" + pathSlug(segment)
+		}
+	}
+
+	info := mappingValue(s.root, "info")
+	title := scalarValue(info, "title")
+	if title == "" {
+		title = base
+	}
+
+	overview := Package{
+		Name:       title,
+		ImportPath: importPath,
+		Path:       path,
+		Structs:    []Struct{s.infoStruct(title, info)},
+	}
+	overview.Structs = append(overview.Structs, s.schemas()...)
+	overview.Structs = append(overview.Structs, s.securitySchemes()...)
+
+	// Tags declared at the top level come first, in their declared order
+	tagDocs := make(map[string]string)
+	var tags []string
+	if list := mappingValue(s.root, "tags"); list != nil && list.Kind == yaml.SequenceNode {
+		for _, tag := range list.Content {
+			name := scalarValue(tag, "name")
+			tags = appendOnce(tags, name)
+			tagDocs[name] = scalarValue(tag, "description")
+		}
+	}
+
+	byTag := make(map[string][]Function)
+	for _, op := range s.operations() {
+		opTags := []string{"default"}
+		if list := mappingValue(op.node, "tags"); list != nil && list.Kind == yaml.SequenceNode && len(list.Content) > 0 {
+			opTags = nil
+			for _, t := range list.Content {
+				opTags = append(opTags, t.Value)
+			}
+		}
+		for _, tag := range opTags {
+			tags = appendOnce(tags, tag)
+			byTag[tag] = append(byTag[tag], op.fn)
+		}
+	}
+
+	packages := []Package{overview}
+	used := make(map[string]bool)
+	for _, tag := range tags {
+		if len(byTag[tag]) == 0 {
+			continue
+		}
+		// Tags slugifying alike, such as "Pets" and "pets!", get distinct pages
+		slug := pathSlug(tag)
+		for n := 2; used[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", pathSlug(tag), n)
+		}
+		used[slug] = true
+		pkg := Package{
+			Name:       tag,
+			ImportPath: importPath + "/" + slug,
+			Path:       path,
+			Funcs:      byTag[tag],
+		}
+		if tagDocs[tag] != "" {
+			pkg.Structs = []Struct{{
+				Name: tag,
+				Kind: "tag",
+				Doc:  ai.Documentation{Summary: tagDocs[tag]},
+			}}
+		}
+		packages = append(packages, pkg)
+	}
+	return packages
+}
+
+// infoStruct records the API title, version, description and servers
+func (s *openAPISpec) infoStruct(title string, info *yaml.Node) Struct {
+	st := Struct{
+		Name: title,
+		Kind: "info",
+		Doc:  ai.Documentation{Summary: strings.TrimSpace(scalarValue(info, "description"))},
+	}
+	if version := scalarValue(info, "version"); version != "" {
+		st.Fields = append(st.Fields, Field{Name: "version", Type: "string", Value: version})
+	}
+	if s.swagger() {
+		st.Fields = append(st.Fields, Field{Name: "specification", Type: "string", Value: "Swagger " + scalarValue(s.root, "swagger")})
+		if host := scalarValue(s.root, "host"); host != "" {
+			st.Fields = append(st.Fields, Field{Name: "server", Type: "string", Value: host + scalarValue(s.root, "basePath")})
+		}
+		return st
+	}
+
+	st.Fields = append(st.Fields, Field{Name: "specification", Type: "string", Value: "OpenAPI " + scalarValue(s.root, "openapi")})
+	if servers := mappingValue(s.root, "servers"); servers != nil && servers.Kind == yaml.SequenceNode {
+		for _, server := range servers.Content {
+			st.Fields = append(st.Fields, Field{
+				Name:  "server",
+				Type:  "string",
+				Value: scalarValue(server, "url"),
+				Doc:   ai.Documentation{Summary: scalarValue(server, "description")},
+			})
+		}
+	}
+	return st
+}
+
+type openAPIOperation struct {
+	node *yaml.Node
+	fn   Function
+}
+
+// operations lists every operation in document order
+func (s *openAPISpec) operations() []openAPIOperation {
+	var ops []openAPIOperation
+	paths := mappingValue(s.root, "paths")
+	forEachPair(paths, func(route string, item *yaml.Node) {
+		item = s.resolve(item)
+		shared := mappingValue(item, "parameters")
+		for _, method := range openAPIMethods {
+			op := mappingValue(item, method)
+			if op == nil || op.Kind != yaml.MappingNode {
+				continue
+			}
+			ops = append(ops, openAPIOperation{node: op, fn: s.operation(method, route, op, shared)})
+		}
+	})
+	return ops
+}
+
+// operation describes one HTTP operation as a function named "METHOD /path"
+func (s *openAPISpec) operation(method, route string, op, shared *yaml.Node) Function {
+	fn := Function{Name: strings.ToUpper(method) + " " + route}
+
+	summary := strings.TrimSpace(scalarValue(op, "summary"))
+	description := strings.TrimSpace(scalarValue(op, "description"))
+	switch {
+	case summary != "" && description != "" && description != summary:
+		fn.Doc.Summary = summary + "\n\n" + description
+	case summary != "":
+		fn.Doc.Summary = summary
+	default:
+		fn.Doc.Summary = description
+	}
+	if scalarValue(op, "deprecated") == "true" {
+		fn.Modifiers = append(fn.Modifiers, "deprecated")
+	}
+
+	// Operation parameters override path-level ones with the same name and location
+	seen := make(map[string]bool)
+	for _, list := range []*yaml.Node{mappingValue(op, "parameters"), shared} {
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, p := range list.Content {
+			param := s.parameter(s.resolve(p))
+			key := param.In + ":" + param.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			fn.Parameters = append(fn.Parameters, param)
+		}
+	}
+	if body := s.resolve(mappingValue(op, "requestBody")); body != nil {
+		schema, mediaTypes := s.contentSchema(body)
+		fn.Parameters = append(fn.Parameters, Parameter{
+			Name:        "body",
+			In:          "body",
+			Type:        schema,
+			Required:    scalarValue(body, "required") == "true",
+			Description: joinNonEmpty(mediaTypes, strings.TrimSpace(scalarValue(body, "description"))),
+		})
+	}
+
+	forEachPair(mappingValue(op, "responses"), func(status string, resp *yaml.Node) {
+		resp = s.resolve(resp)
+		var schema string
+		if s.swagger() {
+			schema = s.schemaType(mappingValue(resp, "schema"))
+		} else {
+			schema, _ = s.contentSchema(resp)
+		}
+		fn.Results = append(fn.Results, Parameter{
+			Name:        status,
+			Type:        schema,
+			Description: strings.TrimSpace(scalarValue(resp, "description")),
+		})
+	})
+
+	security := mappingValue(op, "security")
+	if security == nil {
+		security = mappingValue(s.root, "security")
+	}
+	if security != nil && security.Kind == yaml.SequenceNode {
+		for _, requirement := range security.Content {
+			forEachPair(requirement, func(name string, scopes *yaml.Node) {
+				if scopes != nil && len(scopes.Content) > 0 {
+					var list []string
+					for _, scope := range scopes.Content {
+						list = append(list, scope.Value)
+					}
+					name += " (" + strings.Join(list, ", ") + ")"
+				}
+				fn.Security = appendOnce(fn.Security, name)
+			})
+		}
+	}
+	return fn
+}
+
+// parameter describes a path, query, header, cookie, form or body parameter
+func (s *openAPISpec) parameter(node *yaml.Node) Parameter {
+	param := Parameter{
+		Name:        scalarValue(node, "name"),
+		In:          scalarValue(node, "in"),
+		Required:    scalarValue(node, "required") == "true",
+		Description: strings.TrimSpace(scalarValue(node, "description")),
+	}
+	schema := mappingValue(node, "schema")
+	if schema == nil {
+		// Swagger 2 puts the type on the parameter itself
+		schema = node
+	}
+	param.Type = s.schemaType(schema)
+	param.Default = scalarValue(s.resolve(schema), "default")
+	return param
+}
+
+// contentSchema returns the schema type of the first media type in a
+// request body or response, along with every media type offered
+func (s *openAPISpec) contentSchema(node *yaml.Node) (string, string) {
+	var schema string
+	var mediaTypes []string
+	forEachPair(mappingValue(node, "content"), func(mediaType string, media *yaml.Node) {
+		if schema == "" {
+			schema = s.schemaType(mappingValue(media, "schema"))
+		}
+		mediaTypes = append(mediaTypes, mediaType)
+	})
+	if schema == "" && s.swagger() {
+		schema = s.schemaType(mappingValue(node, "schema"))
+	}
+	if len(mediaTypes) == 0 {
+		return schema, ""
+	}
+	return schema, "(" + strings.Join(mediaTypes, ", ") + ")"
+}
+
+// schemas turns components.schemas (definitions in Swagger 2) into structs
+func (s *openAPISpec) schemas() []Struct {
+	container := mappingValue(mappingValue(s.root, "components"), "schemas")
+	if s.swagger() {
+		container = mappingValue(s.root, "definitions")
+	}
+
+	var structs []Struct
+	forEachPair(container, func(name string, schema *yaml.Node) {
+		st := Struct{
+			Name:   name,
+			Kind:   "schema",
+			Doc:    ai.Documentation{Summary: strings.TrimSpace(scalarValue(schema, "description"))},
+			Fields: s.properties(schema),
+		}
+		if len(st.Fields) == 0 {
+			// Not an object: describe the schema itself, e.g. an enum
+			st.Fields = []Field{{Name: "type", Type: s.schemaType(schema), Value: enumValues(schema)}}
+		}
+		structs = append(structs, st)
+	})
+	return structs
+}
+
+// properties lists the properties of an object schema, including those
+// pulled in through allOf
+func (s *openAPISpec) properties(schema *yaml.Node) []Field {
+	return s.collectProperties(schema, make(map[*yaml.Node]bool))
+}
+
+// collectProperties skips schemas it has already seen, so allOf cycles
+// through $ref end
+func (s *openAPISpec) collectProperties(schema *yaml.Node, seen map[*yaml.Node]bool) []Field {
+	schema = s.resolve(schema)
+	if schema == nil || seen[schema] {
+		return nil
+	}
+	seen[schema] = true
+
+	var fields []Field
+	if all := mappingValue(schema, "allOf"); all != nil && all.Kind == yaml.SequenceNode {
+		for _, part := range all.Content {
+			fields = append(fields, s.collectProperties(part, seen)...)
+		}
+	}
+
+	required := make(map[string]bool)
+	if list := mappingValue(schema, "required"); list != nil && list.Kind == yaml.SequenceNode {
+		for _, r := range list.Content {
+			required[r.Value] = true
+		}
+	}
+	forEachPair(mappingValue(schema, "properties"), func(name string, prop *yaml.Node) {
+		field := Field{
+			Name:  name,
+			Type:  s.schemaType(prop),
+			Value: enumValues(prop),
+			Doc:   ai.Documentation{Summary: strings.TrimSpace(scalarValue(s.resolve(prop), "description"))},
+		}
+		if required[name] {
+			field.Modifiers = append(field.Modifiers, "required")
+		}
+		for _, flag := range []string{"readOnly", "writeOnly", "nullable", "deprecated"} {
+			if scalarValue(prop, flag) == "true" {
+				field.Modifiers = append(field.Modifiers, flag)
+			}
+		}
+		fields = append(fields, field)
+	})
+	return fields
+}
+
+// securitySchemes turns components.securitySchemes (securityDefinitions in
+// Swagger 2) into structs
+func (s *openAPISpec) securitySchemes() []Struct {
+	container := mappingValue(mappingValue(s.root, "components"), "securitySchemes")
+	if s.swagger() {
+		container = mappingValue(s.root, "securityDefinitions")
+	}
+
+	var structs []Struct
+	forEachPair(container, func(name string, scheme *yaml.Node) {
+		scheme = s.resolve(scheme)
+		st := Struct{
+			Name: name,
+			Kind: "securityScheme",
+			Doc:  ai.Documentation{Summary: strings.TrimSpace(scalarValue(scheme, "description"))},
+		}
+		forEachPair(scheme, func(key string, value *yaml.Node) {
+			if key == "description" {
+				return
+			}
+			field := Field{Name: key, Type: "string", Value: value.Value}
+			if value.Kind != yaml.ScalarNode {
+				// e.g. OAuth2 flows
+				field.Type = "map"
+				field.Value = strings.Join(mappingKeys(value), ", ")
+			}
+			st.Fields = append(st.Fields, field)
+		})
+		structs = append(structs, st)
+	})
+	return structs
+}
+
+// schemaType renders a schema as a short type expression such as
+// "array[Pet]", "string(date-time)" or "map[string]integer"
+func (s *openAPISpec) schemaType(schema *yaml.Node) string {
+	if schema == nil {
+		return ""
+	}
+	if ref := scalarValue(schema, "$ref"); ref != "" {
+		return ref[strings.LastIndex(ref, "/")+1:]
+	}
+	for _, combinator := range []struct{ key, sep string }{{"allOf", " & "}, {"oneOf", " | "}, {"anyOf", " | "}} {
+		if list := mappingValue(schema, combinator.key); list != nil && list.Kind == yaml.SequenceNode {
+			parts := make([]string, len(list.Content))
+			for i, part := range list.Content {
+				parts[i] = s.schemaType(part)
+			}
+			return strings.Join(parts, combinator.sep)
+		}
+	}
+
+	typ := scalarValue(schema, "type")
+	if list := mappingValue(schema, "type"); list != nil && list.Kind == yaml.SequenceNode {
+		// OpenAPI 3.1 type lists, e.g. [string, "null"]
+		var parts []string
+		for _, t := range list.Content {
+			parts = append(parts, t.Value)
+		}
+		typ = strings.Join(parts, " | ")
+	}
+	switch typ {
+	case "array":
+		return "array[" + s.schemaType(mappingValue(schema, "items")) + "]"
+	case "object", "":
+		if extra := mappingValue(schema, "additionalProperties"); extra != nil && extra.Kind == yaml.MappingNode {
+			return "map[string]" + s.schemaType(extra)
+		}
+		if typ == "" && mappingValue(schema, "properties") == nil {
+			return ""
+		}
+		return "object"
+	}
+	if format := scalarValue(schema, "format"); format != "" {
+		return typ + "(" + format + ")"
+	}
+	return typ
+}
+
+// resolve follows a local "$ref" to the node it points to
+func (s *openAPISpec) resolve(node *yaml.Node) *yaml.Node {
+	for depth := 0; depth < 16 && node != nil; depth++ {
+		ref := scalarValue(node, "$ref")
+		if !strings.HasPrefix(ref, "#/") {
+			return node
+		}
+		target := s.root
+		for _, part := range strings.Split(ref[2:], "/") {
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			target = mappingValue(target, part)
+		}
+		if target == nil {
+			return node
+		}
+		node = target
+	}
+	return node
+}
+
+// enumValues lists the allowed values of an enum schema
+func enumValues(schema *yaml.Node) string {
+	list := mappingValue(schema, "enum")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return ""
+	}
+	values := make([]string, len(list.Content))
+	for i, v := range list.Content {
+		values[i] = v.Value
+	}
+	return strings.Join(values, " | ")
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the scalar value of key in a mapping node
+func scalarValue(node *yaml.Node, key string) string {
+	value := mappingValue(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return value.Value
+}
+
+// forEachPair calls fn for each key of a mapping node, in document order
+func forEachPair(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i].Value, node.Content[i+1])
+	}
+}
+
+func mappingKeys(node *yaml.Node) []string {
+	var keys []string
+	forEachPair(node, func(key string, _ *yaml.Node) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+func appendOnce(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
+
+func joinNonEmpty(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// pathSlug slugifies a page path segment. Names without a single letter or
+// digit, which would slugify to "", are named after their hash instead.
+func pathSlug(name string) string {
+	if slug := slugify(name); slug != "" {
+		return slug
+	}
+	sum := sha256.Sum256([]byte(name))
+	return "x-" + hex.EncodeToString(sum[:4])
+}
+
+// slugify turns a name into a lowercase path segment
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
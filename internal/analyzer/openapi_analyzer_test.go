@@ -0,0 +1,313 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeTestFile writes content under dir, creating parent directories
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func importPaths(file *AnalyzedFile) []string {
+	var paths []string
+	for _, pkg := range file.Packages {
+		paths = append(paths, pkg.ImportPath)
+	}
+	return paths
+}
+
+func TestOpenAPIPagePaths(t *testing.T) {
+	dir := t.TempDir()
+	spec := `openapi: 3.0.0
+info: {title: Items, version: "1"}
+paths:
+  /items:
+    get: {tags: [Items], summary: List items}
+    post: {tags: ["items!"], summary: Add an item}
+  /misc:
+    get: {tags: ["***"], summary: Misc}
+`
+	a := writeTestFile(t, dir, "svc/a/openapi.yaml", spec)
+	b := writeTestFile(t, dir, "svc/b/openapi.yaml", spec)
+
+	o := &OpenAPIAnalyzer{}
+	fileA, err := o.analyzeFile(dir, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileB, err := o.analyzeFile(dir, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	for _, path := range append(importPaths(fileA), importPaths(fileB)...) {
+		if seen[path] {
+			t.Errorf("page %q is written twice", path)
+		}
+		seen[path] = true
+	}
+	if got := fileA.Packages[0].ImportPath; got != "api/svc/a/openapi" {
+		t.Errorf("overview of svc/a = %q, want api/svc/a/openapi", got)
+	}
+	for _, pkg := range fileA.Packages[1:] {
+		if filepath.Dir(pkg.ImportPath) != "api/svc/a/openapi" || filepath.Base(pkg.ImportPath) == "" {
+			t.Errorf("tag %q has page %q", pkg.Name, pkg.ImportPath)
+		}
+	}
+}
+
+func TestOpenAPINotASpec(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"nested.yaml": "config:\n  openapi: \"3.0\"\n",
+		"empty.yaml":  "",
+		"list.json":   `[{"openapi": "3.0.0"}]`,
+	}
+	o := &OpenAPIAnalyzer{}
+	for name, content := range tests {
+		file, err := o.analyzeFile(dir, writeTestFile(t, dir, name, content))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if file != nil {
+			t.Errorf("%s: got %+v, want nil so the YAML analyzer keeps it", name, file)
+		}
+	}
+}
+
+func TestPathSlug(t *testing.T) {
+	tests := map[string]string{
+		"Pet Store": "pet-store",
+		"v2_users":  "v2-users",
+		"***":       pathSlug("***"),
+	}
+	for name, want := range tests {
+		if got := pathSlug(name); got != want || got == "" {
+			t.Errorf("pathSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if pathSlug("***") == pathSlug("+++") {
+		t.Error("names without letters share a slug")
+	}
+}
+
+func parseOpenAPITest(t *testing.T, src string) *openAPISpec {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(src), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return &openAPISpec{root: doc.Content[0]}
+}
+
+func findStruct(structs []Struct, name string) *Struct {
+	for i := range structs {
+		if structs[i].Name == name {
+			return &structs[i]
+		}
+	}
+	return nil
+}
+
+func TestOpenAPIRefCycles(t *testing.T) {
+	spec := parseOpenAPITest(t, `openapi: 3.0.0
+paths: {}
+components:
+  schemas:
+    A:
+      allOf:
+        - $ref: '#/components/schemas/B'
+        - properties: {a: {type: string}}
+    B:
+      allOf:
+        - $ref: '#/components/schemas/A'
+        - properties: {b: {type: integer}}
+    Self:
+      allOf:
+        - $ref: '#/components/schemas/Self'
+    Loop:
+      $ref: '#/components/schemas/Loop'
+    Node:
+      properties:
+        children: {type: array, items: {$ref: '#/components/schemas/Node'}}
+        parent: {$ref: '#/components/schemas/Node'}
+`)
+
+	tests := []struct {
+		schema string
+		fields []string
+	}{
+		{"A", []string{"b", "a"}},
+		{"B", []string{"a", "b"}},
+		{"Self", []string{"type"}},
+		{"Loop", []string{"type"}},
+		{"Node", []string{"children", "parent"}},
+	}
+	structs := spec.schemas()
+	for _, tt := range tests {
+		st := findStruct(structs, tt.schema)
+		if st == nil {
+			t.Errorf("schema %s missing", tt.schema)
+			continue
+		}
+		var fields []string
+		for _, f := range st.Fields {
+			fields = append(fields, f.Name)
+		}
+		if !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("%s fields = %v, want %v", tt.schema, fields, tt.fields)
+		}
+	}
+
+	node := findStruct(structs, "Node")
+	if node.Fields[0].Type != "array[Node]" || node.Fields[1].Type != "Node" {
+		t.Errorf("Node fields = %+v", node.Fields)
+	}
+}
+
+func TestOpenAPISchemaType(t *testing.T) {
+	tests := []struct {
+		schema string
+		want   string
+	}{
+		{"{$ref: '#/components/schemas/Pet'}", "Pet"},
+		{"{type: array, items: {$ref: '#/definitions/Pet'}}", "array[Pet]"},
+		{"{type: string, format: date-time}", "string(date-time)"},
+		{"{type: object, additionalProperties: {type: integer}}", "map[string]integer"},
+		{"{type: [string, 'null']}", "string | null"},
+		{"{oneOf: [{$ref: '#/x/Cat'}, {$ref: '#/x/Dog'}]}", "Cat | Dog"},
+		{"{allOf: [{$ref: '#/x/Base'}, {type: object}]}", "Base & object"},
+		{"{properties: {a: {type: string}}}", "object"},
+		{"{}", ""},
+	}
+	spec := &openAPISpec{}
+	for _, tt := range tests {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(tt.schema), &node); err != nil {
+			t.Fatal(err)
+		}
+		if got := spec.schemaType(node.Content[0]); got != tt.want {
+			t.Errorf("schemaType(%s) = %q, want %q", tt.schema, got, tt.want)
+		}
+	}
+}
+
+func TestOpenAPIOperations(t *testing.T) {
+	spec := parseOpenAPITest(t, `openapi: 3.0.0
+security: [{apiKey: []}]
+paths:
+  /pets/{id}:
+    parameters:
+      - {name: id, in: path, required: true, description: shared, schema: {type: string}}
+      - $ref: '#/components/parameters/Limit'
+    get:
+      summary: Get a pet
+      description: Looks it up
+      deprecated: true
+      security: [{oauth: [read, write]}]
+      parameters:
+        - {name: id, in: path, required: true, description: overridden, schema: {type: integer}}
+      responses:
+        "200": {$ref: '#/components/responses/Pet'}
+        "404": {description: Not found}
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json: {schema: {$ref: '#/components/schemas/Pet'}}
+      responses: {}
+components:
+  parameters:
+    Limit: {name: limit, in: query, schema: {type: integer, default: 20}}
+  responses:
+    Pet:
+      description: The pet
+      content:
+        application/json: {schema: {$ref: '#/components/schemas/Pet'}}
+  schemas:
+    Pet: {properties: {name: {type: string}}}
+`)
+	ops := spec.operations()
+	if len(ops) != 2 {
+		t.Fatalf("got %d operations", len(ops))
+	}
+
+	get := ops[0].fn
+	wantParams := []Parameter{
+		{Name: "id", In: "path", Required: true, Type: "integer", Description: "overridden"},
+		{Name: "limit", In: "query", Type: "integer", Default: "20"},
+	}
+	if get.Name != "GET /pets/{id}" || get.Doc.Summary != "Get a pet\n\nLooks it up" || !reflect.DeepEqual(get.Modifiers, []string{"deprecated"}) {
+		t.Errorf("get = %+v", get)
+	}
+	if !reflect.DeepEqual(get.Parameters, wantParams) {
+		t.Errorf("params = %+v, want %+v", get.Parameters, wantParams)
+	}
+	wantResults := []Parameter{{Name: "200", Type: "Pet", Description: "The pet"}, {Name: "404", Description: "Not found"}}
+	if !reflect.DeepEqual(get.Results, wantResults) {
+		t.Errorf("results = %+v, want %+v", get.Results, wantResults)
+	}
+	if !reflect.DeepEqual(get.Security, []string{"oauth (read, write)"}) {
+		t.Errorf("security = %v", get.Security)
+	}
+
+	post := ops[1].fn
+	body := post.Parameters[len(post.Parameters)-1]
+	if body.In != "body" || body.Type != "Pet" || !body.Required || body.Description != "(application/json)" {
+		t.Errorf("body = %+v", body)
+	}
+	if !reflect.DeepEqual(post.Security, []string{"apiKey"}) {
+		t.Errorf("post security = %v, want the global requirement", post.Security)
+	}
+}
+
+func TestSwagger2(t *testing.T) {
+	spec := parseOpenAPITest(t, `swagger: "2.0"
+host: api.example.com
+basePath: /v1
+paths:
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, type: integer, default: 10}
+      responses:
+        "200": {description: ok, schema: {type: array, items: {$ref: '#/definitions/Pet'}}}
+definitions:
+  Pet: {required: [name], properties: {name: {type: string}}}
+`)
+	info := spec.infoStruct("Pets", nil)
+	var server string
+	for _, f := range info.Fields {
+		if f.Name == "server" {
+			server = f.Value
+		}
+	}
+	if server != "api.example.com/v1" {
+		t.Errorf("server = %q", server)
+	}
+
+	get := spec.operations()[0].fn
+	if get.Parameters[0].Type != "integer" || get.Parameters[0].Default != "10" || get.Results[0].Type != "array[Pet]" {
+		t.Errorf("get = %+v", get)
+	}
+
+	pet := findStruct(spec.schemas(), "Pet")
+	if pet == nil || !reflect.DeepEqual(pet.Fields[0].Modifiers, []string{"required"}) {
+		t.Errorf("Pet = %+v", pet)
+	}
+}
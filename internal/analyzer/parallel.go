@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// analyzeFiles runs analyze over paths on a bounded pool of workers. Results
+// keep the order of paths so generated docs stay stable, and the first error
+// cancels the files that have not been started yet. Files unchanged since the
+// previous run are reused instead of analyzed again. analyze returns a nil
+// file for a path it turns out not to own; such paths are dropped.
+func analyzeFiles(ctx context.Context, paths []string, opts Options, analyze func(path string) (*AnalyzedFile, error)) ([]*AnalyzedFile, error) {
+	previous := previousFiles(opts.Previous)
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*AnalyzedFile, len(paths))
+	jobs := make(chan int)
+	errChan := make(chan error, 1)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workerCount(opts.Workers, len(paths)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if runCtx.Err() != nil {
+					continue
+				}
+				if prev, ok := previous[paths[i]]; ok && unchanged(prev) {
+					prev.Reused = true
+					results[i] = prev
+					continue
+				}
+				file, err := analyze(paths[i])
+				if err != nil {
+					select {
+					case errChan <- err:
+					default:
+					}
+					cancel()
+					continue
+				}
+				results[i] = file
+			}
+		}()
+	}
+
+feed:
+	for i := range paths {
+		select {
+		case jobs <- i:
+		case <-runCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(errChan)
+
+	if err := <-errChan; err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	files := results[:0]
+	for _, file := range results {
+		if file != nil {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// workerCount defaults to one worker per CPU and never exceeds the number of jobs
+func workerCount(workers, jobs int) int {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > jobs {
+		workers = jobs
+	}
+	return workers
+}
@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeFilesKeepsOrder(t *testing.T) {
+	var paths []string
+	for i := 0; i < 40; i++ {
+		paths = append(paths, fmt.Sprintf("file%02d", i))
+	}
+
+	// Later files finish first, and every seventh file is not owned by the analyzer
+	analyze := func(path string) (*AnalyzedFile, error) {
+		var i int
+		fmt.Sscanf(path, "file%d", &i)
+		time.Sleep(time.Duration(len(paths)-i) * 100 * time.Microsecond)
+		if i%7 == 0 {
+			return nil, nil
+		}
+		return &AnalyzedFile{Path: path}, nil
+	}
+	var want []string
+	for i, path := range paths {
+		if i%7 != 0 {
+			want = append(want, path)
+		}
+	}
+
+	for _, workers := range []int{0, 1, 2, 8, 100} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			files, err := analyzeFiles(context.Background(), paths, Options{Workers: workers}, analyze)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range files {
+				got = append(got, f.Path)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("files = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFilesStopsAtFirstError(t *testing.T) {
+	paths := make([]string, 100)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("file%02d", i)
+	}
+	errFirst := errors.New("first")
+	errLater := errors.New("later")
+
+	t.Run("one worker", func(t *testing.T) {
+		var calls []string
+		_, err := analyzeFiles(context.Background(), paths, Options{Workers: 1}, func(path string) (*AnalyzedFile, error) {
+			calls = append(calls, path)
+			switch path {
+			case "file03":
+				return nil, errFirst
+			case "file05":
+				return nil, errLater
+			}
+			return &AnalyzedFile{Path: path}, nil
+		})
+		if err != errFirst {
+			t.Errorf("err = %v, want %v", err, errFirst)
+		}
+		if want := paths[:4]; !reflect.DeepEqual(calls, want) {
+			t.Errorf("analyzed %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("many workers", func(t *testing.T) {
+		var calls atomic.Int32
+		_, err := analyzeFiles(context.Background(), paths, Options{Workers: 4}, func(path string) (*AnalyzedFile, error) {
+			calls.Add(1)
+			if path == "file00" {
+				return nil, errFirst
+			}
+			time.Sleep(time.Millisecond)
+			return &AnalyzedFile{Path: path}, nil
+		})
+		if err != errFirst {
+			t.Errorf("err = %v, want %v", err, errFirst)
+		}
+		if n := calls.Load(); n >= int32(len(paths)) {
+			t.Errorf("analyzed all %d files after the first error", n)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := analyzeFiles(ctx, paths, Options{Workers: 2}, func(path string) (*AnalyzedFile, error) {
+			return &AnalyzedFile{Path: path}, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want %v", err, context.Canceled)
+		}
+	})
+}
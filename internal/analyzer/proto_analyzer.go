@@ -0,0 +1,55 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProtoAnalyzer documents Protocol Buffers definitions. Messages become
+// structs, enums enums, and gRPC services interfaces whose RPCs are also
+// listed as functions so they get documented individually.
+type ProtoAnalyzer struct{}
+
+func (p *ProtoAnalyzer) Supports(projectDir string, opts Options) bool {
+	return hasFiles(projectDir, opts, ".proto")
+}
+
+func (p *ProtoAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	paths, err := walkFiles(projectDir, opts, ".proto")
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := analyzeFiles(ctx, paths, opts, p.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyzerResult{Files: files}, nil
+}
+
+// analyzeFile extracts the messages, enums and services of a .proto file.
+// Files declaring the same package share its page.
+func (p *ProtoAnalyzer) analyzeFile(path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := Package{Path: path}
+	if err := parseProto(string(content), &pkg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if pkg.Name != "" {
+		pkg.ImportPath = "proto/" + strings.ReplaceAll(pkg.Name, ".", "/")
+	} else {
+		pkg.Name = strings.TrimSuffix(filepath.Base(path), ".proto")
+	}
+
+	return &AnalyzedFile{
+		Path:     path,
+		Packages: []Package{pkg},
+	}, nil
+}
@@ -0,0 +1,527 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+type protoTokenKind int
+
+const (
+	protoIdent protoTokenKind = iota
+	protoNumber
+	protoString
+	protoPunct
+	protoEOF
+)
+
+// protoToken is one protobuf token. Doc holds the comment lines directly
+// above the token when it starts a line, Trailing the comment following it
+// on the same line.
+type protoToken struct {
+	Kind     protoTokenKind
+	Text     string
+	Line     int
+	Doc      string
+	Trailing string
+}
+
+// lexProto tokenizes a .proto file, attaching comments the way protoc does:
+// leading comments to the next token, trailing ones to the previous token
+func lexProto(src string) ([]protoToken, error) {
+	var toks []protoToken
+	var doc []string
+	line := 1
+	lineHasToken := false
+	lineHasComment := false
+	i := 0
+
+	emit := func(kind protoTokenKind, start, end int) {
+		tok := protoToken{Kind: kind, Text: src[start:end], Line: line}
+		if !lineHasToken {
+			tok.Doc = strings.Join(doc, "\n")
+		}
+		toks = append(toks, tok)
+		doc = nil
+		lineHasToken = true
+	}
+	comment := func(text string) {
+		text = strings.TrimSpace(text)
+		if lineHasToken {
+			if last := &toks[len(toks)-1]; last.Line == line && last.Trailing == "" {
+				last.Trailing = text
+			}
+			return
+		}
+		doc = append(doc, text)
+		lineHasComment = true
+	}
+
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\n':
+			if !lineHasToken && !lineHasComment {
+				// A blank line detaches comments from what follows
+				doc = nil
+			}
+			line++
+			lineHasToken, lineHasComment = false, false
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+
+		case strings.HasPrefix(src[i:], "//"):
+			start := i + 2
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			comment(src[start:i])
+
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			var lines []string
+			for _, l := range strings.Split(src[i+2:i+2+end], "\n") {
+				lines = append(lines, strings.TrimPrefix(strings.TrimSpace(l), "*"))
+			}
+			comment(strings.Join(lines, "\n"))
+			line += len(lines) - 1
+			i += end + 4
+
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && src[j] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			emit(protoString, i, j+1)
+			i = j + 1
+
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(src) && (isProtoIdentChar(src[i]) || src[i] == '.' ||
+				(src[i] == '+' || src[i] == '-') && (src[i-1] == 'e' || src[i-1] == 'E')) {
+				i++
+			}
+			emit(protoNumber, start, i)
+
+		case isProtoIdentChar(c) || c == '.' && i+1 < len(src) && isProtoIdentChar(src[i+1]):
+			// Identifiers include dotted names such as google.protobuf.Timestamp
+			start := i
+			for i < len(src) && (isProtoIdentChar(src[i]) || src[i] == '.') {
+				i++
+			}
+			emit(protoIdent, start, i)
+
+		default:
+			emit(protoPunct, i, i+1)
+			i++
+		}
+	}
+
+	toks = append(toks, protoToken{Kind: protoEOF, Line: line})
+	return toks, nil
+}
+
+func isProtoIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+type protoParser struct {
+	toks []protoToken
+	pos  int
+	pkg  *Package
+}
+
+// parseProto parses a .proto file into pkg: messages become structs, enums
+// enums, services interfaces and their RPCs functions with the service as
+// receiver. Nested types are named after their parent, e.g. "User.Address".
+func parseProto(src string, pkg *Package) error {
+	toks, err := lexProto(src)
+	if err != nil {
+		return err
+	}
+	p := &protoParser{toks: toks, pkg: pkg}
+
+	for p.peek().Kind != protoEOF {
+		tok := p.next()
+		switch {
+		case tok.Kind == protoIdent && tok.Text == "package":
+			pkg.Name = p.next().Text
+			p.skipStatement()
+		case tok.Kind == protoIdent && tok.Text == "import":
+			for p.peek().Kind == protoIdent {
+				p.next() // public, weak
+			}
+			pkg.Imports = append(pkg.Imports, strings.Trim(p.next().Text, `"'`))
+			p.skipStatement()
+		case tok.Kind == protoIdent && tok.Text == "message":
+			if err := p.parseMessage("", tok.Doc); err != nil {
+				return err
+			}
+		case tok.Kind == protoIdent && tok.Text == "enum":
+			if err := p.parseEnum("", tok.Doc); err != nil {
+				return err
+			}
+		case tok.Kind == protoIdent && tok.Text == "service":
+			if err := p.parseService(tok.Doc); err != nil {
+				return err
+			}
+		case tok.Kind == protoPunct && tok.Text == ";":
+		default:
+			// syntax, edition, option, extend
+			p.skipStatement()
+		}
+	}
+	return nil
+}
+
+func (p *protoParser) peek() protoToken {
+	return p.toks[p.pos]
+}
+
+func (p *protoParser) next() protoToken {
+	tok := p.toks[p.pos]
+	if tok.Kind != protoEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *protoParser) is(text string) bool {
+	tok := p.peek()
+	return tok.Kind == protoPunct && tok.Text == text
+}
+
+func (p *protoParser) expect(text string) error {
+	tok := p.next()
+	if tok.Kind != protoPunct || tok.Text != text {
+		return fmt.Errorf("line %d: expected %q, found %q", tok.Line, text, tok.Text)
+	}
+	return nil
+}
+
+// skipStatement skips to the end of the current statement: a ";" or a
+// balanced "{...}" block
+func (p *protoParser) skipStatement() {
+	depth := 0
+	for {
+		tok := p.next()
+		if tok.Kind == protoEOF {
+			return
+		}
+		if tok.Kind != protoPunct {
+			continue
+		}
+		switch tok.Text {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth <= 0 {
+				return
+			}
+		case ";":
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// parseMessage parses a message after its keyword. The struct is added
+// before any nested types so parents come first.
+func (p *protoParser) parseMessage(prefix, doc string) error {
+	name := prefix + p.next().Text
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	index := len(p.pkg.Structs)
+	p.pkg.Structs = append(p.pkg.Structs, Struct{Name: name, Doc: ai.Documentation{Summary: doc}})
+
+	var fields []Field
+	for !p.is("}") {
+		tok := p.peek()
+		if tok.Kind == protoEOF {
+			return fmt.Errorf("line %d: unexpected end of file in message %s", tok.Line, name)
+		}
+		switch {
+		case tok.Kind == protoPunct && tok.Text == ";":
+			p.next()
+		case tok.Kind == protoIdent && tok.Text == "message":
+			p.next()
+			if err := p.parseMessage(name+".", tok.Doc); err != nil {
+				return err
+			}
+		case tok.Kind == protoIdent && tok.Text == "enum":
+			p.next()
+			if err := p.parseEnum(name+".", tok.Doc); err != nil {
+				return err
+			}
+		case tok.Kind == protoIdent && tok.Text == "oneof":
+			p.next()
+			field, err := p.parseOneof(tok.Doc)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, field)
+		case tok.Kind == protoIdent && (tok.Text == "option" || tok.Text == "reserved" ||
+			tok.Text == "extensions" || tok.Text == "extend"):
+			p.skipStatement()
+		default:
+			field, err := p.parseField()
+			if err != nil {
+				return err
+			}
+			fields = append(fields, field)
+		}
+	}
+	p.next() // }
+	p.pkg.Structs[index].Fields = fields
+	return nil
+}
+
+// parseField parses "[label] type name = number [options];", including map
+// fields
+func (p *protoParser) parseField() (Field, error) {
+	first := p.peek()
+	var field Field
+	typ := p.next().Text
+	switch typ {
+	case "repeated", "optional", "required":
+		field.Modifiers = []string{typ}
+		typ = p.next().Text
+	}
+	if typ == "map" && p.is("<") {
+		p.next()
+		key := p.next().Text
+		if err := p.expect(","); err != nil {
+			return field, err
+		}
+		value := p.next().Text
+		if err := p.expect(">"); err != nil {
+			return field, err
+		}
+		typ = fmt.Sprintf("map<%s, %s>", key, value)
+	}
+	field.Type = typ
+
+	name := p.next()
+	if name.Kind != protoIdent {
+		return field, fmt.Errorf("line %d: expected field name, found %q", name.Line, name.Text)
+	}
+	field.Name = name.Text
+	if err := p.expect("="); err != nil {
+		return field, err
+	}
+	field.Value = p.next().Text
+	if p.is("[") {
+		field.Tag = p.collectOptions()
+	}
+	if typ == "group" || p.is("{") {
+		// proto2 groups carry their own body
+		p.skipStatement()
+	} else if err := p.expect(";"); err != nil {
+		return field, err
+	}
+	field.Doc = ai.Documentation{Summary: p.commentOf(first)}
+	return field, nil
+}
+
+// parseOneof parses a oneof into a field whose members are its alternatives
+func (p *protoParser) parseOneof(doc string) (Field, error) {
+	field := Field{Name: p.next().Text, Type: "oneof", Doc: ai.Documentation{Summary: doc}}
+	if err := p.expect("{"); err != nil {
+		return field, err
+	}
+	for !p.is("}") {
+		tok := p.peek()
+		switch {
+		case tok.Kind == protoEOF:
+			return field, fmt.Errorf("line %d: unexpected end of file in oneof %s", tok.Line, field.Name)
+		case tok.Kind == protoPunct && tok.Text == ";":
+			p.next()
+		case tok.Kind == protoIdent && tok.Text == "option":
+			p.skipStatement()
+		default:
+			member, err := p.parseField()
+			if err != nil {
+				return field, err
+			}
+			field.Fields = append(field.Fields, member)
+		}
+	}
+	p.next() // }
+	return field, nil
+}
+
+// parseEnum parses an enum after its keyword
+func (p *protoParser) parseEnum(prefix, doc string) error {
+	enum := Enum{Name: prefix + p.next().Text, Doc: ai.Documentation{Summary: doc}}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.is("}") {
+		tok := p.peek()
+		switch {
+		case tok.Kind == protoEOF:
+			return fmt.Errorf("line %d: unexpected end of file in enum %s", tok.Line, enum.Name)
+		case tok.Kind == protoPunct && tok.Text == ";":
+			p.next()
+		case tok.Kind == protoIdent && (tok.Text == "option" || tok.Text == "reserved"):
+			p.skipStatement()
+		default:
+			p.next()
+			value := Value{Name: tok.Text}
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			if p.is("-") {
+				value.Value = "-"
+				p.next()
+			}
+			value.Value += p.next().Text
+			if p.is("[") {
+				p.collectOptions()
+			}
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+			value.Doc = ai.Documentation{Summary: p.commentOf(tok)}
+			enum.Members = append(enum.Members, value)
+		}
+	}
+	p.next() // }
+	p.pkg.Enums = append(p.pkg.Enums, enum)
+	return nil
+}
+
+// parseService parses a service after its keyword. Its RPCs are recorded
+// both as the service's methods and as package functions.
+func (p *protoParser) parseService(doc string) error {
+	service := Interface{Name: p.next().Text, Exported: true, Doc: ai.Documentation{Summary: doc}}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.is("}") {
+		tok := p.peek()
+		switch {
+		case tok.Kind == protoEOF:
+			return fmt.Errorf("line %d: unexpected end of file in service %s", tok.Line, service.Name)
+		case tok.Kind == protoPunct && tok.Text == ";":
+			p.next()
+		case tok.Kind == protoIdent && tok.Text == "rpc":
+			p.next()
+			rpc, err := p.parseRPC(service.Name, tok.Doc)
+			if err != nil {
+				return err
+			}
+			service.Methods = append(service.Methods, rpc)
+			p.pkg.Funcs = append(p.pkg.Funcs, rpc)
+		default:
+			p.skipStatement()
+		}
+	}
+	p.next() // }
+	p.pkg.Interfaces = append(p.pkg.Interfaces, service)
+	return nil
+}
+
+// parseRPC parses "Name (stream Req) returns (stream Resp)" followed by ";"
+// or an options block
+func (p *protoParser) parseRPC(service, doc string) (Function, error) {
+	fn := Function{Name: p.next().Text, Receiver: service, Exported: true, Doc: ai.Documentation{Summary: doc}}
+
+	request, clientStream, err := p.parseRPCType()
+	if err != nil {
+		return fn, err
+	}
+	if returns := p.next(); returns.Text != "returns" {
+		return fn, fmt.Errorf("line %d: expected \"returns\", found %q", returns.Line, returns.Text)
+	}
+	response, serverStream, err := p.parseRPCType()
+	if err != nil {
+		return fn, err
+	}
+	fn.Parameters = []Parameter{{Type: request}}
+	fn.Results = []Parameter{{Type: response}}
+
+	switch {
+	case clientStream && serverStream:
+		fn.Modifiers = []string{"bidi-streaming"}
+	case clientStream:
+		fn.Modifiers = []string{"client-streaming"}
+	case serverStream:
+		fn.Modifiers = []string{"server-streaming"}
+	}
+
+	if p.is("{") {
+		p.skipStatement()
+	} else if err := p.expect(";"); err != nil {
+		return fn, err
+	}
+	return fn, nil
+}
+
+// parseRPCType parses "(stream Type)", returning the type as written
+func (p *protoParser) parseRPCType() (string, bool, error) {
+	if err := p.expect("("); err != nil {
+		return "", false, err
+	}
+	typ := p.next().Text
+	stream := false
+	if typ == "stream" && p.peek().Kind == protoIdent {
+		stream = true
+		typ = "stream " + p.next().Text
+	}
+	return typ, stream, p.expect(")")
+}
+
+// collectOptions returns the source text of a "[...]" options list
+func (p *protoParser) collectOptions() string {
+	var parts []string
+	depth := 0
+	for {
+		tok := p.next()
+		if tok.Kind == protoEOF {
+			break
+		}
+		parts = append(parts, tok.Text)
+		if tok.Kind == protoPunct && tok.Text == "[" {
+			depth++
+		} else if tok.Kind == protoPunct && tok.Text == "]" {
+			depth--
+			if depth == 0 {
+				break
+			}
+		}
+	}
+	text := strings.Join(parts, " ")
+	for _, r := range []struct{ from, to string }{{"[ ", "["}, {" ]", "]"}, {" ,", ","}, {" . ", "."}, {"( ", "("}, {" )", ")"}, {") .", ")."}} {
+		text = strings.ReplaceAll(text, r.from, r.to)
+	}
+	return text
+}
+
+// commentOf returns the leading comment of a statement starting at first,
+// falling back to the trailing comment after the ";" just consumed
+func (p *protoParser) commentOf(first protoToken) string {
+	if first.Doc != "" {
+		return first.Doc
+	}
+	return p.toks[p.pos-1].Trailing
+}
@@ -0,0 +1,186 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func parseProtoTest(t *testing.T, src string) *Package {
+	t.Helper()
+	pkg := &Package{}
+	if err := parseProto(src, pkg); err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestProtoFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		field     string
+		typ       string
+		value     string
+		tag       string
+		modifiers []string
+	}{
+		{name: "scalar", src: "string id = 1;", field: "id", typ: "string", value: "1"},
+		{name: "repeated", src: "repeated int64 ids = 2;", field: "ids", typ: "int64", value: "2", modifiers: []string{"repeated"}},
+		{name: "qualified type", src: "optional .acme.v1.Ref ref = 3;", field: "ref", typ: ".acme.v1.Ref", value: "3", modifiers: []string{"optional"}},
+		{name: "map", src: "map<string, Labels> labels = 4;", field: "labels", typ: "map<string, Labels>", value: "4"},
+		{name: "options", src: `string phone = 5 [json_name = "tel"];`, field: "phone", typ: "string", value: "5", tag: `[json_name = "tel"]`},
+		{
+			name:  "extension options",
+			src:   "repeated string tags = 6 [deprecated = true, (validate.rules).repeated.min_items = 1];",
+			field: "tags", typ: "string", value: "6",
+			tag:       "[deprecated = true, (validate.rules).repeated.min_items = 1]",
+			modifiers: []string{"repeated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := parseProtoTest(t, "message M {\n  "+tt.src+"\n}\n")
+			if len(pkg.Structs) != 1 || len(pkg.Structs[0].Fields) != 1 {
+				t.Fatalf("structs = %+v", pkg.Structs)
+			}
+			f := pkg.Structs[0].Fields[0]
+			if f.Name != tt.field || f.Type != tt.typ || f.Value != tt.value || f.Tag != tt.tag {
+				t.Errorf("got %s %s = %s %s, want %s %s = %s %s", f.Type, f.Name, f.Value, f.Tag, tt.typ, tt.field, tt.value, tt.tag)
+			}
+			if !reflect.DeepEqual(f.Modifiers, tt.modifiers) {
+				t.Errorf("modifiers = %v, want %v", f.Modifiers, tt.modifiers)
+			}
+		})
+	}
+}
+
+func TestProtoDeclarations(t *testing.T) {
+	pkg := parseProtoTest(t, `syntax = "proto3";
+package acme.v1;
+
+import "google/protobuf/timestamp.proto";
+import public "other.proto";
+option go_package = "example.com/acme";
+
+// A user account
+message User {
+  option deprecated = true;
+  reserved 8, 9 to 11;
+  reserved "legacy";
+
+  string name = 1; // Display name
+
+  // Where they live
+  message Address {
+    string city = 1;
+  }
+
+  enum Role {
+    option allow_alias = true;
+    ROLE_UNSPECIFIED = 0;
+    // Full access
+    ROLE_ADMIN = 1;
+    ROLE_ROOT = 1 [deprecated = true];
+    ROLE_NEGATIVE = -1;
+  }
+
+  oneof contact {
+    string email = 2;
+    string phone = 3;
+  }
+}
+
+// Manages users
+service Users {
+  option (acme.service) = "users";
+
+  rpc Get(GetRequest) returns (User);
+  rpc Watch(WatchRequest) returns (stream User) {
+    option (google.api.http) = { get: "/v1/users:watch" };
+  }
+  rpc Upload(stream Chunk) returns (Summary) {}
+  rpc Chat(stream Message) returns (stream Message);
+}
+`)
+
+	if pkg.Name != "acme.v1" {
+		t.Errorf("package = %q", pkg.Name)
+	}
+	if want := []string{"google/protobuf/timestamp.proto", "other.proto"}; !reflect.DeepEqual(pkg.Imports, want) {
+		t.Errorf("imports = %v, want %v", pkg.Imports, want)
+	}
+
+	var structs []string
+	for _, s := range pkg.Structs {
+		structs = append(structs, s.Name)
+	}
+	if want := []string{"User", "User.Address"}; !reflect.DeepEqual(structs, want) {
+		t.Fatalf("structs = %v, want %v", structs, want)
+	}
+	user := pkg.Structs[0]
+	if user.Doc.Summary != "A user account" || pkg.Structs[1].Doc.Summary != "Where they live" {
+		t.Errorf("struct docs = %q, %q", user.Doc.Summary, pkg.Structs[1].Doc.Summary)
+	}
+	if len(user.Fields) != 2 || user.Fields[0].Doc.Summary != "Display name" {
+		t.Fatalf("User fields = %+v", user.Fields)
+	}
+	contact := user.Fields[1]
+	if contact.Name != "contact" || contact.Type != "oneof" || len(contact.Fields) != 2 || contact.Fields[1].Name != "phone" {
+		t.Errorf("oneof = %+v", contact)
+	}
+
+	if len(pkg.Enums) != 1 || pkg.Enums[0].Name != "User.Role" {
+		t.Fatalf("enums = %+v", pkg.Enums)
+	}
+	wantMembers := []Value{
+		{Name: "ROLE_UNSPECIFIED", Value: "0"},
+		{Name: "ROLE_ADMIN", Value: "1"},
+		{Name: "ROLE_ROOT", Value: "1"},
+		{Name: "ROLE_NEGATIVE", Value: "-1"},
+	}
+	wantMembers[1].Doc.Summary = "Full access"
+	if !reflect.DeepEqual(pkg.Enums[0].Members, wantMembers) {
+		t.Errorf("members = %+v, want %+v", pkg.Enums[0].Members, wantMembers)
+	}
+
+	if len(pkg.Interfaces) != 1 || pkg.Interfaces[0].Doc.Summary != "Manages users" {
+		t.Fatalf("services = %+v", pkg.Interfaces)
+	}
+	tests := []struct {
+		name      string
+		request   string
+		response  string
+		modifiers []string
+	}{
+		{"Get", "GetRequest", "User", nil},
+		{"Watch", "WatchRequest", "stream User", []string{"server-streaming"}},
+		{"Upload", "stream Chunk", "Summary", []string{"client-streaming"}},
+		{"Chat", "stream Message", "stream Message", []string{"bidi-streaming"}},
+	}
+	methods := pkg.Interfaces[0].Methods
+	if len(methods) != len(tests) || len(pkg.Funcs) != len(tests) {
+		t.Fatalf("methods = %v, funcs = %v", funcNames(methods), funcNames(pkg.Funcs))
+	}
+	for i, tt := range tests {
+		fn := methods[i]
+		if fn.Name != tt.name || fn.Receiver != "Users" || fn.Parameters[0].Type != tt.request || fn.Results[0].Type != tt.response {
+			t.Errorf("rpc %d = %+v", i, fn)
+		}
+		if !reflect.DeepEqual(fn.Modifiers, tt.modifiers) {
+			t.Errorf("%s modifiers = %v, want %v", tt.name, fn.Modifiers, tt.modifiers)
+		}
+	}
+}
+
+func TestProtoErrors(t *testing.T) {
+	tests := map[string]string{
+		"unterminated message": "message M {\n  string a = 1;\n",
+		"missing number":       "message M { string a; }",
+		"missing returns":      "service S { rpc Get(A) (B); }",
+	}
+	for name, src := range tests {
+		if err := parseProto(src, &Package{}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,600 @@
+package analyzer
+
+import (
+	"strings"
+
+	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
+)
+
+// pyParser walks the token stream of one Python module. It only parses what
+// the docs need: classes, functions, their signatures, docstrings and
+// attributes. Every other statement is skipped a logical line at a time.
+type pyParser struct {
+	src  string
+	toks []pyToken
+	pos  int
+}
+
+// pyScope collects the declarations of one block (module, class or function body)
+type pyScope struct {
+	funcs   []Function
+	classes []Struct
+	fields  []Field  // class attributes, or self.x assignments in a method
+	calls   []string // names called in a function body
+	self    string   // name of the instance parameter in methods
+}
+
+// parsePython extracts the classes and functions of a Python module. Classes
+// nested in a class are flattened with qualified names such as "Outer.Inner";
+// functions and classes defined inside a function body are local and skipped.
+func parsePython(src string) ([]Struct, []Function, error) {
+	toks, err := tokenizePython(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &pyParser{src: src, toks: toks}
+	module := &pyScope{}
+	p.parseBlock(module, false)
+
+	// Only calls between functions of this module are worth documenting
+	known := make(map[string]bool)
+	for _, fn := range module.funcs {
+		known[fn.Name] = true
+	}
+	for i := range module.funcs {
+		module.funcs[i].Calls = filterCalls(module.funcs[i].Calls, known, module.funcs[i].Name)
+	}
+	for ci := range module.classes {
+		for mi := range module.classes[ci].Methods {
+			m := &module.classes[ci].Methods[mi]
+			m.Calls = filterCalls(m.Calls, known, "")
+		}
+	}
+
+	return module.classes, module.funcs, nil
+}
+
+func (p *pyParser) peek() pyToken {
+	return p.toks[p.pos]
+}
+
+func (p *pyParser) next() pyToken {
+	tok := p.toks[p.pos]
+	if tok.Kind != pyEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *pyParser) isOp(text string) bool {
+	tok := p.peek()
+	return tok.Kind == pyOp && tok.Text == text
+}
+
+func (p *pyParser) isName(text string) bool {
+	tok := p.peek()
+	return tok.Kind == pyName && tok.Text == text
+}
+
+// parseBlock parses statements until the end of the current indented block
+func (p *pyParser) parseBlock(scope *pyScope, inClass bool) {
+	for {
+		switch p.peek().Kind {
+		case pyEOF:
+			return
+		case pyDedent:
+			p.next()
+			return
+		case pyNewline:
+			p.next()
+			continue
+		case pyIndent:
+			// Unexpected indentation; parse it as a block so its DEDENT
+			// does not end this one
+			p.next()
+			p.parseBlock(scope, inClass)
+			continue
+		}
+
+		var decorators []string
+		for p.isOp("@") {
+			p.next()
+			decorators = append(decorators, p.textUntilNewline())
+		}
+
+		async := false
+		if p.isName("async") && p.toks[p.pos+1].Kind == pyName && p.toks[p.pos+1].Text == "def" {
+			p.next()
+			async = true
+		}
+
+		switch {
+		case p.isName("def"):
+			p.parseDef(scope, decorators, async, inClass)
+		case p.isName("class"):
+			p.parseClass(scope, decorators)
+		default:
+			p.parseStatement(scope, inClass)
+		}
+	}
+}
+
+// parseDef parses a function definition and its body
+func (p *pyParser) parseDef(scope *pyScope, decorators []string, async, inClass bool) {
+	p.next() // def
+	if p.peek().Kind != pyName {
+		p.skipLine()
+		return
+	}
+	fn := Function{
+		Name:       p.next().Text,
+		Decorators: decorators,
+		Async:      async,
+	}
+
+	if p.isOp("(") {
+		fn.Parameters = p.parseParams(p.bracketed())
+	}
+	if p.isOp("->") {
+		p.next()
+		if ret := p.textUntil(":"); ret != "" {
+			fn.Results = []Parameter{{Type: ret}}
+		}
+	}
+
+	body := &pyScope{}
+	if inClass && len(fn.Parameters) > 0 && !hasDecorator(decorators, "staticmethod") {
+		body.self = fn.Parameters[0].Name
+	}
+	fn.Doc = ai.Documentation{Summary: p.parseSuite(body, false)}
+	fn.Calls = body.calls
+
+	if inClass {
+		scope.fields = appendFields(scope.fields, body.fields...)
+	}
+	scope.funcs = append(scope.funcs, fn)
+}
+
+// parseClass parses a class definition, its attributes and its methods
+func (p *pyParser) parseClass(scope *pyScope, decorators []string) {
+	p.next() // class
+	if p.peek().Kind != pyName {
+		p.skipLine()
+		return
+	}
+	s := Struct{
+		Name:       p.next().Text,
+		Decorators: decorators,
+		Fields:     []Field{},
+		Methods:    []Function{},
+	}
+
+	if p.isOp("(") {
+		for _, arg := range splitTopLevel(p.bracketed()) {
+			if !containsOp(arg, "=") {
+				// Keyword arguments such as metaclass= are not bases
+				s.Bases = append(s.Bases, p.text(arg))
+			}
+		}
+	}
+	if p.isOp(":") {
+		p.next()
+	}
+
+	body := &pyScope{}
+	s.Doc = ai.Documentation{Summary: p.parseSuite(body, true)}
+	s.Fields = appendFields(s.Fields, body.fields...)
+
+	for _, fn := range body.funcs {
+		fn.Receiver = s.Name
+		s.Methods = append(s.Methods, fn)
+	}
+	scope.classes = append(scope.classes, s)
+
+	// Classes nested in the class body keep the outer name as a prefix
+	for _, nested := range body.classes {
+		nested.Name = s.Name + "." + nested.Name
+		scope.classes = append(scope.classes, nested)
+	}
+}
+
+// parseSuite parses the body after a ":" and returns its docstring
+func (p *pyParser) parseSuite(body *pyScope, inClass bool) string {
+	if p.isOp(":") {
+		p.next()
+	}
+
+	if p.peek().Kind != pyNewline {
+		// Single-line body, e.g. "def f(): return 1"
+		if doc := p.docstring(); doc != "" {
+			return doc
+		}
+		p.parseStatement(body, inClass)
+		return ""
+	}
+
+	p.next() // NEWLINE
+	if p.peek().Kind != pyIndent {
+		return ""
+	}
+	p.next()
+
+	doc := p.docstring()
+	p.parseBlock(body, inClass)
+	return doc
+}
+
+// docstring returns the cleaned text of a string-only first statement
+func (p *pyParser) docstring() string {
+	start := p.pos
+	var parts []string
+	for p.peek().Kind == pyString {
+		parts = append(parts, pyStringValue(p.next().Text))
+	}
+	if len(parts) == 0 || p.peek().Kind != pyNewline {
+		p.pos = start
+		return ""
+	}
+	p.next()
+	return cleanDocstring(strings.Join(parts, ""))
+}
+
+// parseStatement handles one simple or compound statement. Class attributes,
+// self.x assignments and calls are recorded; blocks of compound statements
+// (if, for, try, with...) are searched for nested definitions.
+func (p *pyParser) parseStatement(scope *pyScope, inClass bool) {
+	start := p.pos
+	p.skipLine()
+	line := p.toks[start:p.pos]
+	if len(line) > 0 && line[len(line)-1].Kind == pyNewline {
+		line = line[:len(line)-1]
+	}
+
+	switch {
+	case inClass:
+		if field, ok := p.assignment(line, ""); ok {
+			scope.fields = appendFields(scope.fields, field)
+		}
+	case scope.self != "":
+		if field, ok := p.assignment(line, scope.self); ok {
+			scope.fields = appendFields(scope.fields, field)
+		}
+	}
+
+	for i := 0; i+1 < len(line); i++ {
+		if line[i].Kind == pyName && line[i+1].Kind == pyOp && line[i+1].Text == "(" &&
+			(i == 0 || line[i-1].Text != ".") {
+			scope.calls = append(scope.calls, line[i].Text)
+		}
+	}
+
+	// Compound statement with an indented block
+	if len(line) > 0 && line[len(line)-1].Text == ":" && p.peek().Kind == pyIndent {
+		p.next()
+		p.parseBlock(scope, inClass)
+	}
+}
+
+// assignment recognizes "name: T = v", "name = v" and, when self is set,
+// "self.name: T = v" / "self.name = v"
+func (p *pyParser) assignment(line []pyToken, self string) (Field, bool) {
+	if self != "" {
+		if len(line) < 3 || line[0].Text != self || line[1].Text != "." {
+			return Field{}, false
+		}
+		line = line[2:]
+	}
+	if len(line) < 2 || line[0].Kind != pyName || isPyKeyword(line[0].Text) {
+		return Field{}, false
+	}
+
+	field := Field{Name: line[0].Text}
+	rest := line[1:]
+	switch {
+	case rest[0].Kind == pyOp && rest[0].Text == ":":
+		ann, value := splitAt(rest[1:], "=")
+		field.Type = p.text(ann)
+		if value != nil {
+			field.Value = p.text(value)
+		}
+	case rest[0].Kind == pyOp && rest[0].Text == "=":
+		_, value := splitAt(rest, "=")
+		field.Value = p.text(value)
+		field.Type = pyLiteralType(value)
+	default:
+		return Field{}, false
+	}
+	return field, true
+}
+
+// parseParams parses the tokens between the parentheses of a def
+func (p *pyParser) parseParams(toks []pyToken) []Parameter {
+	var params []Parameter
+	for _, item := range splitTopLevel(toks) {
+		if len(item) == 0 {
+			continue
+		}
+
+		if len(item) == 1 && item[0].Kind == pyOp && (item[0].Text == "/" || item[0].Text == "*") {
+			// Positional-only and keyword-only markers are not parameters
+			continue
+		}
+
+		var param Parameter
+		i := 0
+		if item[0].Kind == pyOp && (item[0].Text == "*" || item[0].Text == "**") {
+			param.Name = item[0].Text
+			i++
+		}
+		if i < len(item) && item[i].Kind == pyName {
+			param.Name += item[i].Text
+			i++
+		}
+
+		rest := item[i:]
+		if len(rest) > 0 && rest[0].Kind == pyOp && rest[0].Text == ":" {
+			ann, value := splitAt(rest[1:], "=")
+			param.Type = p.text(ann)
+			if value != nil {
+				param.Default = p.text(value)
+			}
+		} else if len(rest) > 0 && rest[0].Kind == pyOp && rest[0].Text == "=" {
+			param.Default = p.text(rest[1:])
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// bracketed consumes a (...) group and returns the tokens inside it
+func (p *pyParser) bracketed() []pyToken {
+	p.next() // (
+	start := p.pos
+	depth := 1
+	for p.peek().Kind != pyEOF {
+		tok := p.next()
+		if tok.Kind != pyOp {
+			continue
+		}
+		switch tok.Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return p.toks[start : p.pos-1]
+			}
+		}
+	}
+	return p.toks[start:p.pos]
+}
+
+// textUntil consumes tokens up to a top-level op and returns their source text
+func (p *pyParser) textUntil(op string) string {
+	start := p.pos
+	depth := 0
+	for p.peek().Kind != pyEOF && p.peek().Kind != pyNewline {
+		tok := p.peek()
+		if tok.Kind == pyOp {
+			switch tok.Text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
+			}
+			if depth == 0 && tok.Text == op {
+				break
+			}
+		}
+		p.next()
+	}
+	return p.text(p.toks[start:p.pos])
+}
+
+// textUntilNewline consumes the rest of the logical line, returning its text
+func (p *pyParser) textUntilNewline() string {
+	start := p.pos
+	for p.peek().Kind != pyNewline && p.peek().Kind != pyEOF {
+		p.next()
+	}
+	text := p.text(p.toks[start:p.pos])
+	if p.peek().Kind == pyNewline {
+		p.next()
+	}
+	return text
+}
+
+// skipLine consumes tokens through the end of the logical line
+func (p *pyParser) skipLine() {
+	for {
+		tok := p.next()
+		if tok.Kind == pyNewline || tok.Kind == pyEOF {
+			return
+		}
+	}
+}
+
+// text returns the source spanned by toks with whitespace collapsed
+func (p *pyParser) text(toks []pyToken) string {
+	if len(toks) == 0 {
+		return ""
+	}
+	return strings.Join(strings.Fields(p.src[toks[0].Start:toks[len(toks)-1].End]), " ")
+}
+
+// splitTopLevel splits tokens on commas outside brackets
+func splitTopLevel(toks []pyToken) [][]pyToken {
+	var parts [][]pyToken
+	depth, start := 0, 0
+	for i, tok := range toks {
+		if tok.Kind != pyOp {
+			continue
+		}
+		switch tok.Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		case ",":
+			if depth == 0 {
+				parts = append(parts, toks[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(toks) {
+		parts = append(parts, toks[start:])
+	}
+	return parts
+}
+
+// splitAt splits tokens at the first top-level op. The second half is nil
+// when op does not occur.
+func splitAt(toks []pyToken, op string) ([]pyToken, []pyToken) {
+	depth := 0
+	for i, tok := range toks {
+		if tok.Kind != pyOp {
+			continue
+		}
+		switch tok.Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		}
+		if depth == 0 && tok.Text == op {
+			return toks[:i], toks[i+1:]
+		}
+	}
+	return toks, nil
+}
+
+func containsOp(toks []pyToken, op string) bool {
+	_, after := splitAt(toks, op)
+	return after != nil
+}
+
+func hasDecorator(decorators []string, name string) bool {
+	for _, d := range decorators {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+// appendFields adds fields whose names have not been seen yet
+func appendFields(fields []Field, more ...Field) []Field {
+	for _, f := range more {
+		found := false
+		for _, existing := range fields {
+			if existing.Name == f.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func filterCalls(calls []string, known map[string]bool, self string) []string {
+	filtered := []string{}
+	seen := make(map[string]bool)
+	for _, c := range calls {
+		if known[c] && c != self && !seen[c] {
+			seen[c] = true
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// pyLiteralType infers the type of a simple literal assignment
+func pyLiteralType(value []pyToken) string {
+	if len(value) == 0 {
+		return ""
+	}
+	first := value[0]
+	switch {
+	case len(value) == 1 && first.Kind == pyNumber:
+		if strings.ContainsAny(first.Text, ".eE") && !strings.HasPrefix(strings.ToLower(first.Text), "0x") {
+			return "float"
+		}
+		return "int"
+	case len(value) == 1 && first.Kind == pyString:
+		if strings.ContainsAny(strings.ToLower(first.Text[:strings.IndexAny(first.Text, `'"`)]), "b") {
+			return "bytes"
+		}
+		return "str"
+	case len(value) == 1 && (first.Text == "True" || first.Text == "False"):
+		return "bool"
+	case first.Text == "[":
+		return "list"
+	case first.Text == "{":
+		return "dict"
+	}
+	return ""
+}
+
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true,
+	"finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true,
+	"not": true, "or": true, "pass": true, "raise": true, "return": true,
+	"try": true, "while": true, "with": true, "yield": true,
+}
+
+func isPyKeyword(name string) bool {
+	return pyKeywords[name]
+}
+
+// pyStringValue strips the prefix and quotes of a string literal
+func pyStringValue(lit string) string {
+	i := strings.IndexAny(lit, `'"`)
+	if i < 0 {
+		return lit
+	}
+	body := lit[i:]
+	quote := body[:1]
+	if strings.HasPrefix(body, strings.Repeat(quote, 3)) && len(body) >= 6 {
+		return body[3 : len(body)-3]
+	}
+	if len(body) >= 2 {
+		return body[1 : len(body)-1]
+	}
+	return body
+}
+
+// cleanDocstring trims a docstring the way inspect.cleandoc does: the first
+// line is stripped, the rest lose their common indentation, and leading and
+// trailing blank lines are dropped
+func cleanDocstring(doc string) string {
+	lines := strings.Split(strings.ReplaceAll(doc, "\t", "        "), "\n")
+
+	margin := -1
+	for _, l := range lines[1:] {
+		trimmed := strings.TrimLeft(l, " ")
+		if trimmed == "" {
+			continue
+		}
+		if indent := len(l) - len(trimmed); margin < 0 || indent < margin {
+			margin = indent
+		}
+	}
+
+	lines[0] = strings.TrimSpace(lines[0])
+	for i := 1; i < len(lines); i++ {
+		if margin > 0 && len(lines[i]) >= margin {
+			lines[i] = lines[i][margin:]
+		}
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
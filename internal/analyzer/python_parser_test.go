@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func paramNames(params []Parameter) []string {
+	var names []string
+	for _, p := range params {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func funcNames(funcs []Function) []string {
+	var names []string
+	for _, fn := range funcs {
+		names = append(names, fn.Name)
+	}
+	return names
+}
+
+func TestPythonParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		params []string
+	}{
+		{"plain", "def f(a, b=1): pass\n", []string{"a", "b"}},
+		{"positional-only marker", "def f(a, b, /, c): pass\n", []string{"a", "b", "c"}},
+		{"keyword-only marker", "def f(a, *, key=None): pass\n", []string{"a", "key"}},
+		{"both markers", "def f(a, /, b, *, c): pass\n", []string{"a", "b", "c"}},
+		{"variadic", "def f(*args, **kwargs): pass\n", []string{"*args", "**kwargs"}},
+		{"annotated defaults", "def f(x: dict[str, int] = {}, *rest: int): pass\n", []string{"x", "*rest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, funcs, err := parsePython(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(funcs) != 1 {
+				t.Fatalf("got functions %v", funcNames(funcs))
+			}
+			if got := paramNames(funcs[0].Parameters); !reflect.DeepEqual(got, tt.params) {
+				t.Errorf("params = %v, want %v", got, tt.params)
+			}
+		})
+	}
+}
+
+func TestPythonAnnotations(t *testing.T) {
+	_, funcs, err := parsePython("async def fetch(url: str, retries: int = 3) -> bytes:\n    ...\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := funcs[0]
+	want := []Parameter{{Name: "url", Type: "str"}, {Name: "retries", Type: "int", Default: "3"}}
+	if !fn.Async || !reflect.DeepEqual(fn.Parameters, want) || len(fn.Results) != 1 || fn.Results[0].Type != "bytes" {
+		t.Errorf("fetch = %+v", fn)
+	}
+}
+
+func TestPythonDeclarations(t *testing.T) {
+	src := `"""Module docstring"""
+import functools
+
+
+def outer(x):
+    """Outer docstring"""
+    def inner(y):
+        return y
+    class Local:
+        pass
+    return inner(x)
+
+
+@dataclass(frozen=True)
+class P(Base, metaclass=Meta):
+    """A point"""
+    x: int = 0
+
+    class Meta:
+        ordering = ["x"]
+
+    def __init__(self, y):
+        self.y = y
+
+    @staticmethod
+    def make(n):
+        def helper():
+            return n
+        return helper()
+
+    @property
+    def norm(self) -> float:
+        """Length of the point"""
+        return abs(self.x)
+
+
+if TYPE_CHECKING:
+    def typed() -> None: ...
+`
+	classes, funcs, err := parsePython(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := funcNames(funcs), []string{"outer", "typed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("functions = %v, want %v", got, want)
+	}
+	if funcs[0].Doc.Summary != "Outer docstring" {
+		t.Errorf("outer docstring = %q", funcs[0].Doc.Summary)
+	}
+
+	var names []string
+	for _, c := range classes {
+		names = append(names, c.Name)
+	}
+	if want := []string{"P", "P.Meta"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("classes = %v, want %v", names, want)
+	}
+
+	p := classes[0]
+	if !reflect.DeepEqual(p.Decorators, []string{"dataclass(frozen=True)"}) || !reflect.DeepEqual(p.Bases, []string{"Base"}) || p.Doc.Summary != "A point" {
+		t.Errorf("P = %+v", p)
+	}
+	if got, want := funcNames(p.Methods), []string{"__init__", "make", "norm"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("methods = %v, want %v", got, want)
+	}
+	if norm := p.Methods[2]; !reflect.DeepEqual(norm.Decorators, []string{"property"}) || norm.Doc.Summary != "Length of the point" {
+		t.Errorf("norm = %+v", norm)
+	}
+
+	var fields []string
+	for _, f := range p.Fields {
+		fields = append(fields, f.Name)
+	}
+	if want := []string{"x", "y"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
@@ -0,0 +1,246 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type pyTokenKind int
+
+const (
+	pyName pyTokenKind = iota
+	pyNumber
+	pyString
+	pyOp
+	pyNewline
+	pyIndent
+	pyDedent
+	pyEOF
+)
+
+// pyToken is one Python token. Start and End are byte offsets into the
+// source, so expressions can be sliced back out verbatim.
+type pyToken struct {
+	Kind  pyTokenKind
+	Text  string
+	Line  int
+	Start int
+	End   int
+}
+
+// Longest operators first, so "**=" wins over "**" and "*"
+var pyOperators = []string{
+	"**=", "//=", ">>=", "<<=", "...",
+	"->", "**", "//", "==", "!=", "<=", ">=", ":=", "+=", "-=", "*=", "/=",
+	"%=", "&=", "|=", "^=", "@=", "<<", ">>",
+}
+
+// tokenizePython splits Python source into tokens the way the CPython
+// tokenizer does: NEWLINE only ends logical lines, newlines inside brackets
+// and after a backslash are ignored, and indentation changes produce
+// INDENT/DEDENT tokens.
+func tokenizePython(src string) ([]pyToken, error) {
+	var toks []pyToken
+	indents := []int{0}
+	depth := 0
+	line := 1
+	atLineStart := true
+	i := 0
+
+	emit := func(kind pyTokenKind, start, end int) {
+		toks = append(toks, pyToken{Kind: kind, Text: src[start:end], Line: line, Start: start, End: end})
+	}
+
+	for i < len(src) {
+		if atLineStart && depth == 0 {
+			// Measure indentation, skipping blank and comment-only lines
+			col := 0
+			j := i
+			for j < len(src) && (src[j] == ' ' || src[j] == '\t' || src[j] == '\f') {
+				if src[j] == '\t' {
+					col = (col/8 + 1) * 8
+				} else if src[j] == ' ' {
+					col++
+				}
+				j++
+			}
+			if j >= len(src) {
+				i = j
+				break
+			}
+			if src[j] == '\n' || src[j] == '\r' || src[j] == '#' {
+				for j < len(src) && src[j] != '\n' {
+					j++
+				}
+				if j < len(src) {
+					j++
+					line++
+				}
+				i = j
+				continue
+			}
+
+			if col > indents[len(indents)-1] {
+				indents = append(indents, col)
+				emit(pyIndent, j, j)
+			}
+			for col < indents[len(indents)-1] {
+				indents = indents[:len(indents)-1]
+				emit(pyDedent, j, j)
+			}
+			if col > indents[len(indents)-1] {
+				// Dedent to a level never seen before; treat it as the
+				// enclosing level rather than failing the whole file
+				indents[len(indents)-1] = col
+			}
+			atLineStart = false
+			i = j
+		}
+
+		c := src[i]
+		switch {
+		case c == '\n':
+			if depth == 0 {
+				emit(pyNewline, i, i+1)
+				atLineStart = true
+			}
+			line++
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+
+		case c == '\\' && i+1 < len(src) && (src[i+1] == '\n' || src[i+1] == '\r'):
+			// Explicit line joining
+			i++
+			if src[i] == '\r' && i+1 < len(src) && src[i+1] == '\n' {
+				i++
+			}
+			line++
+			i++
+
+		case isPyStringStart(src, i):
+			end, lines, err := scanPyString(src, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			emit(pyString, i, end)
+			line += lines
+			i = end
+
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			start := i
+			for i < len(src) {
+				ch := src[i]
+				if (ch == '+' || ch == '-') && (src[i-1] == 'e' || src[i-1] == 'E') && !strings.HasPrefix(strings.ToLower(src[start:i]), "0x") {
+					i++
+					continue
+				}
+				if !(ch == '.' || ch == '_' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z') {
+					break
+				}
+				i++
+			}
+			emit(pyNumber, start, i)
+
+		case isPyNameStart(src, i):
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					break
+				}
+				i += size
+			}
+			emit(pyName, start, i)
+
+		default:
+			size := 1
+			for _, op := range pyOperators {
+				if strings.HasPrefix(src[i:], op) {
+					size = len(op)
+					break
+				}
+			}
+			switch c {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				if depth > 0 {
+					depth--
+				}
+			}
+			emit(pyOp, i, i+size)
+			i += size
+		}
+	}
+
+	if len(toks) > 0 && toks[len(toks)-1].Kind != pyNewline && toks[len(toks)-1].Kind != pyDedent {
+		emit(pyNewline, len(src), len(src))
+	}
+	for len(indents) > 1 {
+		indents = indents[:len(indents)-1]
+		emit(pyDedent, len(src), len(src))
+	}
+	emit(pyEOF, len(src), len(src))
+	return toks, nil
+}
+
+func isPyNameStart(src string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(src[i:])
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isPyStringStart reports whether a string literal, possibly with a prefix
+// such as r, b, f or rb, starts at i
+func isPyStringStart(src string, i int) bool {
+	for j := i; j < len(src) && j-i <= 2; j++ {
+		switch src[j] {
+		case '\'', '"':
+			return true
+		case 'r', 'R', 'b', 'B', 'u', 'U', 'f', 'F':
+			continue
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// scanPyString returns the end offset of the string literal starting at i
+// and the number of newlines it spans
+func scanPyString(src string, i int) (int, int, error) {
+	for src[i] != '\'' && src[i] != '"' {
+		i++
+	}
+	quote := src[i : i+1]
+	if strings.HasPrefix(src[i:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+
+	lines := 0
+	for j := i + len(quote); j < len(src); j++ {
+		switch {
+		case src[j] == '\\':
+			if j+1 < len(src) && src[j+1] == '\n' {
+				lines++
+			}
+			j++
+		case src[j] == '\n':
+			if len(quote) == 1 {
+				return 0, 0, fmt.Errorf("unterminated string literal")
+			}
+			lines++
+		case strings.HasPrefix(src[j:], quote):
+			return j + len(quote), lines, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("unterminated string literal")
+}
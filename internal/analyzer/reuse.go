@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// HashFile returns the hex encoded SHA-256 of a file's content
+func HashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// hashSources records the content hash of every source behind file
+func hashSources(file *AnalyzedFile) error {
+	file.Hashes = make(map[string]string)
+	for _, src := range file.Sources() {
+		hash, err := HashFile(src)
+		if err != nil {
+			return err
+		}
+		file.Hashes[src] = hash
+	}
+	return nil
+}
+
+// unchanged reports whether every source of a previous result still has the
+// recorded content hash
+func unchanged(file *AnalyzedFile) bool {
+	sources := file.Sources()
+	if len(sources) == 0 || len(file.Hashes) != len(sources) {
+		return false
+	}
+	for _, src := range sources {
+		hash, err := HashFile(src)
+		if err != nil || hash != file.Hashes[src] {
+			return false
+		}
+	}
+	return true
+}
+
+// previousFiles indexes the single-file results of an earlier run by path
+func previousFiles(previous []*AnalyzedFile) map[string]*AnalyzedFile {
+	byPath := make(map[string]*AnalyzedFile)
+	for _, file := range previous {
+		if sources := file.Sources(); len(sources) == 1 && sources[0] == file.Path {
+			byPath[file.Path] = file
+		}
+	}
+	return byPath
+}
+
+// reuseGoPackages returns the previous Go packages whose directory still holds
+// exactly the same, unchanged files, along with the paths left to parse
+func reuseGoPackages(paths []string, previous []*AnalyzedFile) ([]*AnalyzedFile, []string) {
+	if len(previous) == 0 {
+		return nil, paths
+	}
+
+	byDir := make(map[string][]*AnalyzedFile)
+	for _, file := range previous {
+		for _, pkg := range file.Packages {
+			if len(pkg.Files) > 0 {
+				byDir[file.Path] = append(byDir[file.Path], file)
+				break
+			}
+		}
+	}
+
+	current := make(map[string][]string)
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		current[dir] = append(current[dir], path)
+	}
+
+	reusedDirs := make(map[string]bool)
+	var reused []*AnalyzedFile
+	for dir, files := range current {
+		candidates := byDir[dir]
+		if len(candidates) == 0 {
+			continue
+		}
+
+		var sources []string
+		ok := true
+		for _, file := range candidates {
+			sources = append(sources, file.Sources()...)
+			ok = ok && unchanged(file)
+		}
+		if !ok || !sameFiles(sources, files) {
+			continue
+		}
+
+		reusedDirs[dir] = true
+		for _, file := range candidates {
+			file.Reused = true
+			reused = append(reused, file)
+		}
+	}
+
+	var remaining []string
+	for _, path := range paths {
+		if !reusedDirs[filepath.Dir(path)] {
+			remaining = append(remaining, path)
+		}
+	}
+	return reused, remaining
+}
+
+// reuseUnits returns the previous results of the given language, keyed by
+// directory, whose files are all unchanged, along with the directories left
+// to analyze. Helm charts and Ansible roles are analyzed this way.
+func reuseUnits(language string, dirs []string, sources map[string][]string, previous []*AnalyzedFile) ([]*AnalyzedFile, []string) {
+	byDir := make(map[string]*AnalyzedFile)
+	for _, file := range previous {
+		if file.Language == language {
+			byDir[file.Path] = file
+		}
+	}
+
+	var reused []*AnalyzedFile
+	var remaining []string
+	for _, dir := range dirs {
+		prev, ok := byDir[dir]
+		if ok && unchanged(prev) && sameFiles(prev.Sources(), sources[dir]) {
+			prev.Reused = true
+			reused = append(reused, prev)
+			continue
+		}
+		remaining = append(remaining, dir)
+	}
+	return reused, remaining
+}
+
+func sameFiles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
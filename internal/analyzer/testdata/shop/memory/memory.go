@@ -0,0 +1,23 @@
+package memory
+
+import "example.com/shop/store"
+
+// Memory keeps items in a map
+type Memory struct {
+	store.Base
+	items map[string]string
+}
+
+// New returns an empty store
+func New() *Memory {
+	m := &Memory{}
+	m.reset()
+	return m
+}
+
+func (m *Memory) reset() { m.items = make(map[string]string) }
+
+// Get looks an item up
+func (m *Memory) Get(id string) (string, error) {
+	return m.items[store.Normalize(id)], nil
+}
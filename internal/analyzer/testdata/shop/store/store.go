@@ -0,0 +1,17 @@
+package store
+
+import "strings"
+
+// Store persists items
+type Store interface {
+	Get(id string) (string, error)
+}
+
+// Base provides the behaviour shared by every store
+type Base struct{}
+
+// Close releases the store
+func (b *Base) Close() error { return nil }
+
+// Normalize canonicalizes an item ID
+func Normalize(id string) string { return strings.ToLower(id) }
@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type TSAnalyzer struct{}
+
+var tsExtensions = []string{".ts", ".tsx", ".mts", ".cts"}
+
+func (t *TSAnalyzer) Supports(projectDir string, opts Options) bool {
+	return hasFiles(projectDir, opts, tsExtensions...)
+}
+
+func (t *TSAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	paths, err := walkFiles(projectDir, opts, tsExtensions...)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := analyzeFiles(ctx, skipShadowedDeclarations(paths), opts, t.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyzerResult{Files: files}, nil
+}
+
+// analyzeFile extracts the TypeScript declarations of a single file
+func (t *TSAnalyzer) analyzeFile(path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := Package{
+		Name: filepath.Base(filepath.Dir(path)),
+		Path: path,
+	}
+	parseES(string(content), true, &pkg)
+
+	return &AnalyzedFile{
+		Path:     path,
+		Packages: []Package{pkg},
+	}, nil
+}
+
+// skipShadowedDeclarations drops foo.d.ts when foo.ts sits next to it, since
+// the declaration file is usually generated from that source
+func skipShadowedDeclarations(paths []string) []string {
+	present := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		present[path] = true
+	}
+
+	var kept []string
+	for _, path := range paths {
+		if base, ok := strings.CutSuffix(path, ".d.ts"); ok && (present[base+".ts"] || present[base+".tsx"]) {
+			continue
+		}
+		kept = append(kept, path)
+	}
+	return kept
+}
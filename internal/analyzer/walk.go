@@ -0,0 +1,266 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// skippedDirs are never analyzed: VCS metadata, vendored dependencies, test
+// fixtures, virtualenvs and DocuPocus' own AI cache
+var skippedDirs = map[string]bool{
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+	"__pycache__":  true,
+	".venv":        true,
+	"venv":         true,
+	"ai-cache":     true,
+}
+
+// fileWalker lists the project files analyzers should look at. It honors
+// .gitignore files at any depth, skips well-known directories and the docs
+// output, and applies the include/exclude globs from Options.
+type fileWalker struct {
+	root      string
+	outputDir string
+	include   *ignore.GitIgnore
+	exclude   *ignore.GitIgnore
+	ignores   map[string]*gitignoreFile // .gitignore per directory
+}
+
+// gitignoreFile holds the patterns of one .gitignore. The negated patterns are
+// also compiled on their own, so a deeper file can re-include paths a parent
+// directory ignores.
+type gitignoreFile struct {
+	patterns *ignore.GitIgnore
+	negated  *ignore.GitIgnore
+}
+
+func newFileWalker(root string, opts Options) *fileWalker {
+	w := &fileWalker{
+		root:    root,
+		ignores: make(map[string]*gitignoreFile),
+	}
+	if opts.OutputDir != "" {
+		if abs, err := filepath.Abs(opts.OutputDir); err == nil {
+			w.outputDir = abs
+		}
+	}
+	if len(opts.Include) > 0 {
+		w.include, _ = ignore.CompileIgnoreLines(opts.Include...)
+	}
+	if len(opts.Exclude) > 0 {
+		w.exclude, _ = ignore.CompileIgnoreLines(opts.Exclude...)
+	}
+	return w
+}
+
+// walkFiles returns the files below root with one of the given extensions,
+// in lexical order, after applying every exclusion rule
+func walkFiles(root string, opts Options, exts ...string) ([]string, error) {
+	return newFileWalker(root, opts).walk(func(path string) bool {
+		return hasExtension(path, exts)
+	})
+}
+
+// errFileFound stops a walk at the first matching file
+var errFileFound = errors.New("file found")
+
+// hasFiles reports whether root holds any file with one of the given
+// extensions that an analyzer would look at
+func hasFiles(root string, opts Options, exts ...string) bool {
+	err := newFileWalker(root, opts).each(func(path string) bool {
+		return hasExtension(path, exts)
+	}, func(path string) error {
+		return errFileFound
+	})
+	return err == errFileFound
+}
+
+// languageExtensions maps the languages reported by DetectLanguages to their
+// file extensions
+var languageExtensions = []struct {
+	language string
+	exts     []string
+}{
+	{"Go", []string{".go"}},
+	{"Python", []string{".py"}},
+	{"TypeScript", []string{".ts", ".tsx"}},
+	{"JavaScript", []string{".js", ".mjs", ".cjs"}},
+	{"YAML", []string{".yaml", ".yml"}},
+	{"Terraform", []string{".tf"}},
+	{"HCL", []string{".hcl"}},
+	{"Protobuf", []string{".proto"}},
+}
+
+// DetectLanguages lists the languages of the files an analysis of projectDir
+// covers, in a fixed order
+func DetectLanguages(projectDir string, opts Options) []string {
+	found := make(map[string]bool)
+	newFileWalker(projectDir, opts).each(func(path string) bool {
+		return true
+	}, func(path string) error {
+		for _, l := range languageExtensions {
+			if hasExtension(path, l.exts) {
+				found[l.language] = true
+			}
+		}
+		return nil
+	})
+
+	var langs []string
+	for _, l := range languageExtensions {
+		if found[l.language] {
+			langs = append(langs, l.language)
+		}
+	}
+	return langs
+}
+
+// walk returns the files below the root accepted by match
+func (w *fileWalker) walk(match func(path string) bool) ([]string, error) {
+	var files []string
+	err := w.each(match, func(path string) error {
+		files = append(files, path)
+		return nil
+	})
+	return files, err
+}
+
+// each calls visit, in lexical order, for every file below the root accepted
+// by match. An error from visit stops the walk and is returned. Entries that
+// cannot be read are reported and skipped.
+func (w *fileWalker) each(match func(path string) bool, visit func(path string) error) error {
+	return filepath.WalkDir(w.root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if d == nil {
+				// The root itself is missing or unreadable
+				return err
+			}
+			fmt.Printf("⚠️ Skipping %s: %v\n", path, err)
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if path != w.root && w.skipDir(path, d.Name()) {
+				return filepath.SkipDir
+			}
+			w.loadGitignore(path)
+			return nil
+		}
+
+		if path == filepath.Join(w.root, DefaultConfigFile) {
+			// DocuPocus' own settings are not part of the project
+			return nil
+		}
+		if !match(path) || w.ignored(path, false) {
+			return nil
+		}
+		if w.include != nil && !w.include.MatchesPath(w.rel(path)) {
+			return nil
+		}
+		return visit(path)
+	})
+}
+
+func (w *fileWalker) skipDir(path, name string) bool {
+	if skippedDirs[name] {
+		return true
+	}
+	if w.outputDir != "" {
+		if abs, err := filepath.Abs(path); err == nil && abs == w.outputDir {
+			return true
+		}
+	}
+	return w.ignored(path, true)
+}
+
+// ignored checks path against the exclude globs and every .gitignore between
+// the project root and the path
+func (w *fileWalker) ignored(path string, isDir bool) bool {
+	suffix := ""
+	if isDir {
+		// Patterns such as "build/" only match directories
+		suffix = "/"
+	}
+
+	if w.exclude != nil && w.exclude.MatchesPath(w.rel(path)+suffix) {
+		return true
+	}
+
+	var dirs []string
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == w.root || filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	// Deeper files take precedence, as in git
+	ignored := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		gf := w.ignores[dirs[i]]
+		if gf == nil {
+			continue
+		}
+		rel, err := filepath.Rel(dirs[i], path)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel) + suffix
+		if gf.patterns.MatchesPath(rel) {
+			ignored = true
+		} else if ignored && gf.negated.MatchesPath(rel) {
+			ignored = false
+		}
+	}
+	return ignored
+}
+
+func (w *fileWalker) loadGitignore(dir string) {
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		w.ignores[dir] = nil
+		return
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var negated []string
+	for _, line := range lines {
+		if pattern, ok := strings.CutPrefix(strings.TrimSpace(line), "!"); ok {
+			negated = append(negated, pattern)
+		}
+	}
+	gf := &gitignoreFile{}
+	gf.patterns, _ = ignore.CompileIgnoreLines(lines...)
+	gf.negated, _ = ignore.CompileIgnoreLines(negated...)
+	w.ignores[dir] = gf
+}
+
+func (w *fileWalker) rel(path string) string {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func hasExtension(path string, exts []string) bool {
+	for _, ext := range exts {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHasFilesSkipsDependencies(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "vendor/lib/lib.go", "package lib\n")
+	writeTestFile(t, dir, "node_modules/pkg/index.ts", "export {}\n")
+	writeTestFile(t, dir, "ai-cache/x.yaml", "a: 1\n")
+	writeTestFile(t, dir, "main.py", "print(1)\n")
+
+	tests := []struct {
+		exts []string
+		want bool
+	}{
+		{[]string{".go"}, false},
+		{[]string{".ts", ".tsx"}, false},
+		{[]string{".yaml", ".yml"}, false},
+		{[]string{".py"}, true},
+	}
+	for _, tt := range tests {
+		if got := hasFiles(dir, Options{}, tt.exts...); got != tt.want {
+			t.Errorf("hasFiles(%v) = %v, want %v", tt.exts, got, tt.want)
+		}
+	}
+
+	if got, want := DetectLanguages(dir, Options{}), []string{"Python"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectLanguages() = %v, want %v", got, want)
+	}
+}
+
+func TestWalkFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, ".gitignore", "*.log\nbuild/\n")
+	writeTestFile(t, dir, "sub/.gitignore", "!keep.log\ngen_*.go\n")
+	for _, name := range []string{
+		"main.go", "debug.log", "build/out.go",
+		"sub/keep.log", "sub/other.log", "sub/gen_x.go", "sub/x.go",
+		"other/gen_y.go", "other/y_test.go",
+		"docs/api.go", DefaultConfigFile,
+	} {
+		writeTestFile(t, dir, name, "")
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"gitignore", Options{}, []string{"docs/api.go", "main.go", "other/gen_y.go", "other/y_test.go", "sub/keep.log", "sub/x.go"}},
+		{"output dir", Options{OutputDir: filepath.Join(dir, "docs")}, []string{"main.go", "other/gen_y.go", "other/y_test.go", "sub/keep.log", "sub/x.go"}},
+		{"include", Options{Include: []string{"sub/"}}, []string{"sub/keep.log", "sub/x.go"}},
+		{"exclude", Options{Exclude: []string{"*_test.go", "sub"}}, []string{"docs/api.go", "main.go", "other/gen_y.go"}},
+		{"include and exclude", Options{Include: []string{"*.go"}, Exclude: []string{"other/"}}, []string{"docs/api.go", "main.go", "sub/x.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := walkFiles(dir, tt.opts, ".go", ".log", ".yaml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range files {
+				rel, _ := filepath.Rel(dir, f)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasFilesHonorsExclude(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "scripts/tool.py", "print(1)\n")
+
+	if !hasFiles(dir, Options{}, ".py") {
+		t.Error("hasFiles() = false without excludes")
+	}
+	if hasFiles(dir, Options{Exclude: []string{"scripts/"}}, ".py") {
+		t.Error("hasFiles() = true for an excluded directory")
+	}
+	if (&PythonAnalyzer{}).Supports(dir, Options{Exclude: []string{"*.py"}}) {
+		t.Error("Python analyzer supports a project whose Python files are all excluded")
+	}
+}
+
+func TestWalkSkipsUnreadableDirs(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.go", "")
+	writeTestFile(t, dir, "gone/b.go", "")
+	writeTestFile(t, dir, "z.go", "")
+
+	// The directory is listed but disappears before it is read
+	var files []string
+	err := newFileWalker(dir, Options{}).each(func(path string) bool {
+		return true
+	}, func(path string) error {
+		files = append(files, path)
+		return os.RemoveAll(filepath.Join(dir, "gone"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{filepath.Join(dir, "a.go"), filepath.Join(dir, "z.go")}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+}
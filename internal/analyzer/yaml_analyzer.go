@@ -1,75 +1,98 @@
 package analyzer
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	ai "github.com/MRGHOSJ/docupocus/internal/ai/types"
-	"github.com/MRGHOSJ/docupocus/internal/utils"
 	"gopkg.in/yaml.v3"
 )
 
 type YAMLAnalyzer struct{}
 
-func (y *YAMLAnalyzer) Supports(projectDir string) bool {
-	return utils.HasFilesWithExtension(projectDir, ".yaml") ||
-		utils.HasFilesWithExtension(projectDir, ".yml")
+func (y *YAMLAnalyzer) Supports(projectDir string, opts Options) bool {
+	return hasFiles(projectDir, opts, ".yaml", ".yml")
 }
 
-func (y *YAMLAnalyzer) Analyze(projectDir string) (*AnalyzerResult, error) {
-	var result AnalyzerResult
+func (y *YAMLAnalyzer) Analyze(ctx context.Context, projectDir string, opts Options) (*AnalyzerResult, error) {
+	paths, err := walkFiles(projectDir, opts, ".yaml", ".yml")
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.WalkDir(projectDir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() || !(strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
-			return nil
-		}
+	files, err := analyzeFiles(ctx, paths, opts, y.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyzerResult{Files: files}, nil
+}
 
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return err
-		}
+// analyzeFile extracts the YAML declarations of a single file
+func (y *YAMLAnalyzer) analyzeFile(path string) (*AnalyzedFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
 
-		// Parse with full document structure
-		var nodes []yaml.Node
-		decoder := yaml.NewDecoder(strings.NewReader(string(content)))
-		for {
-			var node yaml.Node
-			if err := decoder.Decode(&node); err != nil {
-				break
-			}
-			nodes = append(nodes, node)
+	// Parse with full document structure
+	var nodes []yaml.Node
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			break
 		}
-
-		pkg := Package{
-			Name: filepath.Base(filepath.Dir(path)),
-			Path: path,
+		limitYAMLAliases(&node)
+		nodes = append(nodes, node)
+	}
+
+	// Workflows and actions are documented on the CI/CD page
+	if isGitHubActionsFile(path) && len(nodes) == 1 {
+		if structs := y.analyzeGitHubActions(&nodes[0], path, string(content)); structs != nil {
+			return &AnalyzedFile{
+				Path:     path,
+				Language: "GitHub Actions",
+				Packages: []Package{{Name: cicdPackageName, ImportPath: cicdImportPath, Path: path, Structs: structs}},
+			}, nil
 		}
+	}
 
-		// Handle multi-document YAML files
-		for _, node := range nodes {
-			structs := y.analyzeYAMLNode(&node, filepath.Base(path))
-			pkg.Structs = append(pkg.Structs, structs...)
-		}
+	pkg := Package{
+		Name: filepath.Base(filepath.Dir(path)),
+		Path: path,
+	}
 
-		result.Files = append(result.Files, &AnalyzedFile{
+	// Compose files describe services and what they share as a whole
+	if len(nodes) == 1 && len(nodes[0].Content) > 0 && y.isDockerCompose(nodes[0].Content[0], path) {
+		pkg.Structs = y.analyzeDockerCompose(&nodes[0], path)
+		return &AnalyzedFile{
 			Path:     path,
+			Language: "Docker Compose",
 			Packages: []Package{pkg},
-		})
+		}, nil
+	}
 
-		return nil
-	})
+	// Handle multi-document YAML files
+	for _, node := range nodes {
+		structs := y.analyzeYAMLNode(&node, filepath.Base(path))
+		pkg.Structs = append(pkg.Structs, structs...)
+	}
 
-	return &result, err
+	return &AnalyzedFile{
+		Path:     path,
+		Packages: []Package{pkg},
+	}, nil
 }
 
 func (y *YAMLAnalyzer) analyzeYAMLNode(node *yaml.Node, baseName string) []Struct {
 	var structs []Struct
 
+	// Hand-written comments document the struct; the AI only fills the gaps
+	comment := y.extractYAMLComment(node)
 	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
 		node = node.Content[0]
 	}
@@ -78,25 +101,23 @@ func (y *YAMLAnalyzer) analyzeYAMLNode(node *yaml.Node, baseName string) []Struc
 	switch {
 	case y.isKubernetesResource(node):
 		resource := y.analyzeKubernetesResource(node, baseName)
-		resource.Doc.Summary = y.extractYAMLDescription(node)
+		if comment == "" && len(resource.Fields) > 0 {
+			// A comment right above apiVersion describes the resource
+			comment = resource.Fields[0].DocYAML.Summary
+			resource.Fields[0].DocYAML.Summary = ""
+		}
+		resource.Doc.Summary = comment
 		structs = append(structs, resource)
-	case y.isHelmChart(node):
-		chart := y.analyzeHelmChart(node, baseName)
-		chart.Doc.Summary = y.extractYAMLDescription(node)
-		structs = append(structs, chart)
-	case y.isAnsiblePlaybook(node):
-		chart := y.analyzeAnsiblePlaybook(node, baseName)
-		chart.Doc.Summary = y.extractYAMLDescription(node)
-		structs = append(structs, chart)
-	case y.isDockerCompose(node):
-		chart := y.analyzeDockerCompose(node, baseName)
-		chart.Doc.Summary = y.extractYAMLDescription(node)
-		structs = append(structs, chart)
 	default:
 		// Generic YAML analysis
+		summary := comment
+		if summary == "" {
+			summary = y.extractYAMLDescription(node)
+		}
 		structs = append(structs, Struct{
 			Name:    baseName,
-			DocYAML: ai.YAMLDocumentation{Summary: y.extractYAMLDescription(node)},
+			Doc:     ai.Documentation{Summary: comment},
+			DocYAML: ai.YAMLDocumentation{Summary: summary},
 			Fields:  y.extractYAMLFields(node, 0),
 		})
 	}
@@ -112,25 +133,6 @@ func (y *YAMLAnalyzer) isKubernetesResource(node *yaml.Node) bool {
 		y.hasKey(node, "kind")
 }
 
-func (y *YAMLAnalyzer) isHelmChart(node *yaml.Node) bool {
-	return node.Kind == yaml.MappingNode &&
-		(y.hasKey(node, "chart") ||
-			y.hasKey(node, "dependencies"))
-}
-
-func (y *YAMLAnalyzer) isAnsiblePlaybook(node *yaml.Node) bool {
-	return node.Kind == yaml.SequenceNode &&
-		len(node.Content) > 0 &&
-		node.Content[0].Kind == yaml.MappingNode &&
-		y.hasKey(node.Content[0], "hosts")
-}
-
-func (y *YAMLAnalyzer) isDockerCompose(node *yaml.Node) bool {
-	return node.Kind == yaml.MappingNode &&
-		(y.hasKey(node, "services") ||
-			y.hasKey(node, "version"))
-}
-
 // -- Specialized Analyzers --
 
 func (y *YAMLAnalyzer) analyzeKubernetesResource(node *yaml.Node, baseName string) Struct {
@@ -156,6 +158,7 @@ func (y *YAMLAnalyzer) analyzeKubernetesResource(node *yaml.Node, baseName strin
 
 	return Struct{
 		Name:    kind,
+		Kind:    "kubernetes",
 		DocYAML: ai.YAMLDocumentation{Summary: desc},
 		Fields:  y.extractKubernetesSpec(node),
 	}
@@ -164,24 +167,15 @@ func (y *YAMLAnalyzer) analyzeKubernetesResource(node *yaml.Node, baseName strin
 func (y *YAMLAnalyzer) extractKubernetesSpec(node *yaml.Node) []Field {
 	var fields []Field
 
-	if node.Kind != yaml.MappingNode {
-		return fields
-	}
-
-	for i := 0; i < len(node.Content); i += 2 {
-		if i+1 >= len(node.Content) {
-			continue
-		}
-		keyNode := node.Content[i]
-		valueNode := node.Content[i+1]
+	for _, pair := range y.mappingPairs(node) {
+		keyNode, valueNode := pair.Key, pair.Value
 
 		name := keyNode.Value
 		field := Field{
-			Name: name,
-			Type: y.nodeKindToString(valueNode.Kind),
-			DocYAML: ai.YAMLDocumentation{
-				Summary: strings.TrimSpace(keyNode.HeadComment + " " + keyNode.LineComment),
-			},
+			Name:    name,
+			Type:    y.nodeKindToString(valueNode.Kind),
+			DocYAML: ai.YAMLDocumentation{Summary: fieldComment(keyNode, valueNode)},
+			Origin:  pair.Origin,
 		}
 
 		switch valueNode.Kind {
@@ -203,195 +197,15 @@ func (y *YAMLAnalyzer) extractKubernetesSpec(node *yaml.Node) []Field {
 }
 
 func (y *YAMLAnalyzer) findSpecNode(node *yaml.Node) *yaml.Node {
-	if node.Kind != yaml.MappingNode {
-		return nil
-	}
-
 	// Look for 'spec' key
-	for i := 0; i < len(node.Content); i += 2 {
-		if i+1 >= len(node.Content) {
-			continue
-		}
-		keyNode := node.Content[i]
-		if keyNode.Value == "spec" {
-			return node.Content[i+1]
-		}
-	}
-
-	return nil
-}
-
-func (y *YAMLAnalyzer) analyzeHelmChart(node *yaml.Node, baseName string) Struct {
-	return Struct{
-		Name: "HelmChart",
-		DocYAML: ai.YAMLDocumentation{
-			Summary: "Helm Chart configuration \n" + y.extractYAMLDescription(node),
-		},
-		Fields: y.extractYAMLFields(node, 0),
-	}
-}
-
-func (y *YAMLAnalyzer) analyzeAnsiblePlaybook(node *yaml.Node, baseName string) Struct {
-	playbook := Struct{
-		Name: "AnsiblePlaybook",
-		DocYAML: ai.YAMLDocumentation{
-			Summary: "Ansible Playbook configuration",
-		},
-	}
-
-	if node.Kind == yaml.SequenceNode && len(node.Content) > 0 {
-		// Analyze first play (common case)
-		firstPlay := node.Content[0]
-		if firstPlay.Kind == yaml.MappingNode {
-			playbook.Fields = y.extractAnsiblePlayFields(firstPlay)
-		}
-	}
-
-	return playbook
-}
-
-func (y *YAMLAnalyzer) extractAnsiblePlayFields(playNode *yaml.Node) []Field {
-	var fields []Field
-
-	for i := 0; i < len(playNode.Content); i += 2 {
-		if i+1 >= len(playNode.Content) {
-			continue
-		}
-		keyNode := playNode.Content[i]
-		valueNode := playNode.Content[i+1]
-
-		field := Field{
-			Name:    keyNode.Value,
-			Type:    y.nodeKindToString(valueNode.Kind),
-			DocYAML: ai.YAMLDocumentation{Summary: strings.TrimSpace(keyNode.HeadComment + "\n" + keyNode.LineComment)},
-		}
-
-		// Special handling for tasks
-		if keyNode.Value == "tasks" && valueNode.Kind == yaml.SequenceNode {
-			field.Fields = y.extractAnsibleTasks(valueNode)
-		} else if valueNode.Kind == yaml.MappingNode || valueNode.Kind == yaml.SequenceNode {
-			field.Fields = y.extractYAMLFields(valueNode, 1)
-		}
-
-		fields = append(fields, field)
-	}
-
-	return fields
-}
-
-func (y *YAMLAnalyzer) extractAnsibleTasks(tasksNode *yaml.Node) []Field {
-	var taskFields []Field
-
-	for i, taskNode := range tasksNode.Content {
-		if taskNode.Kind == yaml.MappingNode {
-			taskField := Field{
-				Name:   "task_" + string(rune('a'+i)),
-				Type:   "task",
-				Fields: []Field{},
-			}
-
-			// Extract task details
-			for j := 0; j < len(taskNode.Content); j += 2 {
-				if j+1 >= len(taskNode.Content) {
-					continue
-				}
-				keyNode := taskNode.Content[j]
-				valueNode := taskNode.Content[j+1]
-
-				subField := Field{
-					Name:    keyNode.Value,
-					Type:    y.nodeKindToString(valueNode.Kind),
-					DocYAML: ai.YAMLDocumentation{Summary: strings.TrimSpace(keyNode.HeadComment + "\n" + keyNode.LineComment)},
-				}
-
-				if valueNode.Kind == yaml.MappingNode || valueNode.Kind == yaml.SequenceNode {
-					subField.Fields = y.extractYAMLFields(valueNode, 2)
-				}
-
-				taskField.Fields = append(taskField.Fields, subField)
-			}
-
-			taskFields = append(taskFields, taskField)
-		}
-	}
-
-	return taskFields
-}
-
-func (y *YAMLAnalyzer) analyzeDockerCompose(node *yaml.Node, baseName string) Struct {
-	compose := Struct{
-		Name: "DockerCompose",
-		DocYAML: ai.YAMLDocumentation{
-			Summary: "Docker Compose configuration",
-		},
-	}
-
-	// Extract version if present
-	version := y.getValue(node, "version")
-	if version != "" {
-		compose.Doc.Summary += "\nVersion: " + version
-	}
-
-	// Extract services
-	servicesNode := y.getValueNode(node, "services")
-	if servicesNode != nil && servicesNode.Kind == yaml.MappingNode {
-		compose.Fields = y.extractDockerServices(servicesNode)
-	}
-
-	// Extract networks and volumes if present
-	if networksNode := y.getValueNode(node, "networks"); networksNode != nil {
-		compose.Fields = append(compose.Fields, Field{
-			Name:   "networks",
-			Type:   y.nodeKindToString(networksNode.Kind),
-			Fields: y.extractYAMLFields(networksNode, 1),
-		})
-	}
-
-	if volumesNode := y.getValueNode(node, "volumes"); volumesNode != nil {
-		compose.Fields = append(compose.Fields, Field{
-			Name:   "volumes",
-			Type:   y.nodeKindToString(volumesNode.Kind),
-			Fields: y.extractYAMLFields(volumesNode, 1),
-		})
-	}
-
-	return compose
-}
-
-func (y *YAMLAnalyzer) extractDockerServices(servicesNode *yaml.Node) []Field {
-	var serviceFields []Field
-
-	for i := 0; i < len(servicesNode.Content); i += 2 {
-		if i+1 >= len(servicesNode.Content) {
-			continue
-		}
-		serviceNameNode := servicesNode.Content[i]
-		serviceConfigNode := servicesNode.Content[i+1]
-
-		serviceField := Field{
-			Name:    serviceNameNode.Value,
-			Type:    "service",
-			DocYAML: ai.YAMLDocumentation{Summary: strings.TrimSpace(serviceNameNode.HeadComment + "\n" + serviceNameNode.LineComment)},
-		}
-
-		if serviceConfigNode.Kind == yaml.MappingNode {
-			serviceField.Fields = y.extractYAMLFields(serviceConfigNode, 1)
-		}
-
-		serviceFields = append(serviceFields, serviceField)
-	}
-
-	return serviceFields
+	return y.getValueNode(node, "spec")
 }
 
 // Helper to get the value node for a key
 func (y *YAMLAnalyzer) getValueNode(node *yaml.Node, key string) *yaml.Node {
-	if node.Kind != yaml.MappingNode {
-		return nil
-	}
-	for i := 0; i < len(node.Content); i += 2 {
-		if i+1 < len(node.Content) && node.Content[i].Value == key {
-			return node.Content[i+1]
+	for _, pair := range y.mappingPairs(node) {
+		if pair.Key.Value == key {
+			return pair.Value
 		}
 	}
 	return nil
@@ -403,8 +217,14 @@ func (y *YAMLAnalyzer) extractArrayItems(node *yaml.Node) []Field {
 	var items []Field
 
 	for _, item := range node.Content {
+		origin := ""
+		if item.Kind == yaml.AliasNode {
+			origin = "*" + item.Value
+			item = resolveYAMLAlias(item)
+		}
 		itemField := Field{
-			Type: y.nodeKindToString(item.Kind),
+			Type:   y.nodeKindToString(item.Kind),
+			Origin: origin,
 		}
 
 		switch item.Kind {
@@ -422,25 +242,30 @@ func (y *YAMLAnalyzer) extractArrayItems(node *yaml.Node) []Field {
 
 func (y *YAMLAnalyzer) extractYAMLFields(node *yaml.Node, depth int) []Field {
 	var fields []Field
+	if depth > maxYAMLDepth {
+		return nil
+	}
 
+	node = resolveYAMLAlias(node)
 	if node.Kind == yaml.MappingNode {
-		for i := 0; i < len(node.Content); i += 2 {
-			if i+1 >= len(node.Content) {
-				continue
-			}
-			keyNode := node.Content[i]
-			valueNode := node.Content[i+1]
+		for _, pair := range y.mappingPairs(node) {
+			keyNode, valueNode := pair.Key, pair.Value
 
 			field := Field{
 				Name:    keyNode.Value,
 				Type:    y.nodeKindToString(valueNode.Kind),
-				DocYAML: ai.YAMLDocumentation{Summary: strings.TrimSpace(keyNode.HeadComment + "\n" + keyNode.LineComment)},
+				DocYAML: ai.YAMLDocumentation{Summary: fieldComment(keyNode, valueNode)},
+				Origin:  pair.Origin,
 			}
 
 			// Capture scalar values
 			if valueNode.Kind == yaml.ScalarNode {
 				field.Value = valueNode.Value
 			}
+			if valueNode.Kind == yaml.AliasNode {
+				// Recursive alias, kept as written
+				field.Value = "*" + valueNode.Value
+			}
 
 			// Handle nested structures
 			if valueNode.Kind == yaml.MappingNode || valueNode.Kind == yaml.SequenceNode {
@@ -451,10 +276,21 @@ func (y *YAMLAnalyzer) extractYAMLFields(node *yaml.Node, depth int) []Field {
 		}
 	} else if node.Kind == yaml.SequenceNode {
 		for i, itemNode := range node.Content {
+			comment := yamlComment(itemNode.HeadComment, itemNode.LineComment, itemNode.FootComment)
+			origin := ""
+			if itemNode.Kind == yaml.AliasNode {
+				origin = "*" + itemNode.Value
+				itemNode = resolveYAMLAlias(itemNode)
+			}
 			field := Field{
-				Name:   fmt.Sprintf("[%d]", i),
-				Type:   y.nodeKindToString(itemNode.Kind),
-				Fields: y.extractYAMLFields(itemNode, depth+1),
+				Name:    fmt.Sprintf("[%d]", i),
+				Type:    y.nodeKindToString(itemNode.Kind),
+				DocYAML: ai.YAMLDocumentation{Summary: comment},
+				Origin:  origin,
+				Fields:  y.extractYAMLFields(itemNode, depth+1),
+			}
+			if itemNode.Kind == yaml.ScalarNode {
+				field.Value = itemNode.Value
 			}
 			fields = append(fields, field)
 		}
@@ -463,14 +299,10 @@ func (y *YAMLAnalyzer) extractYAMLFields(node *yaml.Node, depth int) []Field {
 	return fields
 }
 
+// extractYAMLDescription describes the shape of an uncommented document
 func (y *YAMLAnalyzer) extractYAMLDescription(node *yaml.Node) string {
 	var desc strings.Builder
 
-	if node.HeadComment != "" {
-		desc.WriteString(strings.TrimSpace(node.HeadComment))
-		desc.WriteString("\n\n")
-	}
-
 	switch node.Kind {
 	case yaml.MappingNode:
 		desc.WriteString("YAML mapping with keys: ")
@@ -478,7 +310,7 @@ func (y *YAMLAnalyzer) extractYAMLDescription(node *yaml.Node) string {
 		desc.WriteString(strings.Join(keys, ", "))
 	case yaml.SequenceNode:
 		desc.WriteString("YAML sequence with ")
-		desc.WriteString(string(len(node.Content)))
+		desc.WriteString(fmt.Sprintf("%d", len(node.Content)))
 		desc.WriteString(" items")
 	case yaml.ScalarNode:
 		desc.WriteString("YAML scalar value")
@@ -487,42 +319,267 @@ func (y *YAMLAnalyzer) extractYAMLDescription(node *yaml.Node) string {
 	return desc.String()
 }
 
-// -- Utility Methods --
+// extractYAMLComment returns the comment at the top of a document, separated
+// from the first key by a blank line
+func (y *YAMLAnalyzer) extractYAMLComment(node *yaml.Node) string {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return yamlComment(node.HeadComment, node.Content[0].HeadComment)
+	}
+	return yamlComment(node.HeadComment)
+}
 
-func (y *YAMLAnalyzer) hasKey(node *yaml.Node, key string) bool {
-	if node.Kind != yaml.MappingNode {
-		return false
+// fieldComment returns the comments around a mapping entry: above the key,
+// after the key or its scalar value, and below the entry
+func fieldComment(key, value *yaml.Node) string {
+	comments := []string{key.HeadComment, key.LineComment}
+	if value.Kind == yaml.ScalarNode || value.Kind == yaml.AliasNode {
+		comments = append(comments, value.LineComment)
 	}
-	for i := 0; i < len(node.Content); i += 2 {
-		if node.Content[i].Value == key {
-			return true
+	comments = append(comments, key.FootComment)
+	return yamlComment(comments...)
+}
+
+// yamlComment joins comment blocks without their "#" markers and blank lines
+func yamlComment(comments ...string) string {
+	var lines []string
+	for _, c := range comments {
+		for _, line := range strings.Split(c, "\n") {
+			line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+			if line != "" {
+				lines = append(lines, line)
+			}
 		}
 	}
-	return false
+	return strings.Join(lines, "\n")
+}
+
+// -- Utility Methods --
+
+func (y *YAMLAnalyzer) hasKey(node *yaml.Node, key string) bool {
+	return y.getValueNode(node, key) != nil
 }
 
 func (y *YAMLAnalyzer) getValue(node *yaml.Node, key string) string {
-	if node.Kind != yaml.MappingNode {
-		return ""
-	}
-	for i := 0; i < len(node.Content); i += 2 {
-		if node.Content[i].Value == key && i+1 < len(node.Content) {
-			return node.Content[i+1].Value
-		}
+	if value := y.getValueNode(node, key); value != nil {
+		return value.Value
 	}
 	return ""
 }
 
 func (y *YAMLAnalyzer) getMappingKeys(node *yaml.Node) []string {
 	var keys []string
-	if node.Kind == yaml.MappingNode {
-		for i := 0; i < len(node.Content); i += 2 {
-			keys = append(keys, node.Content[i].Value)
-		}
+	for _, pair := range y.mappingPairs(node) {
+		keys = append(keys, pair.Key.Value)
 	}
 	return keys
 }
 
+// yamlPair is a mapping entry with aliases resolved
+type yamlPair struct {
+	Key    *yaml.Node
+	Value  *yaml.Node
+	Origin string // "*anchor" for an aliased value, "<<: *anchor" for a merged key
+}
+
+// mappingPairs returns the effective entries of a mapping: "<<" merge keys
+// are expanded in place, keys written in the mapping override merged ones
+// and earlier merges override later ones, as in YAML 1.1
+func (y *YAMLAnalyzer) mappingPairs(node *yaml.Node) []yamlPair {
+	node = resolveYAMLAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	// Entries brought in by each merge key, and where each merged key came from
+	merges := make(map[int][]yamlPair)
+	mergedFrom := make(map[string]string)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isMergeKey(node.Content[i]) {
+			continue
+		}
+		// <<: *base or <<: [*base, *other]
+		value := node.Content[i+1]
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, source := range sources {
+			origin := "<<"
+			if source.Kind == yaml.AliasNode {
+				origin = "<<: *" + source.Value
+			}
+			for _, merged := range y.mappingPairs(source) {
+				if _, ok := mergedFrom[merged.Key.Value]; ok {
+					continue
+				}
+				mergedFrom[merged.Key.Value] = origin
+				merges[i] = append(merges[i], yamlPair{Key: merged.Key, Value: merged.Value, Origin: origin})
+			}
+		}
+	}
+
+	local := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isMergeKey(node.Content[i]) {
+			local[node.Content[i].Value] = true
+		}
+	}
+
+	var pairs []yamlPair
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if isMergeKey(key) {
+			for _, merged := range merges[i] {
+				if !local[merged.Key.Value] {
+					pairs = append(pairs, merged)
+				}
+			}
+			continue
+		}
+
+		origin := ""
+		if from, ok := mergedFrom[key.Value]; ok {
+			origin = "overrides " + from
+		}
+		resolved := resolveYAMLAlias(value)
+		if resolved != value {
+			origin = "*" + value.Value
+		}
+		pairs = append(pairs, yamlPair{Key: key, Value: resolved, Origin: origin})
+	}
+	return pairs
+}
+
+// isMergeKey reports whether key is an unquoted "<<"
+func isMergeKey(key *yaml.Node) bool {
+	return key.Value == "<<" && key.ShortTag() == "!!merge"
+}
+
+const (
+	// maxYAMLAliasNodes bounds the nodes the aliases of one document may
+	// expand to
+	maxYAMLAliasNodes = 2000
+	// maxYAMLDepth bounds how deep fields are extracted
+	maxYAMLDepth = 32
+)
+
+// limitYAMLAliases defuses alias bombs: an alias whose expansion would take
+// the document past maxYAMLAliasNodes is turned into the scalar "*name",
+// recording the alias as written instead of following it
+func limitYAMLAliases(root *yaml.Node) {
+	sizes := make(map[*yaml.Node]int)
+	budget := maxYAMLAliasNodes
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind != yaml.AliasNode {
+			for _, child := range node.Content {
+				walk(child)
+			}
+			return
+		}
+		if node.Alias == nil {
+			return
+		}
+		size := expandedYAMLSize(node.Alias, sizes, make(map[*yaml.Node]bool))
+		if size > budget {
+			node.Kind, node.Tag, node.Value, node.Alias = yaml.ScalarNode, "!!str", "*"+node.Value, nil
+			return
+		}
+		budget -= size
+	}
+	walk(root)
+}
+
+// expandedYAMLSize counts the nodes under node with aliases expanded, up to
+// maxYAMLAliasNodes+1. Aliases inside their own anchor count as one node.
+func expandedYAMLSize(node *yaml.Node, sizes map[*yaml.Node]int, visiting map[*yaml.Node]bool) int {
+	if size, ok := sizes[node]; ok {
+		return size
+	}
+	if visiting[node] {
+		return 1
+	}
+	visiting[node] = true
+	defer delete(visiting, node)
+
+	size := 1
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		size = expandedYAMLSize(node.Alias, sizes, visiting)
+	}
+	for _, child := range node.Content {
+		size += expandedYAMLSize(child, sizes, visiting)
+		if size > maxYAMLAliasNodes {
+			size = maxYAMLAliasNodes + 1
+			break
+		}
+	}
+	sizes[node] = size
+	return size
+}
+
+// resolveYAMLAlias follows an alias to the anchored node. An alias inside
+// its own anchor, which would describe an infinite structure, is left as is.
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	if node == nil || node.Kind != yaml.AliasNode || node.Alias == nil || containsYAMLNode(node.Alias, node) {
+		return node
+	}
+	return node.Alias
+}
+
+// containsYAMLNode reports whether target appears under root, without
+// following aliases
+func containsYAMLNode(root, target *yaml.Node) bool {
+	if root == target {
+		return true
+	}
+	for _, child := range root.Content {
+		if containsYAMLNode(child, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// yamlValueType names the type of a value the way chart and role READMEs do
+func yamlValueType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "list"
+	case yaml.MappingNode:
+		return "object"
+	}
+	switch node.ShortTag() {
+	case "!!int":
+		return "int"
+	case "!!float":
+		return "float"
+	case "!!bool":
+		return "bool"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// yamlDefault renders a default value; lists and maps are written as JSON
+func yamlDefault(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	if node.Kind == yaml.AliasNode {
+		return "*" + node.Value
+	}
+	var v interface{}
+	if err := node.Decode(&v); err != nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 func (y *YAMLAnalyzer) nodeKindToString(kind yaml.Kind) string {
 	switch kind {
 	case yaml.DocumentNode:
@@ -0,0 +1,233 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseYAMLTest parses a document the way the analyzers do and returns its
+// top-level node
+func parseYAMLTest(t *testing.T, src string) *yaml.Node {
+	t.Helper()
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(src), &root); err != nil {
+		t.Fatal(err)
+	}
+	limitYAMLAliases(&root)
+	return root.Content[0]
+}
+
+func countFields(fields []Field) int {
+	n := len(fields)
+	for _, f := range fields {
+		n += countFields(f.Fields)
+	}
+	return n
+}
+
+func findField(fields []Field, name string) *Field {
+	for i := range fields {
+		if fields[i].Name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func TestYAMLAliasBomb(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`a: &a ["lol","lol","lol","lol","lol","lol"]` + "\n")
+	for level := 'b'; level <= 'h'; level++ {
+		prev := string(level - 1)
+		b.WriteString(string(level) + ": &" + string(level) + " [" + strings.TrimSuffix(strings.Repeat("*"+prev+",", 6), ",") + "]\n")
+	}
+
+	y := &YAMLAnalyzer{}
+	fields := y.extractYAMLFields(parseYAMLTest(t, b.String()), 0)
+	if n := countFields(fields); n > 2*maxYAMLAliasNodes {
+		t.Errorf("extracted %d fields, want at most about %d", n, maxYAMLAliasNodes)
+	}
+
+	// Past the budget, aliases are recorded as written
+	h := findField(fields, "h")
+	if h == nil || len(h.Fields) == 0 || h.Fields[0].Value != "*g" {
+		t.Errorf("h = %+v, want its items kept as *g", h)
+	}
+}
+
+func TestYAMLAliases(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		path   []string
+		value  string
+		origin string
+	}{
+		{
+			name:   "alias is expanded",
+			src:    "base: &base {port: 80}\nweb: *base\n",
+			path:   []string{"web", "port"},
+			value:  "80",
+			origin: "",
+		},
+		{
+			name:   "aliased value records its anchor",
+			src:    "default: &d 3\nretries: *d\n",
+			path:   []string{"retries"},
+			value:  "3",
+			origin: "*d",
+		},
+		{
+			name:   "merge key brings in the anchor's keys",
+			src:    "base: &base {port: 80, host: a}\nweb:\n  <<: *base\n  host: b\n",
+			path:   []string{"web", "port"},
+			value:  "80",
+			origin: "<<: *base",
+		},
+		{
+			name:   "local keys override merged ones",
+			src:    "base: &base {port: 80, host: a}\nweb:\n  <<: *base\n  host: b\n",
+			path:   []string{"web", "host"},
+			value:  "b",
+			origin: "overrides <<: *base",
+		},
+		{
+			name:   "earlier merges win",
+			src:    "a: &a {x: 1}\nb: &b {x: 2}\nc:\n  <<: [*a, *b]\n",
+			path:   []string{"c", "x"},
+			value:  "1",
+			origin: "<<: *a",
+		},
+		{
+			name:   "alias loop is kept as written",
+			src:    "node: &n\n  name: root\n  child: *n\n",
+			path:   []string{"node", "child"},
+			value:  "*n",
+			origin: "",
+		},
+	}
+
+	y := &YAMLAnalyzer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := y.extractYAMLFields(parseYAMLTest(t, tt.src), 0)
+			var f *Field
+			for _, name := range tt.path {
+				f = findField(fields, name)
+				if f == nil {
+					t.Fatalf("no field %q in %+v", name, fields)
+				}
+				fields = f.Fields
+			}
+			if f.Value != tt.value || f.Origin != tt.origin {
+				t.Errorf("got value %q origin %q, want %q %q", f.Value, f.Origin, tt.value, tt.origin)
+			}
+		})
+	}
+}
+
+func TestYAMLDepthLimit(t *testing.T) {
+	src := strings.Repeat("[", 100) + strings.Repeat("]", 100)
+	y := &YAMLAnalyzer{}
+	depth := 0
+	for fields := y.extractYAMLFields(parseYAMLTest(t, src), 0); len(fields) > 0; fields = fields[0].Fields {
+		depth++
+	}
+	if depth > maxYAMLDepth+1 {
+		t.Errorf("extracted %d levels, want at most %d", depth, maxYAMLDepth+1)
+	}
+}
+
+func TestYAMLFieldComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		path    []string
+		comment string
+	}{
+		{"above the key", "# Replica count\nreplicas: 3\n", []string{"replicas"}, "Replica count"},
+		{"after the value", "replicas: 3 # at least one\n", []string{"replicas"}, "at least one"},
+		{"above and after", "# Replica count\n## More\nreplicas: 3 # at least one\n", []string{"replicas"}, "Replica count\nMore\nat least one"},
+		{"after a mapping key", "server: # The server\n  port: 80\n", []string{"server"}, "The server"},
+		{"nested key", "server:\n  # Listen port\n  port: 80\n", []string{"server", "port"}, "Listen port"},
+		{"below the last entry", "server:\n  port: 80\n  # trailing note\n", []string{"server", "port"}, "trailing note"},
+		{"sequence item", "hosts:\n  # primary\n  - a\n  - b # replica\n", []string{"hosts", "[1]"}, "replica"},
+		{"uncommented", "replicas: 3\n", []string{"replicas"}, ""},
+	}
+
+	y := &YAMLAnalyzer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := y.extractYAMLFields(parseYAMLTest(t, tt.src), 0)
+			var f *Field
+			for _, name := range tt.path {
+				f = findField(fields, name)
+				if f == nil {
+					t.Fatalf("no field %q in %+v", name, fields)
+				}
+				fields = f.Fields
+			}
+			if f.DocYAML.Summary != tt.comment {
+				t.Errorf("comment = %q, want %q", f.DocYAML.Summary, tt.comment)
+			}
+		})
+	}
+}
+
+func TestYAMLDocumentComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		doc     string
+		summary string
+		first   string
+	}{
+		{
+			name:    "comment separated from the first key",
+			src:     "# Service settings\n\n# Replica count\nreplicas: 3\n",
+			doc:     "Service settings",
+			summary: "Service settings",
+			first:   "Replica count",
+		},
+		{
+			name:    "uncommented document is described",
+			src:     "a: 1\nb: [1, 2]\n",
+			summary: "YAML mapping with keys: a, b",
+		},
+		{
+			name:    "comment above apiVersion describes the resource",
+			src:     "# The web frontend\napiVersion: apps/v1\nkind: Deployment\nmetadata: {name: web}\n",
+			doc:     "The web frontend",
+			summary: "Kubernetes Deployment: web (apiVersion: apps/v1)",
+		},
+		{
+			name:    "document comment of a resource",
+			src:     "# Exposes web\n\napiVersion: v1\nkind: Service\nmetadata: {name: web}\n",
+			doc:     "Exposes web",
+			summary: "Kubernetes Service: web (apiVersion: v1)",
+		},
+	}
+
+	y := &YAMLAnalyzer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.src), &doc); err != nil {
+				t.Fatal(err)
+			}
+			structs := y.analyzeYAMLNode(&doc, "values.yaml")
+			if len(structs) != 1 {
+				t.Fatalf("got %d structs", len(structs))
+			}
+			s := structs[0]
+			if s.Doc.Summary != tt.doc || s.DocYAML.Summary != tt.summary {
+				t.Errorf("doc %q summary %q, want %q %q", s.Doc.Summary, s.DocYAML.Summary, tt.doc, tt.summary)
+			}
+			if got := s.Fields[0].DocYAML.Summary; got != tt.first {
+				t.Errorf("first field comment = %q, want %q", got, tt.first)
+			}
+		})
+	}
+}
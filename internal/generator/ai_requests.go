@@ -2,10 +2,12 @@ package generator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/MRGHOSJ/docupocus/internal/ai"
+	aiTypes "github.com/MRGHOSJ/docupocus/internal/ai/types"
 	"github.com/MRGHOSJ/docupocus/internal/analyzer"
 	cfg "github.com/MRGHOSJ/docupocus/internal/generator/types"
 )
@@ -14,8 +16,9 @@ func processAIRequests(
 	codeRequests []cfg.AICodeRequest,
 	yamlRequests []cfg.AIYAMLRequest,
 	client *ai.Client,
-) {
+) error {
 	ctx := context.Background()
+	var errs []error
 
 	// Process code requests
 	if len(codeRequests) > 0 {
@@ -29,10 +32,11 @@ func processAIRequests(
 		results, err := client.EnhanceDocumentationBatch(ctx, inputs, languages)
 		if err != nil {
 			fmt.Printf("⚠️ Code enhancement failed: %v\n", err)
+			errs = append(errs, err)
 		} else {
 			for i, res := range results {
 				if codeRequests[i].Target != nil {
-					*codeRequests[i].Target = res
+					*codeRequests[i].Target = mergeDocumentation(*codeRequests[i].Target, res)
 				}
 			}
 		}
@@ -50,6 +54,7 @@ func processAIRequests(
 		results, err := client.EnhanceYAMLDocumentationBatch(ctx, inputs, languages)
 		if err != nil {
 			fmt.Printf("⚠️ YAML enhancement failed: %v\n", err)
+			errs = append(errs, err)
 		} else {
 			for i, res := range results {
 				if yamlRequests[i].Target != nil {
@@ -58,33 +63,103 @@ func processAIRequests(
 			}
 		}
 	}
+
+	return errors.Join(errs...)
 }
 
-// New function to format YAML structs for AI processing
-func formatYAMLStruct(s analyzer.Struct) string {
+// withSourceDoc prefixes a code AI input with the documentation the analyzer
+// read from the source (docstrings, JSDoc tags) so the AI builds on it
+func withSourceDoc(input string, doc aiTypes.Documentation) string {
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("YAML Configuration Structure: %s\n", s.Name))
-	if s.Doc.Summary != "" {
-		b.WriteString(fmt.Sprintf("Description: %s\n", s.Doc.Summary))
+	if doc.Summary != "" {
+		b.WriteString(doc.Summary + "\n")
 	}
-	b.WriteString("Fields:\n")
-
-	for _, f := range s.Fields {
-		b.WriteString(fmt.Sprintf("- %s (%s)", f.Name, f.Type))
-		if f.Value != "" {
-			b.WriteString(fmt.Sprintf(" = %s", f.Value))
+	for _, p := range doc.Parameters {
+		if p.Description != "" {
+			b.WriteString(fmt.Sprintf("@param %s %s\n", p.Name, p.Description))
 		}
-		b.WriteString("\n")
+	}
+	if doc.Returns != "" {
+		b.WriteString("@returns " + doc.Returns + "\n")
+	}
+	for _, t := range doc.Throws {
+		b.WriteString("@throws " + t + "\n")
+	}
+	if doc.Deprecated {
+		b.WriteString(strings.TrimSpace("@deprecated "+doc.DeprecationNote) + "\n")
+	}
+	if b.Len() == 0 {
+		return input
+	}
+	return "Documentation from the source:\n" + b.String() + "\n" + input
+}
 
-		if len(f.Fields) > 0 {
-			b.WriteString(formatYAMLFields(f.Fields, 1))
+// mergeDocumentation fills the gaps of the documentation read from the
+// source with the AI's. The author's text wins; Throws and Deprecated only
+// ever come from the source.
+func mergeDocumentation(source, generated aiTypes.Documentation) aiTypes.Documentation {
+	merged := generated
+	if source.Summary != "" {
+		merged.Summary = source.Summary
+	}
+	if source.Returns != "" {
+		merged.Returns = source.Returns
+	}
+	if source.UsageExample != "" {
+		merged.UsageExample = source.UsageExample
+	}
+	if len(source.EdgeCases) > 0 {
+		merged.EdgeCases = source.EdgeCases
+	}
+	if len(source.Parameters) > 0 {
+		// Documented parameters first, then the ones only the AI described
+		described := make(map[string]aiTypes.Param)
+		for _, p := range generated.Parameters {
+			described[p.Name] = p
+		}
+		merged.Parameters = nil
+		for _, p := range source.Parameters {
+			if p.Description == "" {
+				p.Description = described[p.Name].Description
+			}
+			if p.Type == "" {
+				p.Type = described[p.Name].Type
+			}
+			merged.Parameters = append(merged.Parameters, p)
+			delete(described, p.Name)
 		}
+		for _, p := range generated.Parameters {
+			if _, ok := described[p.Name]; ok {
+				merged.Parameters = append(merged.Parameters, p)
+			}
+		}
+	}
+	merged.Throws = source.Throws
+	merged.Deprecated = source.Deprecated
+	merged.DeprecationNote = source.DeprecationNote
+	return merged
+}
+
+// New function to format YAML structs for AI processing
+func formatYAMLStruct(lang string, s analyzer.Struct) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s Configuration Structure: %s\n", lang, s.Name))
+	description := s.Doc.Summary
+	if description == "" {
+		// Comments and descriptions the analyzer wrote before the AI replaces them
+		description = s.DocYAML.Summary
+	}
+	if description != "" {
+		b.WriteString(fmt.Sprintf("Description: %s\n", description))
 	}
+	b.WriteString("Fields:\n")
+	b.WriteString(formatYAMLFields(s.Fields, 0))
 
 	return b.String()
 }
 
-// Helper for recursive field formatting
+// Helper for recursive field formatting; comments from the source follow
+// each field after "#" so the AI keeps their meaning
 func formatYAMLFields(fields []analyzer.Field, depth int) string {
 	var b strings.Builder
 	indent := strings.Repeat("  ", depth)
@@ -94,6 +169,12 @@ func formatYAMLFields(fields []analyzer.Field, depth int) string {
 		if f.Value != "" {
 			b.WriteString(fmt.Sprintf(" = %s", f.Value))
 		}
+		if f.Origin != "" {
+			b.WriteString(" [from " + f.Origin + "]")
+		}
+		if f.DocYAML.Summary != "" {
+			b.WriteString(" # " + strings.Join(strings.Fields(f.DocYAML.Summary), " "))
+		}
 		b.WriteString("\n")
 
 		if len(f.Fields) > 0 {
@@ -103,3 +184,21 @@ func formatYAMLFields(fields []analyzer.Field, depth int) string {
 
 	return b.String()
 }
+
+// yamlCommented reports whether the comments in the source already document
+// a struct and each of its top-level fields, leaving nothing for the AI
+func yamlCommented(s analyzer.Struct) bool {
+	if s.Doc.Summary == "" {
+		return false
+	}
+	if s.Kind == "target" {
+		// A Make target's "## help" says what it does; its recipe needs no comments
+		return true
+	}
+	for _, f := range s.Fields {
+		if f.DocYAML.Summary == "" {
+			return false
+		}
+	}
+	return true
+}
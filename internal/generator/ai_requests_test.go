@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	aiTypes "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+func TestMergeDocumentation(t *testing.T) {
+	source := aiTypes.Documentation{
+		Summary:         "Parses a config string.",
+		Parameters:      []aiTypes.Param{{Name: "text", Description: "the raw config text"}},
+		Throws:          []string{"(SyntaxError) when the text is malformed"},
+		Deprecated:      true,
+		DeprecationNote: "use parseConfigV2",
+	}
+	generated := aiTypes.Documentation{
+		Summary: "AI summary",
+		Parameters: []aiTypes.Param{
+			{Name: "strict", Type: "boolean", Description: "reject unknown keys"},
+			{Name: "text", Type: "string", Description: "AI text"},
+		},
+		Returns:        "the parsed object",
+		TimeComplexity: "O(n)",
+		Throws:         []string{"made up"},
+	}
+
+	got := mergeDocumentation(source, generated)
+	want := aiTypes.Documentation{
+		Summary: "Parses a config string.",
+		Parameters: []aiTypes.Param{
+			{Name: "text", Type: "string", Description: "the raw config text"},
+			{Name: "strict", Type: "boolean", Description: "reject unknown keys"},
+		},
+		Returns:         "the parsed object",
+		TimeComplexity:  "O(n)",
+		Throws:          []string{"(SyntaxError) when the text is malformed"},
+		Deprecated:      true,
+		DeprecationNote: "use parseConfigV2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeDocumentation() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := mergeDocumentation(aiTypes.Documentation{}, generated); got.Throws != nil || got.Summary != "AI summary" {
+		t.Errorf("without source docs: %+v", got)
+	}
+}
+
+func TestWithSourceDoc(t *testing.T) {
+	if got := withSourceDoc("func f()", aiTypes.Documentation{}); got != "func f()" {
+		t.Errorf("undocumented input changed: %q", got)
+	}
+	got := withSourceDoc("function f(a)", aiTypes.Documentation{
+		Summary:    "Does f.",
+		Parameters: []aiTypes.Param{{Name: "a", Description: "the input"}},
+		Throws:     []string{"Error"},
+		Deprecated: true,
+	})
+	for _, want := range []string{"Does f.", "@param a the input", "@throws Error", "@deprecated", "function f(a)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("input %q does not contain %q", got, want)
+		}
+	}
+}
+
+func TestYAMLCommented(t *testing.T) {
+	recipe := []analyzer.Field{{Name: "recipe", Type: "array", Fields: []analyzer.Field{{Name: "[0]", Value: "go build ./..."}}}}
+	tests := []struct {
+		name string
+		s    analyzer.Struct
+		want bool
+	}{
+		{
+			name: "make target with help",
+			s:    analyzer.Struct{Name: "build", Kind: "target", Fields: recipe, Doc: aiTypes.Documentation{Summary: "Build the binary"}},
+			want: true,
+		},
+		{
+			name: "make target without help",
+			s:    analyzer.Struct{Name: "build", Kind: "target", Fields: recipe},
+			want: false,
+		},
+		{
+			name: "resource with an uncommented field",
+			s:    analyzer.Struct{Name: "web", Fields: recipe, Doc: aiTypes.Documentation{Summary: "Web server"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		if got := yamlCommented(tt.s); got != tt.want {
+			t.Errorf("%s: yamlCommented() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
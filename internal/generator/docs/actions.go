@@ -0,0 +1,340 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
+)
+
+// GenerateActionsDoc appends the workflows and actions of a GitHub Actions
+// file to the CI/CD page
+func GenerateActionsDoc(pkg analyzer.Package, filePath string, cfg docTypes.GeneratorConfig) error {
+	docDir := docUtils.PackageDocDir(pkg)
+	pkgDir := filepath.Join(cfg.OutputDir, docDir)
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+
+	readmePath := filepath.Join(pkgDir, "README.md")
+	var existingContent []byte
+	if _, err := os.Stat(readmePath); err == nil {
+		existingContent, _ = os.ReadFile(readmePath)
+	}
+
+	var b strings.Builder
+	if len(existingContent) > 0 {
+		b.Write(existingContent)
+		b.WriteString("\n---\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("# ⚙️ %s\n\n", pkg.Name))
+		b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
+	}
+
+	b.WriteString(fmt.Sprintf("## 📄 File: `%s`\n\n", filepath.Base(filePath)))
+	b.WriteString(fmt.Sprintf("> 📍 Path: `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+
+	var jobs []analyzer.Struct
+	for _, s := range pkg.Structs {
+		switch s.Kind {
+		case "workflow":
+			b.WriteString(formatWorkflow(s))
+		case "job":
+			jobs = append(jobs, s)
+		case "action":
+			b.WriteString(formatAction(s))
+		}
+	}
+	if len(jobs) > 0 {
+		b.WriteString(formatJobs(jobs))
+	}
+
+	return os.WriteFile(readmePath, []byte(b.String()), 0644)
+}
+
+func formatWorkflow(s analyzer.Struct) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("### 🔄 Workflow: `%s`\n\n", s.Name))
+	b.WriteString(formatActionsSummary(s))
+
+	for _, f := range s.Fields {
+		switch f.Name {
+		case "on":
+			b.WriteString("**Triggers:**\n\n")
+			for _, t := range f.Fields {
+				line := fmt.Sprintf("- `%s`", t.Name)
+				if t.Value != "" {
+					line += " — " + t.Value
+				}
+				b.WriteString(line + "\n")
+			}
+			b.WriteString("\n")
+		case "permissions":
+			b.WriteString(formatPermissions(f))
+		case "env":
+			b.WriteString("**Environment:**\n\n")
+			for _, e := range f.Fields {
+				b.WriteString(fmt.Sprintf("- `%s`: `%s`\n", e.Name, e.Value))
+			}
+			b.WriteString("\n")
+		case "concurrency":
+			group := f.Value
+			for _, c := range f.Fields {
+				if c.Name == "group" {
+					group = c.Value
+				}
+			}
+			b.WriteString(fmt.Sprintf("**Concurrency:** `%s`\n\n", group))
+		}
+	}
+	b.WriteString(formatReferences(s.Fields))
+	return b.String()
+}
+
+// formatJobs renders the job index followed by one section per job
+func formatJobs(jobs []analyzer.Struct) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("### 🧱 Jobs (%d)\n\n", len(jobs)))
+	b.WriteString("| Job | Runs on | Needs | Steps |\n")
+	b.WriteString("|-----|---------|-------|-------|\n")
+	for _, job := range jobs {
+		runsOn := jobField(job, "runs-on").Value
+		if uses := jobField(job, "uses").Value; uses != "" {
+			runsOn = "reusable: " + uses
+		}
+		var needs []string
+		for _, n := range jobField(job, "needs").Fields {
+			needs = append(needs, n.Value)
+		}
+		b.WriteString(fmt.Sprintf("| [`%s`](#%s) | %s | %s | %d |\n", job.Name, operationAnchor(job.Name), tableCode(runsOn), tableCode(strings.Join(needs, ", ")), len(jobField(job, "steps").Fields)))
+	}
+	b.WriteString("\n")
+
+	for _, job := range jobs {
+		b.WriteString(fmt.Sprintf("#### `%s`\n\n", job.Name))
+		b.WriteString(formatActionsSummary(job))
+		details := 0
+		for _, f := range job.Fields {
+			switch f.Name {
+			case "if", "environment", "timeout-minutes", "continue-on-error":
+				value := f.Value
+				if value == "" {
+					// environment: {name, url}
+					value = jobField(analyzer.Struct{Fields: f.Fields}, "name").Value
+				}
+				b.WriteString(fmt.Sprintf("- **%s:** `%s`\n", f.Name, value))
+				details++
+			case "strategy":
+				if matrix := jobField(analyzer.Struct{Fields: f.Fields}, "matrix"); len(matrix.Fields) > 0 {
+					var axes []string
+					for _, axis := range matrix.Fields {
+						axes = append(axes, "`"+axis.Name+"`")
+					}
+					b.WriteString("- **matrix:** " + strings.Join(axes, ", ") + "\n")
+					details++
+				}
+			case "with":
+				var inputs []string
+				for _, w := range f.Fields {
+					inputs = append(inputs, fmt.Sprintf("`%s: %s`", w.Name, firstLine(w.Value)))
+				}
+				b.WriteString("- **with:** " + strings.Join(inputs, ", ") + "\n")
+				details++
+			}
+		}
+		if details > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(formatPermissions(jobField(job, "permissions")))
+		b.WriteString(formatSteps(jobField(job, "steps").Fields))
+	}
+	return b.String()
+}
+
+func formatAction(s analyzer.Struct) string {
+	var b strings.Builder
+	using := jobField(s, "runs").Value
+	b.WriteString(fmt.Sprintf("### 🧩 Action: `%s`\n\n", s.Name))
+	b.WriteString(formatActionsSummary(s))
+	if using != "" {
+		b.WriteString(fmt.Sprintf("**Runs using:** `%s`\n\n", using))
+	}
+
+	for _, f := range s.Fields {
+		switch f.Name {
+		case "inputs":
+			b.WriteString("**Inputs:**\n\n")
+			b.WriteString("| Name | Required | Default | Description |\n")
+			b.WriteString("|------|----------|---------|-------------|\n")
+			for _, in := range f.Fields {
+				required := ""
+				description := in.DocYAML.Summary
+				for _, m := range in.Modifiers {
+					if m == "required" {
+						required = "✅"
+					} else {
+						description = strings.TrimSpace("_" + m + "_ " + description)
+					}
+				}
+				b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", in.Name, required, tableCode(in.Value), tableText(description)))
+			}
+			b.WriteString("\n")
+		case "outputs":
+			b.WriteString("**Outputs:**\n\n")
+			b.WriteString("| Name | Value | Description |\n")
+			b.WriteString("|------|-------|-------------|\n")
+			for _, out := range f.Fields {
+				b.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", out.Name, tableCode(out.Value), tableText(out.DocYAML.Summary)))
+			}
+			b.WriteString("\n")
+		case "runs":
+			var steps []analyzer.Field
+			details := 0
+			for _, r := range f.Fields {
+				if r.Name == "steps" {
+					steps = r.Fields
+					continue
+				}
+				// main, pre, post, image, entrypoint
+				b.WriteString(fmt.Sprintf("- **%s:** `%s`\n", r.Name, r.Value))
+				details++
+			}
+			if details > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(formatSteps(steps))
+		}
+	}
+	b.WriteString(formatReferences(s.Fields))
+	return b.String()
+}
+
+// formatSteps renders the steps of a job or composite action as a numbered list
+func formatSteps(steps []analyzer.Field) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("**Steps:**\n\n")
+	for i, step := range steps {
+		multiline := step.Type == "run" && strings.Contains(step.Value, "\n")
+		var line string
+		switch title := step.DocYAML.Summary; {
+		case title == "" && multiline:
+			line = fmt.Sprintf("%d. **%s**", i+1, step.Type)
+		case title == "":
+			// Unnamed steps are known by their action or command
+			line = fmt.Sprintf("%d. `%s`", i+1, step.Value)
+		case multiline || step.Value == "":
+			line = fmt.Sprintf("%d. **%s**", i+1, title)
+		default:
+			line = fmt.Sprintf("%d. **%s** — `%s`", i+1, title, step.Value)
+		}
+		b.WriteString(line + "\n")
+		if multiline {
+			b.WriteString("   ```bash\n")
+			for _, l := range strings.Split(step.Value, "\n") {
+				b.WriteString("   " + l + "\n")
+			}
+			b.WriteString("   ```\n")
+		}
+		for _, f := range step.Fields {
+			if f.Name != "with" && f.Name != "if" {
+				continue
+			}
+			if f.Name == "if" {
+				b.WriteString(fmt.Sprintf("   - if: `%s`\n", f.Value))
+				continue
+			}
+			for _, w := range f.Fields {
+				b.WriteString(fmt.Sprintf("   - `%s`: `%s`\n", w.Name, firstLine(w.Value)))
+			}
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatPermissions renders "permissions: read-all" or one line per scope
+func formatPermissions(f analyzer.Field) string {
+	if f.Name == "" {
+		return ""
+	}
+	if len(f.Fields) == 0 {
+		value := f.Value
+		if value == "" {
+			value = "none"
+		}
+		return fmt.Sprintf("**Permissions:** `%s`\n\n", value)
+	}
+	var b strings.Builder
+	b.WriteString("**Permissions:**\n\n")
+	for _, scope := range f.Fields {
+		b.WriteString(fmt.Sprintf("- `%s`: `%s`\n", scope.Name, scope.Value))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatReferences lists the secrets and actions a workflow or action relies on
+func formatReferences(fields []analyzer.Field) string {
+	var b strings.Builder
+	for _, f := range fields {
+		var values []string
+		for _, item := range f.Fields {
+			values = append(values, "`"+item.Value+"`")
+		}
+		switch f.Name {
+		case "secrets":
+			b.WriteString("**Secrets:** " + strings.Join(values, ", ") + "\n\n")
+		case "uses":
+			b.WriteString("**Actions used:**\n\n")
+			for _, v := range values {
+				b.WriteString("- " + v + "\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// formatActionsSummary renders the description and AI usage notes of a
+// workflow, job or action. Hand-written descriptions win over the AI summary.
+func formatActionsSummary(s analyzer.Struct) string {
+	var b strings.Builder
+	summary := s.Doc.Summary
+	if summary == "" {
+		summary = s.DocYAML.Summary
+	}
+	if summary != "" {
+		b.WriteString(summary + "\n\n")
+	}
+	if s.DocYAML.Usage != "" {
+		b.WriteString("<details>\n<summary>🧰 Usage</summary>\n\n")
+		b.WriteString(s.DocYAML.Usage + "\n")
+		b.WriteString("</details>\n\n")
+	}
+	return b.String()
+}
+
+// jobField returns the named field of a struct, or the zero Field
+func jobField(s analyzer.Struct, name string) analyzer.Field {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return analyzer.Field{}
+}
+
+// firstLine shortens a multi-line value, such as an inline script, to its first line
+func firstLine(s string) string {
+	if line, _, ok := strings.Cut(s, "\n"); ok {
+		return line + " …"
+	}
+	return s
+}
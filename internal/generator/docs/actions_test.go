@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+func TestFormatJobs(t *testing.T) {
+	jobs := []analyzer.Struct{
+		{
+			Name: "test",
+			Kind: "job",
+			Fields: []analyzer.Field{
+				{Name: "runs-on", Value: "ubuntu-latest"},
+				{Name: "strategy", Fields: []analyzer.Field{{Name: "matrix", Fields: []analyzer.Field{{Name: "os"}, {Name: "go"}}}}},
+				{Name: "permissions", Value: "read-all"},
+				{Name: "steps", Fields: []analyzer.Field{
+					{Type: "uses", Value: "actions/checkout@v4"},
+					{Type: "run", Value: "go vet\ngo test"},
+				}},
+			},
+		},
+		{
+			Name: "deploy",
+			Kind: "job",
+			Fields: []analyzer.Field{
+				{Name: "needs", Fields: []analyzer.Field{{Value: "test"}}},
+				{Name: "uses", Value: "./.github/workflows/deploy.yml"},
+				{Name: "with", Fields: []analyzer.Field{{Name: "environment", Value: "prod"}}},
+			},
+		},
+	}
+	jobs[1].DocYAML.Summary = "Ships the release"
+
+	got := formatJobs(jobs)
+	for _, want := range []string{
+		"### 🧱 Jobs (2)",
+		"| [`test`](#test) | `ubuntu-latest` |  | 2 |",
+		"| [`deploy`](#deploy) | `reusable: ./.github/workflows/deploy.yml` | `test` | 0 |",
+		"- **matrix:** `os`, `go`",
+		"**Permissions:** `read-all`",
+		"1. `actions/checkout@v4`",
+		"2. **run**\n   ```bash\n   go vet\n   go test\n   ```",
+		"Ships the release",
+		"- **with:** `environment: prod`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("jobs are missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatAction(t *testing.T) {
+	action := analyzer.Struct{
+		Name: "Setup tools",
+		Kind: "action",
+		Fields: []analyzer.Field{
+			{Name: "inputs", Fields: []analyzer.Field{
+				{Name: "version", Modifiers: []string{"required"}},
+				{Name: "cache", Value: "true", Modifiers: []string{"deprecated"}},
+			}},
+			{Name: "outputs", Fields: []analyzer.Field{{Name: "path", Value: "${{ steps.install.outputs.path }}"}}},
+			{Name: "runs", Value: "composite"},
+			{Name: "secrets", Fields: []analyzer.Field{{Value: "CACHE_SALT"}}},
+		},
+	}
+	action.Fields[0].Fields[0].DocYAML.Summary = "Version to install"
+
+	got := formatAction(action)
+	for _, want := range []string{
+		"### 🧩 Action: `Setup tools`",
+		"**Runs using:** `composite`",
+		"| `version` | ✅ |  | Version to install |",
+		"| `cache` |  | `true` | _deprecated_ |",
+		"| `path` | `${{ steps.install.outputs.path }}` |  |",
+		"**Secrets:** `CACHE_SALT`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("action is missing %q:\n%s", want, got)
+		}
+	}
+}
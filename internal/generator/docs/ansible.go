@@ -0,0 +1,308 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	aiTypes "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
+)
+
+// playSettings are the play keys shown under the play summary
+var playSettings = []string{"hosts", "become", "become_user", "remote_user", "gather_facts", "connection", "strategy", "serial", "vars_files", "tags"}
+
+// taskSections are the task lists of a play, in the order Ansible runs them
+var taskSections = []struct{ key, title string }{
+	{"pre_tasks", "Pre-tasks"},
+	{"tasks", "Tasks"},
+	{"post_tasks", "Post-tasks"},
+}
+
+// GenerateAnsibleDoc writes the page of an Ansible role, or appends a
+// playbook to the Ansible page
+func GenerateAnsibleDoc(pkg analyzer.Package, filePath string, cfg docTypes.GeneratorConfig) error {
+	docDir := docUtils.PackageDocDir(pkg)
+	pkgDir := filepath.Join(cfg.OutputDir, docDir)
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+
+	readmePath := filepath.Join(pkgDir, "README.md")
+	var existingContent []byte
+	if _, err := os.Stat(readmePath); err == nil {
+		existingContent, _ = os.ReadFile(readmePath)
+	}
+
+	isRole := len(pkg.Structs) > 0 && pkg.Structs[0].Kind == "role"
+	var b strings.Builder
+	if len(existingContent) > 0 {
+		b.Write(existingContent)
+		b.WriteString("\n---\n\n")
+	} else if isRole {
+		b.WriteString(fmt.Sprintf("# 🎭 Role: `%s`\n\n", pkg.Name))
+		b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
+	} else {
+		b.WriteString(fmt.Sprintf("# 🎭 %s\n\n", pkg.Name))
+		b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
+	}
+
+	if isRole {
+		b.WriteString(fmt.Sprintf("> 📍 Path: `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+		b.WriteString(formatRole(pkg.Structs))
+	} else {
+		b.WriteString(fmt.Sprintf("## 📄 Playbook: `%s`\n\n", filepath.Base(filePath)))
+		b.WriteString(fmt.Sprintf("> 📍 Path: `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+		for _, s := range pkg.Structs {
+			b.WriteString(formatPlay(s))
+		}
+	}
+
+	return os.WriteFile(readmePath, []byte(b.String()), 0644)
+}
+
+// formatRole renders a role's metadata, variables, task files, handlers and tags
+func formatRole(structs []analyzer.Struct) string {
+	var b strings.Builder
+	var tasks, handlers []analyzer.Field
+	var taskFiles, handlerFiles []analyzer.Struct
+	for _, s := range structs {
+		switch s.Kind {
+		case "role":
+			b.WriteString(formatActionsSummary(s))
+			info := analyzer.Struct{Fields: jobField(s, "galaxy_info").Fields}
+			details := 0
+			for _, key := range []string{"author", "license", "min_ansible_version"} {
+				if value := jobField(info, key).Value; value != "" {
+					b.WriteString(fmt.Sprintf("- **%s:** `%s`\n", key, value))
+					details++
+				}
+			}
+			var deps []string
+			for _, dep := range jobField(s, "dependencies").Fields {
+				name := dep.Value
+				if name == "" {
+					name = jobField(analyzer.Struct{Fields: dep.Fields}, "role").Value
+				}
+				deps = append(deps, "`"+name+"`")
+			}
+			if len(deps) > 0 {
+				b.WriteString("- **dependencies:** " + strings.Join(deps, ", ") + "\n")
+				details++
+			}
+			if details > 0 {
+				b.WriteString("\n")
+			}
+		case "variables":
+			b.WriteString(formatAnsibleVariables("## 🔧 Variables", s.Fields, s.DocYAML.Fields))
+		case "tasks":
+			taskFiles = append(taskFiles, s)
+			tasks = append(tasks, s.Fields...)
+		case "handlers":
+			handlerFiles = append(handlerFiles, s)
+			handlers = append(handlers, s.Fields...)
+		}
+	}
+
+	if len(taskFiles) > 0 {
+		b.WriteString(fmt.Sprintf("## 📋 Tasks (%d)\n\n", len(tasks)))
+		for _, file := range taskFiles {
+			b.WriteString(fmt.Sprintf("### `%s`\n\n", file.Name))
+			b.WriteString(formatActionsSummary(file))
+			b.WriteString(formatAnsibleTasks(file.Fields))
+		}
+	}
+	if len(handlerFiles) > 0 {
+		b.WriteString(fmt.Sprintf("## 🔔 Handlers (%d)\n\n", len(handlers)))
+		b.WriteString(formatAnsibleHandlers(handlers, tasks))
+	}
+	b.WriteString(formatAnsibleTags("## 🏷️ Tags", tasks))
+	return b.String()
+}
+
+// formatPlay renders one play of a playbook, or a playbook import
+func formatPlay(s analyzer.Struct) string {
+	var b strings.Builder
+	if s.Kind == "import" {
+		b.WriteString(fmt.Sprintf("### ↪️ Imports `%s`\n\n", s.Name))
+		b.WriteString(formatActionsSummary(s))
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("### ▶️ Play: %s\n\n", s.Name))
+	b.WriteString(formatActionsSummary(s))
+	details := 0
+	for _, key := range playSettings {
+		if value := jobField(s, key).Value; value != "" {
+			b.WriteString(fmt.Sprintf("- **%s:** `%s`\n", key, value))
+			details++
+		}
+	}
+	if details > 0 {
+		b.WriteString("\n")
+	}
+
+	if vars := jobField(s, "vars").Fields; len(vars) > 0 {
+		b.WriteString(formatAnsibleVariables("**Variables:**", vars, s.DocYAML.Fields))
+	}
+	if roles := jobField(s, "roles").Fields; len(roles) > 0 {
+		b.WriteString("**Roles:**\n\n")
+		for _, role := range roles {
+			line := fmt.Sprintf("- `%s`", role.Name)
+			for _, f := range role.Fields {
+				line += fmt.Sprintf(" — %s: `%s`", f.Name, f.Value)
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	var tasks []analyzer.Field
+	for _, section := range taskSections {
+		list := jobField(s, section.key).Fields
+		if len(list) == 0 {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("**%s (%d):**\n\n", section.title, len(list)))
+		b.WriteString(formatAnsibleTasks(list))
+		tasks = append(tasks, list...)
+	}
+	if handlers := jobField(s, "handlers").Fields; len(handlers) > 0 {
+		b.WriteString(fmt.Sprintf("**Handlers (%d):**\n\n", len(handlers)))
+		b.WriteString(formatAnsibleHandlers(handlers, tasks))
+	}
+	b.WriteString(formatAnsibleTags("**Tags:**", tasks))
+	return b.String()
+}
+
+// formatAnsibleVariables renders variables with their defaults and where
+// they are defined. Comments win over the AI descriptions.
+func formatAnsibleVariables(title string, vars []analyzer.Field, described []aiTypes.YAMLField) string {
+	descriptions := make(map[string]string)
+	for _, f := range described {
+		descriptions[f.Name] = f.Description
+	}
+
+	var b strings.Builder
+	b.WriteString(title + "\n\n")
+	b.WriteString("| Variable | Type | Default | Defined in | Description |\n")
+	b.WriteString("|----------|------|---------|------------|-------------|\n")
+	for _, v := range vars {
+		description := v.DocYAML.Summary
+		if description == "" {
+			description = descriptions[v.Name]
+		}
+		if v.Origin != "" {
+			description = strings.TrimSpace(description + " _(from `" + v.Origin + "`)_")
+		}
+		b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n", v.Name, v.Type, tableCode(firstLine(v.Value)), v.DefinedIn, tableText(description)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatAnsibleTasks renders tasks as a table of their module, tags,
+// notified handlers and conditions
+func formatAnsibleTasks(tasks []analyzer.Field) string {
+	var b strings.Builder
+	b.WriteString("| # | Task | Module | Tags | Notifies | When |\n")
+	b.WriteString("|---|------|--------|------|----------|------|\n")
+	for i, task := range tasks {
+		t := analyzer.Struct{Fields: task.Fields}
+		b.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s | %s |\n", i+1,
+			tableText(task.Name),
+			tableCode(task.Type),
+			codeList(jobField(t, "tags").Value),
+			codeList(jobField(t, "notify").Value),
+			tableCode(jobField(t, "when").Value)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatAnsibleHandlers renders handlers with the tasks notifying them, by
+// name or through a listen topic
+func formatAnsibleHandlers(handlers, tasks []analyzer.Field) string {
+	var b strings.Builder
+	b.WriteString("| Handler | Module | Listens to | Notified by |\n")
+	b.WriteString("|---------|--------|------------|-------------|\n")
+	for _, h := range handlers {
+		listen := jobField(analyzer.Struct{Fields: h.Fields}, "listen").Value
+		names := append([]string{h.Name}, splitList(listen)...)
+
+		var notifiedBy []string
+		for _, task := range tasks {
+			for _, n := range splitList(jobField(analyzer.Struct{Fields: task.Fields}, "notify").Value) {
+				if containsName(names, n) {
+					notifiedBy = append(notifiedBy, tableText(task.Name))
+					break
+				}
+			}
+		}
+		if len(notifiedBy) == 0 {
+			notifiedBy = []string{"⚠️ never notified"}
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", tableText(h.Name), tableCode(h.Type), codeList(listen), strings.Join(notifiedBy, ", ")))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatAnsibleTags renders each tag with the tasks carrying it
+func formatAnsibleTags(title string, tasks []analyzer.Field) string {
+	var tags []string
+	tagged := make(map[string][]string)
+	for _, task := range tasks {
+		for _, tag := range splitList(jobField(analyzer.Struct{Fields: task.Fields}, "tags").Value) {
+			if _, ok := tagged[tag]; !ok {
+				tags = append(tags, tag)
+			}
+			tagged[tag] = append(tagged[tag], tableText(task.Name))
+		}
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(title + "\n\n")
+	b.WriteString("| Tag | Tasks |\n")
+	b.WriteString("|-----|-------|\n")
+	for _, tag := range tags {
+		b.WriteString(fmt.Sprintf("| `%s` | %s |\n", tag, strings.Join(tagged[tag], ", ")))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// splitList splits the comma-separated lists the analyzer records
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// codeList formats a comma-separated list as code spans for a table cell
+func codeList(s string) string {
+	var items []string
+	for _, item := range splitList(s) {
+		items = append(items, tableCode(item))
+	}
+	return strings.Join(items, ", ")
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"html"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,17 +14,23 @@ import (
 )
 
 func GeneratePackageDoc(pkg analyzer.Package, filePath string, cfg docTypes.GeneratorConfig) error {
-	pkgDir := filepath.Join(cfg.OutputDir, pkg.Name)
+	docDir := docUtils.PackageDocDir(pkg)
+	pkgDir := filepath.Join(cfg.OutputDir, docDir)
 
 	if err := os.MkdirAll(pkgDir, 0755); err != nil {
 		return fmt.Errorf("failed to create package directory: %w", err)
 	}
 
 	readmePath := filepath.Join(pkgDir, "README.md")
+	lang := docUtils.GetLanguage(filePath)
+	fence := codeFence(lang)
 
+	// Aggregated packages own their page; per-file packages share it
 	var existingContent []byte
-	if _, err := os.Stat(readmePath); err == nil {
-		existingContent, _ = os.ReadFile(readmePath)
+	if len(pkg.Files) == 0 {
+		if _, err := os.Stat(readmePath); err == nil {
+			existingContent, _ = os.ReadFile(readmePath)
+		}
 	}
 
 	var b strings.Builder
@@ -34,18 +41,45 @@ func GeneratePackageDoc(pkg analyzer.Package, filePath string, cfg docTypes.Gene
 	} else {
 		// Package header with breadcrumbs
 		b.WriteString(fmt.Sprintf("# 📦 Package: `%s`\n\n", pkg.Name))
-		b.WriteString("[← Back to Overview](../README.md)\n\n")
+		if pkg.ImportPath != "" {
+			b.WriteString(fmt.Sprintf("> 🔗 Import path: `%s`\n\n", pkg.ImportPath))
+		}
+		b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
 	}
 
-	// Add file-specific section
-	b.WriteString(fmt.Sprintf("## 📄 File: `%s`\n\n", filepath.Base(filePath)))
-	b.WriteString(fmt.Sprintf("> 📍 `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+	if len(pkg.Files) > 0 {
+		// One section listing every file of the package
+		b.WriteString(fmt.Sprintf("## 📄 Files (%d)\n\n", len(pkg.Files)))
+		for _, f := range pkg.Files {
+			b.WriteString(fmt.Sprintf("- `%s`\n", docUtils.GetDisplayPath(f)))
+		}
+		b.WriteString("\n")
+	} else {
+		// Add file-specific section
+		b.WriteString(fmt.Sprintf("## 📄 File: `%s`\n\n", filepath.Base(filePath)))
+		b.WriteString(fmt.Sprintf("> 📍 `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+	}
 
 	// TOC for package
 	b.WriteString("## 📑 Contents\n\n")
 	if len(pkg.Structs) > 0 {
 		b.WriteString(fmt.Sprintf("- [🧱 Structs (%d)](#-structs)\n", len(pkg.Structs)))
 	}
+	if len(pkg.Interfaces) > 0 {
+		b.WriteString(fmt.Sprintf("- [🧩 Interfaces (%d)](#-interfaces)\n", len(pkg.Interfaces)))
+	}
+	if len(pkg.Types) > 0 {
+		b.WriteString(fmt.Sprintf("- [🔖 Types (%d)](#-types)\n", len(pkg.Types)))
+	}
+	if len(pkg.Enums) > 0 {
+		b.WriteString(fmt.Sprintf("- [🎛️ Enums (%d)](#️-enums)\n", len(pkg.Enums)))
+	}
+	if len(pkg.Consts) > 0 {
+		b.WriteString(fmt.Sprintf("- [🔢 Constants (%d)](#-constants)\n", len(pkg.Consts)))
+	}
+	if len(pkg.Vars) > 0 {
+		b.WriteString(fmt.Sprintf("- [🌐 Variables (%d)](#-variables)\n", len(pkg.Vars)))
+	}
 	if len(pkg.Funcs) > 0 {
 		b.WriteString(fmt.Sprintf("- [🔧 Functions (%d)](#-functions)\n", len(pkg.Funcs)))
 	}
@@ -56,20 +90,67 @@ func GeneratePackageDoc(pkg analyzer.Package, filePath string, cfg docTypes.Gene
 		b.WriteString("## 🧱 Structs\n\n")
 		for _, s := range pkg.Structs {
 			b.WriteString(fmt.Sprintf("### `%s`\n\n", s.Name))
-			b.WriteString("```go\n" + FormatStruct(s) + "\n```\n\n")
-			b.WriteString(formatDocumentation(s.Doc))
+			b.WriteString("```" + fence + "\n" + FormatStructAs(lang, s) + "\n```\n\n")
+			b.WriteString(formatRelations("Implements", s.Implements))
+			b.WriteString(formatPromoted(s.Promoted))
+			b.WriteString(formatDocumentation(s.Doc, fence))
 			b.WriteString("\n---\n\n")
 		}
 	}
 
+	// Interfaces section with method sets
+	if len(pkg.Interfaces) > 0 {
+		b.WriteString("## 🧩 Interfaces\n\n")
+		for _, i := range pkg.Interfaces {
+			b.WriteString(fmt.Sprintf("### `%s`\n\n", i.Name))
+			b.WriteString("```" + fence + "\n" + FormatInterfaceAs(lang, i) + "\n```\n\n")
+			b.WriteString(formatRelations("Implemented by", i.Implementations))
+			b.WriteString(formatDocumentation(i.Doc, fence))
+			b.WriteString("\n---\n\n")
+		}
+	}
+
+	// Named types and aliases
+	if len(pkg.Types) > 0 {
+		b.WriteString("## 🔖 Types\n\n")
+		for _, t := range pkg.Types {
+			b.WriteString(fmt.Sprintf("### `%s`\n\n", t.Name))
+			b.WriteString("```" + fence + "\n" + FormatTypeDefAs(lang, t) + "\n```\n\n")
+			b.WriteString(formatRelations("Implements", t.Implements))
+			b.WriteString(formatDocumentation(t.Doc, fence))
+			b.WriteString("\n---\n\n")
+		}
+	}
+
+	// Enumerations
+	if len(pkg.Enums) > 0 {
+		b.WriteString("## 🎛️ Enums\n\n")
+		for _, e := range pkg.Enums {
+			b.WriteString(fmt.Sprintf("### `%s`\n\n", e.Name))
+			b.WriteString("```" + fence + "\n" + FormatEnumAs(lang, e) + "\n```\n\n")
+			b.WriteString(formatDocumentation(e.Doc, fence))
+			b.WriteString("\n---\n\n")
+		}
+	}
+
+	// Const and var blocks
+	if len(pkg.Consts) > 0 {
+		b.WriteString("## 🔢 Constants\n\n")
+		b.WriteString(formatValueGroups(pkg.Consts, fence))
+	}
+	if len(pkg.Vars) > 0 {
+		b.WriteString("## 🌐 Variables\n\n")
+		b.WriteString(formatValueGroups(pkg.Vars, fence))
+	}
+
 	// Functions section with expandable details
 	if len(pkg.Funcs) > 0 {
 		b.WriteString("## 🔧 Functions\n\n")
 		for _, f := range pkg.Funcs {
 			b.WriteString("<details>\n")
-			b.WriteString(fmt.Sprintf("<summary><b><code>%s(%s)</code></b></summary>\n\n",
-				f.Name, formatParams(f.Parameters)))
-			b.WriteString(formatDocumentation(f.Doc))
+			b.WriteString(fmt.Sprintf("<summary><b><code>%s%s(%s)</code></b></summary>\n\n",
+				f.Name, html.EscapeString(formatTypeParamsAs(lang, f.TypeParams)), html.EscapeString(formatParamsAs(lang, f.Parameters))))
+			b.WriteString(formatDocumentation(f.Doc, fence))
 			b.WriteString("\n</details>\n\n")
 		}
 	}
@@ -77,14 +158,24 @@ func GeneratePackageDoc(pkg analyzer.Package, filePath string, cfg docTypes.Gene
 	return os.WriteFile(readmePath, []byte(b.String()), 0644)
 }
 
-// formatDocumentation formats a full Documentation struct to Markdown
-func formatDocumentation(doc aiTypes.Documentation) string {
-	if doc.Summary == "" {
+// formatDocumentation formats a full Documentation struct to Markdown, with
+// the usage example fenced as fence
+func formatDocumentation(doc aiTypes.Documentation, fence string) string {
+	if doc.Summary == "" && len(doc.Parameters) == 0 && doc.Returns == "" && len(doc.Throws) == 0 && !doc.Deprecated {
 		return "_No documentation available._\n"
 	}
 
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("**Summary:** %s\n\n", doc.Summary))
+	if doc.Deprecated {
+		if doc.DeprecationNote != "" {
+			b.WriteString(fmt.Sprintf("> ⚠️ **Deprecated:** %s\n\n", doc.DeprecationNote))
+		} else {
+			b.WriteString("> ⚠️ **Deprecated**\n\n")
+		}
+	}
+	if doc.Summary != "" {
+		b.WriteString(fmt.Sprintf("**Summary:** %s\n\n", doc.Summary))
+	}
 
 	if len(doc.Parameters) > 0 {
 		b.WriteString("**Parameters:**\n")
@@ -98,6 +189,14 @@ func formatDocumentation(doc aiTypes.Documentation) string {
 		b.WriteString(fmt.Sprintf("**Returns:** %s\n\n", doc.Returns))
 	}
 
+	if len(doc.Throws) > 0 {
+		b.WriteString("**Throws:**\n")
+		for _, t := range doc.Throws {
+			b.WriteString(fmt.Sprintf("- %s\n", t))
+		}
+		b.WriteString("\n")
+	}
+
 	if doc.TimeComplexity != "" || doc.SpaceComplexity != "" {
 		b.WriteString("**Complexity:**\n")
 		if doc.TimeComplexity != "" {
@@ -111,7 +210,7 @@ func formatDocumentation(doc aiTypes.Documentation) string {
 
 	if doc.UsageExample != "" {
 		b.WriteString("**Example:**\n")
-		b.WriteString(fmt.Sprintf("```go\n%s\n```\n\n", doc.UsageExample))
+		b.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", fence, doc.UsageExample))
 	}
 
 	if len(doc.EdgeCases) > 0 {
@@ -125,35 +224,228 @@ func formatDocumentation(doc aiTypes.Documentation) string {
 	return b.String()
 }
 
+// formatParamsAs renders a parameter list in the syntax of lang
+func formatParamsAs(lang string, params []analyzer.Parameter) string {
+	switch lang {
+	case "Python":
+		return formatPythonParams(params)
+	case "TypeScript", "JavaScript":
+		return formatTSParams(params)
+	}
+	return formatParams(params)
+}
+
+// formatTypeParamsAs renders a type parameter list in the syntax of lang
+func formatTypeParamsAs(lang string, params []analyzer.Parameter) string {
+	if lang == "TypeScript" || lang == "JavaScript" {
+		return formatTSTypeParams(params)
+	}
+	return formatTypeParams(params)
+}
+
 func formatParams(params []analyzer.Parameter) string {
 	var parts []string
 	for _, p := range params {
-		parts = append(parts, fmt.Sprintf("%s %s", p.Name, p.Type))
+		parts = append(parts, strings.TrimSpace(p.Name+" "+p.Type))
 	}
 	return strings.Join(parts, ", ")
 }
 
-func FormatFunction(f analyzer.Function) string {
+// formatRelations lists type names linked by the type checker, if any
+func formatRelations(label string, names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = "`" + n + "`"
+	}
+	return fmt.Sprintf("**%s:** %s\n\n", label, strings.Join(quoted, ", "))
+}
+
+func formatPromoted(methods []analyzer.Function) string {
+	if len(methods) == 0 {
+		return ""
+	}
 	var b strings.Builder
-	if f.Receiver != "" {
-		b.WriteString(fmt.Sprintf("func (%s) %s(", f.Receiver, f.Name))
-	} else {
-		b.WriteString(fmt.Sprintf("func %s(", f.Name))
+	b.WriteString("**Promoted methods:**\n")
+	for _, m := range methods {
+		b.WriteString(fmt.Sprintf("- `%s%s` (from `%s`)\n", m.Name, formatSignature(m), m.Receiver))
 	}
-	b.WriteString(formatParams(f.Parameters))
-	b.WriteString(")")
-	if len(f.Results) > 0 {
-		b.WriteString(" " + formatParams(f.Results))
+	b.WriteString("\n")
+	return b.String()
+}
+
+func formatValueGroups(groups []analyzer.ValueGroup, fence string) string {
+	var b strings.Builder
+	for _, g := range groups {
+		b.WriteString(fmt.Sprintf("### %s\n\n", valueGroupTitle(g)))
+		b.WriteString("```" + fence + "\n" + FormatValueGroup(g) + "\n```\n\n")
+		b.WriteString(formatDocumentation(g.Doc, fence))
+		b.WriteString("\n---\n\n")
 	}
 	return b.String()
 }
 
+// valueGroupTitle names a const/var block after its first and last values
+func valueGroupTitle(g analyzer.ValueGroup) string {
+	switch len(g.Values) {
+	case 0:
+		return "`" + g.Kind + "`"
+	case 1:
+		return fmt.Sprintf("`%s`", g.Values[0].Name)
+	default:
+		return fmt.Sprintf("`%s` … `%s` (%d values)", g.Values[0].Name, g.Values[len(g.Values)-1].Name, len(g.Values))
+	}
+}
+
+// formatSignature renders the parameter and result lists of a function
+func formatSignature(f analyzer.Function) string {
+	sig := "(" + formatParams(f.Parameters) + ")"
+	switch {
+	case len(f.Results) == 1 && f.Results[0].Name == "":
+		sig += " " + f.Results[0].Type
+	case len(f.Results) > 0:
+		sig += " (" + formatParams(f.Results) + ")"
+	}
+	return sig
+}
+
+// formatTypeParams renders a type parameter list such as "[K comparable, V any]"
+func formatTypeParams(params []analyzer.Parameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	return "[" + formatParams(params) + "]"
+}
+
+// codeFence is the Markdown code block language for lang
+func codeFence(lang string) string {
+	switch lang {
+	case "Python":
+		return "python"
+	case "JavaScript":
+		return "javascript"
+	case "TypeScript":
+		return "typescript"
+	case "Protobuf":
+		return "protobuf"
+	default:
+		return "go"
+	}
+}
+
+// FormatFunctionAs renders a function in the syntax of lang
+func FormatFunctionAs(lang string, f analyzer.Function) string {
+	switch lang {
+	case "Python":
+		return FormatPythonFunction(f)
+	case "TypeScript", "JavaScript":
+		return FormatTSFunction(f)
+	case "Protobuf":
+		return FormatProtoRPC(f)
+	}
+	return FormatFunction(f)
+}
+
+// FormatStructAs renders a struct or class in the syntax of lang
+func FormatStructAs(lang string, s analyzer.Struct) string {
+	switch lang {
+	case "Python":
+		return FormatPythonClass(s)
+	case "TypeScript", "JavaScript":
+		return FormatTSClass(s)
+	case "Protobuf":
+		return FormatProtoMessage(s)
+	}
+	return FormatStruct(s)
+}
+
+// FormatInterfaceAs renders an interface in the syntax of lang
+func FormatInterfaceAs(lang string, i analyzer.Interface) string {
+	switch lang {
+	case "TypeScript":
+		return FormatTSInterface(i)
+	case "Protobuf":
+		return FormatProtoService(i)
+	}
+	return FormatInterface(i)
+}
+
+// FormatEnumAs renders an enum in the syntax of lang
+func FormatEnumAs(lang string, e analyzer.Enum) string {
+	if lang == "Protobuf" {
+		return FormatProtoEnum(e)
+	}
+	return FormatEnum(e)
+}
+
+// FormatTypeDefAs renders a named type or alias in the syntax of lang
+func FormatTypeDefAs(lang string, t analyzer.TypeDef) string {
+	if lang == "TypeScript" {
+		return FormatTSTypeDef(t)
+	}
+	return FormatTypeDef(t)
+}
+
+func FormatFunction(f analyzer.Function) string {
+	if f.Receiver != "" {
+		return fmt.Sprintf("func (%s) %s%s%s", f.Receiver, f.Name, formatTypeParams(f.TypeParams), formatSignature(f))
+	}
+	return fmt.Sprintf("func %s%s%s", f.Name, formatTypeParams(f.TypeParams), formatSignature(f))
+}
+
 func FormatStruct(s analyzer.Struct) string {
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("type %s struct {\n", s.Name))
+	b.WriteString(fmt.Sprintf("type %s%s struct {\n", s.Name, formatTypeParams(s.TypeParams)))
 	for _, f := range s.Fields {
 		b.WriteString(fmt.Sprintf("\t%s %s %s\n", f.Name, f.Type, f.Tag))
 	}
 	b.WriteString("}")
 	return b.String()
 }
+
+func FormatInterface(i analyzer.Interface) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("type %s%s interface {\n", i.Name, formatTypeParams(i.TypeParams)))
+	for _, e := range i.Embeds {
+		b.WriteString(fmt.Sprintf("\t%s\n", e))
+	}
+	for _, m := range i.Methods {
+		b.WriteString(fmt.Sprintf("\t%s%s\n", m.Name, formatSignature(m)))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func FormatTypeDef(t analyzer.TypeDef) string {
+	if t.Alias {
+		return fmt.Sprintf("type %s%s = %s", t.Name, formatTypeParams(t.TypeParams), t.Underlying)
+	}
+	return fmt.Sprintf("type %s%s %s", t.Name, formatTypeParams(t.TypeParams), t.Underlying)
+}
+
+func FormatValueGroup(g analyzer.ValueGroup) string {
+	formatValue := func(v analyzer.Value) string {
+		line := v.Name
+		if v.Type != "" {
+			line += " " + v.Type
+		}
+		if v.Value != "" {
+			line += " = " + v.Value
+		}
+		return line
+	}
+
+	if len(g.Values) == 1 {
+		return g.Kind + " " + formatValue(g.Values[0])
+	}
+
+	var b strings.Builder
+	b.WriteString(g.Kind + " (\n")
+	for _, v := range g.Values {
+		b.WriteString("\t" + formatValue(v) + "\n")
+	}
+	b.WriteString(")")
+	return b.String()
+}
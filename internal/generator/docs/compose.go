@@ -0,0 +1,349 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
+)
+
+// composeSections are the top-level resources of a Compose file, in page order
+var composeSections = []struct{ label, title string }{
+	{"network", "🌐 Networks"},
+	{"volume", "💾 Volumes"},
+	{"secret", "🔐 Secrets"},
+	{"config", "🗂️ Configs"},
+}
+
+// GenerateComposeDoc appends a Compose file to its directory page: the
+// service graph, the services, the ports they publish, and the networks,
+// volumes, secrets and configs they share
+func GenerateComposeDoc(pkg analyzer.Package, filePath string, cfg docTypes.GeneratorConfig) error {
+	docDir := docUtils.PackageDocDir(pkg)
+	pkgDir := filepath.Join(cfg.OutputDir, docDir)
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+
+	readmePath := filepath.Join(pkgDir, "README.md")
+	var existingContent []byte
+	if _, err := os.Stat(readmePath); err == nil {
+		existingContent, _ = os.ReadFile(readmePath)
+	}
+
+	var b strings.Builder
+	if len(existingContent) > 0 {
+		b.Write(existingContent)
+		b.WriteString("\n---\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("# 🐳 Docker Compose: `%s`\n\n", pkg.Name))
+		b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
+	}
+
+	b.WriteString(fmt.Sprintf("## 📄 File: `%s`\n\n", filepath.Base(filePath)))
+	b.WriteString(fmt.Sprintf("> 📍 Path: `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+
+	var services []analyzer.Struct
+	resources := make(map[string][]analyzer.Struct)
+	for _, s := range pkg.Structs {
+		switch kind := s.Kind; kind {
+		case "compose":
+			b.WriteString(formatActionsSummary(s))
+			for _, f := range s.Fields {
+				if f.Value != "" {
+					b.WriteString(fmt.Sprintf("- **%s:** `%s`\n", f.Name, f.Value))
+				}
+			}
+			b.WriteString("\n")
+		case "service":
+			services = append(services, s)
+		default:
+			resources[kind] = append(resources[kind], s)
+		}
+	}
+
+	if graph := formatComposeGraph(services); graph != "" {
+		b.WriteString("### 🗺️ Service Graph\n\n")
+		b.WriteString(graph + "\n")
+	}
+	b.WriteString(formatComposeServices(services))
+	b.WriteString(formatComposePorts(services))
+	for _, section := range composeSections {
+		b.WriteString(formatComposeResources(section.label, section.title, resources[section.label], services))
+	}
+
+	return os.WriteFile(readmePath, []byte(b.String()), 0644)
+}
+
+// formatComposeGraph renders services, their dependencies, and the networks
+// and named volumes they share as a Mermaid graph
+func formatComposeGraph(services []analyzer.Struct) string {
+	if len(services) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("```mermaid\ngraph LR\n")
+	node := make(map[string]string)
+	declare := func(id string) string {
+		if n, ok := node[id]; ok {
+			return n
+		}
+		n := fmt.Sprintf("c%d", len(node))
+		node[id] = n
+		kind, name, _ := strings.Cut(id, "/")
+		switch kind {
+		case "network":
+			b.WriteString(fmt.Sprintf("  %s{{\"network: %s\"}}\n", n, name))
+		case "volume":
+			b.WriteString(fmt.Sprintf("  %s[(\"volume: %s\")]\n", n, name))
+		default:
+			b.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", n, name))
+		}
+		return n
+	}
+	for _, s := range services {
+		declare("service/" + s.Name)
+	}
+	for _, s := range services {
+		from := declare("service/" + s.Name)
+		for _, dep := range analyzer.ComposeServiceDependencies(s.Fields) {
+			label := ""
+			if dep.Detail != "" {
+				label = "|" + dep.Detail + "|"
+			}
+			b.WriteString(fmt.Sprintf("  %s -->%s %s\n", from, label, declare("service/"+dep.Name)))
+		}
+		for _, network := range analyzer.ComposeServiceRefs(s.Fields, "networks") {
+			b.WriteString(fmt.Sprintf("  %s -.- %s\n", from, declare("network/"+network.Name)))
+		}
+		for _, m := range analyzer.ComposeMounts(s.Fields) {
+			if m.Type == "volume" && m.Source != "" {
+				b.WriteString(fmt.Sprintf("  %s -.-|\"%s\"| %s\n", from, m.Target, declare("volume/"+m.Source)))
+			}
+		}
+	}
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// formatComposeServices renders the service index, then each service's
+// dependencies and configuration
+func formatComposeServices(services []analyzer.Struct) string {
+	if len(services) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("### 🧱 Services (%d)\n\n", len(services)))
+	b.WriteString("| Service | Image / Build | Depends on | Networks | Mounts |\n")
+	b.WriteString("|---------|---------------|------------|----------|--------|\n")
+	for _, s := range services {
+		image := jobField(s, "image").Value
+		if build := jobField(s, "build"); build.Name != "" {
+			context := build.Value
+			if context == "" {
+				context = jobField(analyzer.Struct{Fields: build.Fields}, "context").Value
+			}
+			if image == "" {
+				image = "build: " + context
+			} else {
+				image += " (build: " + context + ")"
+			}
+		}
+
+		var deps, networks, mounts []string
+		for _, dep := range analyzer.ComposeServiceDependencies(s.Fields) {
+			deps = append(deps, "`"+dep.Name+"`"+detailSuffix(dep.Detail))
+		}
+		for _, network := range analyzer.ComposeServiceRefs(s.Fields, "networks") {
+			networks = append(networks, "`"+network.Name+"`")
+		}
+		for _, m := range analyzer.ComposeMounts(s.Fields) {
+			mounts = append(mounts, formatComposeMount(m))
+		}
+		b.WriteString(fmt.Sprintf("| [`%s`](#%s) | %s | %s | %s | %s |\n", s.Name, operationAnchor("service "+s.Name), tableCode(image),
+			strings.Join(deps, ", "), strings.Join(networks, ", "), tableText(strings.Join(mounts, ", "))))
+	}
+	b.WriteString("\n")
+
+	for _, s := range services {
+		b.WriteString(fmt.Sprintf("#### Service `%s`\n\n", s.Name))
+		b.WriteString(formatActionsSummary(s))
+		if users := composeDependents(s.Name, services); len(users) > 0 {
+			b.WriteString("- **Used by:** `" + strings.Join(users, "`, `") + "`\n\n")
+		}
+		if len(s.Fields) > 0 {
+			b.WriteString("<details>\n")
+			b.WriteString(fmt.Sprintf("<summary>⚙️ Configuration of `%s`</summary>\n\n", s.Name))
+			b.WriteString("```yaml\n")
+			b.WriteString(generateYAMLExample(docUtils.NormalizeFields(s.Fields), 0))
+			b.WriteString("```\n")
+			b.WriteString("</details>\n\n")
+		}
+	}
+	return b.String()
+}
+
+// formatComposePorts renders the ports each service publishes to the host
+// or only exposes to other services
+func formatComposePorts(services []analyzer.Struct) string {
+	var rows []string
+	for _, s := range services {
+		for _, p := range analyzer.ComposePorts(s.Fields) {
+			host := "_internal_"
+			if p.Published != "" {
+				host = tableCode(p.Published)
+				if p.HostIP != "" {
+					host = tableCode(p.HostIP + ":" + p.Published)
+				}
+			}
+			protocol := p.Protocol
+			if protocol == "" {
+				protocol = "tcp"
+			}
+			rows = append(rows, fmt.Sprintf("| `%s` | %s | %s | %s |\n", s.Name, host, tableCode(p.Target), protocol))
+		}
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("### 🔌 Ports\n\n")
+	b.WriteString("| Service | Host | Container | Protocol |\n")
+	b.WriteString("|---------|------|-----------|----------|\n")
+	for _, row := range rows {
+		b.WriteString(row)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatComposeResources renders the top-level networks, volumes, secrets or
+// configs with the services using them. Volumes also list bind mounts of
+// host paths shared by several services.
+func formatComposeResources(label, title string, resources, services []analyzer.Struct) string {
+	var b strings.Builder
+	if len(resources) > 0 {
+		b.WriteString(fmt.Sprintf("### %s (%d)\n\n", title, len(resources)))
+		b.WriteString("| Name | Settings | Description | Used by |\n")
+		b.WriteString("|------|----------|-------------|---------|\n")
+		for _, r := range resources {
+			var settings []string
+			for _, f := range r.Fields {
+				if f.Value != "" {
+					settings = append(settings, f.Name+": "+f.Value)
+				}
+			}
+			usedBy := []string{"⚠️ unused"}
+			if users := composeUsers(label, r.Name, services); len(users) > 0 {
+				usedBy = users
+			}
+			summary := r.Doc.Summary
+			if summary == "" {
+				summary = r.DocYAML.Summary
+			}
+			b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", r.Name, codeList(strings.Join(settings, ", ")), tableText(summary), strings.Join(usedBy, ", ")))
+		}
+		b.WriteString("\n")
+	}
+
+	if label == "volume" {
+		b.WriteString(formatSharedBindMounts(services))
+	}
+	return b.String()
+}
+
+// composeDependents lists the services depending on or linking to a service
+func composeDependents(name string, services []analyzer.Struct) []string {
+	var users []string
+	for _, s := range services {
+		for _, dep := range analyzer.ComposeServiceDependencies(s.Fields) {
+			if dep.Name == name {
+				users = append(users, s.Name)
+				break
+			}
+		}
+	}
+	return users
+}
+
+// composeUsers lists the services using a resource, with the mount point of
+// a volume or the target of a secret or config
+func composeUsers(label, name string, services []analyzer.Struct) []string {
+	var users []string
+	for _, s := range services {
+		var details []string
+		switch label {
+		case "volume":
+			for _, m := range analyzer.ComposeMounts(s.Fields) {
+				if m.Type == "volume" && m.Source == name {
+					details = append(details, m.Target)
+				}
+			}
+		default:
+			for _, ref := range analyzer.ComposeServiceRefs(s.Fields, label+"s") {
+				if ref.Name == name {
+					details = append(details, ref.Detail)
+				}
+			}
+		}
+		if len(details) == 0 {
+			continue
+		}
+		users = append(users, "`"+s.Name+"`"+detailSuffix(strings.Trim(strings.Join(details, ", "), ", ")))
+	}
+	return users
+}
+
+// formatSharedBindMounts lists host paths mounted into more than one service
+func formatSharedBindMounts(services []analyzer.Struct) string {
+	var sources []string
+	users := make(map[string][]string)
+	for _, s := range services {
+		for _, m := range analyzer.ComposeMounts(s.Fields) {
+			if m.Type != "bind" {
+				continue
+			}
+			if _, ok := users[m.Source]; !ok {
+				sources = append(sources, m.Source)
+			}
+			users[m.Source] = append(users[m.Source], "`"+s.Name+"` ("+m.Target+")")
+		}
+	}
+	var b strings.Builder
+	for _, source := range sources {
+		if len(users[source]) < 2 {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString("**Shared bind mounts:**\n\n")
+		}
+		b.WriteString(fmt.Sprintf("- `%s` → %s\n", source, strings.Join(users[source], ", ")))
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func formatComposeMount(m analyzer.ComposeMount) string {
+	mount := m.Target
+	if m.Source != "" {
+		mount = m.Source + ":" + m.Target
+	}
+	if m.ReadOnly {
+		mount += ":ro"
+	}
+	return "`" + mount + "`"
+}
+
+// detailSuffix appends a detail in parentheses, if any
+func detailSuffix(detail string) string {
+	if detail == "" {
+		return ""
+	}
+	return " (" + detail + ")"
+}
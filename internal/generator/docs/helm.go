@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
+)
+
+// chartMetadata lists the Chart.yaml keys shown under the chart summary
+var chartMetadata = []string{"version", "appVersion", "type", "kubeVersion", "home"}
+
+// GenerateHelmDoc writes the page of a Helm chart: its metadata and
+// dependencies, every value with its default and the templates using it,
+// and the values no template reads
+func GenerateHelmDoc(pkg analyzer.Package, filePath string, cfg docTypes.GeneratorConfig) error {
+	docDir := docUtils.PackageDocDir(pkg)
+	pkgDir := filepath.Join(cfg.OutputDir, docDir)
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+
+	readmePath := filepath.Join(pkgDir, "README.md")
+	var existingContent []byte
+	if _, err := os.Stat(readmePath); err == nil {
+		existingContent, _ = os.ReadFile(readmePath)
+	}
+
+	var b strings.Builder
+	if len(existingContent) > 0 {
+		b.Write(existingContent)
+		b.WriteString("\n---\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("# ⎈ Helm Chart: `%s`\n\n", pkg.Name))
+		b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
+	}
+	b.WriteString(fmt.Sprintf("> 📍 Path: `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+
+	var values analyzer.Struct
+	var templates []analyzer.Struct
+	for _, s := range pkg.Structs {
+		switch s.Kind {
+		case "chart":
+			b.WriteString(formatChart(s))
+		case "values":
+			values = s
+		case "template":
+			templates = append(templates, s)
+		}
+	}
+	b.WriteString(formatChartValues(values, templates))
+	b.WriteString(formatChartTemplates(templates))
+
+	return os.WriteFile(readmePath, []byte(b.String()), 0644)
+}
+
+// formatChart renders the Chart.yaml metadata, maintainers and dependencies
+func formatChart(s analyzer.Struct) string {
+	var b strings.Builder
+	b.WriteString(formatActionsSummary(s))
+
+	details := 0
+	for _, key := range chartMetadata {
+		if value := jobField(s, key).Value; value != "" {
+			b.WriteString(fmt.Sprintf("- **%s:** `%s`\n", key, value))
+			details++
+		}
+	}
+	if keywords := jobField(s, "keywords").Fields; len(keywords) > 0 {
+		var words []string
+		for _, k := range keywords {
+			words = append(words, "`"+k.Value+"`")
+		}
+		b.WriteString("- **keywords:** " + strings.Join(words, ", ") + "\n")
+		details++
+	}
+	for _, m := range jobField(s, "maintainers").Fields {
+		maintainer := jobField(analyzer.Struct{Fields: m.Fields}, "name").Value
+		if email := jobField(analyzer.Struct{Fields: m.Fields}, "email").Value; email != "" {
+			maintainer += " <" + email + ">"
+		}
+		b.WriteString("- **maintainer:** " + maintainer + "\n")
+		details++
+	}
+	if details > 0 {
+		b.WriteString("\n")
+	}
+
+	deps := jobField(s, "dependencies").Fields
+	if len(deps) == 0 {
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("## 📦 Dependencies (%d)\n\n", len(deps)))
+	b.WriteString("| Name | Version | Repository | Condition | Alias |\n")
+	b.WriteString("|------|---------|------------|-----------|-------|\n")
+	for _, dep := range deps {
+		d := analyzer.Struct{Fields: dep.Fields}
+		b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n",
+			jobField(d, "name").Value,
+			tableCode(jobField(d, "version").Value),
+			tableCode(jobField(d, "repository").Value),
+			tableCode(jobField(d, "condition").Value),
+			tableCode(jobField(d, "alias").Value)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatChartValues renders every value with its default, its description
+// and the templates reading it, then the values nothing reads
+func formatChartValues(values analyzer.Struct, templates []analyzer.Struct) string {
+	if len(values.Fields) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## ⚙️ Values (%d)\n\n", len(values.Fields)))
+	if values.Doc.Summary != "" {
+		b.WriteString(values.Doc.Summary + "\n\n")
+	}
+	if values.DocYAML.Summary != "" && values.DocYAML.Summary != values.Doc.Summary {
+		b.WriteString(values.DocYAML.Summary + "\n\n")
+	}
+
+	described := make(map[string]string)
+	for _, f := range values.DocYAML.Fields {
+		described[f.Name] = f.Description
+	}
+
+	var unused []analyzer.Field
+	b.WriteString("| Key | Type | Default | Description | Used in |\n")
+	b.WriteString("|-----|------|---------|-------------|---------|\n")
+	for _, f := range values.Fields {
+		description := f.DocYAML.Summary
+		if description == "" {
+			description = described[f.Name]
+		}
+		if f.Origin != "" {
+			description = strings.TrimSpace(description + " _(from `" + f.Origin + "`)_")
+		}
+
+		var usedIn []string
+		for _, t := range templates {
+			for _, ref := range t.Fields {
+				if analyzer.HelmValueUsed(f.Name, ref.Name) {
+					usedIn = append(usedIn, "`"+t.Name+"`")
+					break
+				}
+			}
+		}
+		if f.Unused {
+			usedIn = []string{"⚠️ unused"}
+			unused = append(unused, f)
+		}
+		b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n", f.Name, f.Type, tableCode(firstLine(f.Value)), tableText(description), strings.Join(usedIn, ", ")))
+	}
+	b.WriteString("\n")
+
+	if len(unused) > 0 {
+		b.WriteString(fmt.Sprintf("### ⚠️ Unused Values (%d)\n\n", len(unused)))
+		b.WriteString("Defined in `values.yaml` but never referenced by a template or a dependency:\n\n")
+		for _, f := range unused {
+			b.WriteString(fmt.Sprintf("- `%s`\n", f.Name))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatChartTemplates renders each template with the resources it renders
+// and the values it reads
+func formatChartTemplates(templates []analyzer.Struct) string {
+	if len(templates) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## 🧾 Templates (%d)\n\n", len(templates)))
+	b.WriteString("| Template | Renders | Values |\n")
+	b.WriteString("|----------|---------|--------|\n")
+	for _, t := range templates {
+		var refs []string
+		for _, ref := range t.Fields {
+			refs = append(refs, fmt.Sprintf("`%s` (%s)", ref.Name, ref.Value))
+		}
+		b.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", t.Name, strings.Join(t.Renders, ", "), strings.Join(refs, ", ")))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+// FormatKubernetesTopology renders the links between the project's
+// Kubernetes resources as a Mermaid graph. Resources referenced but not
+// defined in the project are drawn dashed. It returns "" when nothing links.
+func FormatKubernetesTopology(result *analyzer.AnalyzerResult) string {
+	var ids []string
+	defined := make(map[string]bool)
+	edges := make(map[string][]string)
+	for _, file := range result.Files {
+		for _, pkg := range file.Packages {
+			for _, s := range pkg.Structs {
+				if s.Kind != "kubernetes" || len(s.DependsOn) == 0 && len(s.UsedBy) == 0 {
+					continue
+				}
+				id := kubernetesStructID(s)
+				if !defined[id] {
+					defined[id] = true
+					ids = append(ids, id)
+				}
+				edges[id] = append(edges[id], s.DependsOn...)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+
+	node := make(map[string]string)
+	var b strings.Builder
+	b.WriteString("```mermaid\ngraph LR\n")
+	declare := func(id string) string {
+		if n, ok := node[id]; ok {
+			return n
+		}
+		n := fmt.Sprintf("k%d", len(node))
+		node[id] = n
+		kind, name := splitKubernetesID(id)
+		b.WriteString(fmt.Sprintf("  %s[\"%s<br/>%s\"]\n", n, kind, name))
+		if !defined[id] {
+			b.WriteString(fmt.Sprintf("  class %s external\n", n))
+		}
+		return n
+	}
+	for _, id := range ids {
+		declare(id)
+	}
+	for _, id := range ids {
+		from := declare(id)
+		fromKind, _ := splitKubernetesID(id)
+		for _, dep := range edges[id] {
+			to := declare(dep)
+			toKind, _ := splitKubernetesID(dep)
+			b.WriteString(fmt.Sprintf("  %s -->|%s| %s\n", from, kubernetesRelation(fromKind, toKind), to))
+		}
+	}
+	b.WriteString("  classDef external stroke-dasharray: 5 5\n")
+	b.WriteString("```\n")
+	return b.String()
+}
+
+// formatKubernetesLinks renders the "depends on / used by" section of a resource
+func formatKubernetesLinks(s analyzer.Struct) string {
+	if len(s.DependsOn) == 0 && len(s.UsedBy) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("🔗 Relationships\n\n")
+	if len(s.DependsOn) > 0 {
+		b.WriteString("- **Depends on:** `" + strings.Join(s.DependsOn, "`, `") + "`\n")
+	}
+	if len(s.UsedBy) > 0 {
+		b.WriteString("- **Used by:** `" + strings.Join(s.UsedBy, "`, `") + "`\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// kubernetesStructID rebuilds the ID the analyzer linked a resource by
+func kubernetesStructID(s analyzer.Struct) string {
+	var name, namespace string
+	for _, f := range s.Fields {
+		if f.Name != "metadata" {
+			continue
+		}
+		for _, m := range f.Fields {
+			switch m.Name {
+			case "name":
+				name = m.Value
+			case "namespace":
+				namespace = m.Value
+			}
+		}
+	}
+	return analyzer.KubernetesID(s.Name, name, namespace)
+}
+
+// splitKubernetesID returns the kind and the (namespaced) name of an ID
+func splitKubernetesID(id string) (string, string) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 3 {
+		return parts[1], parts[0] + "/" + parts[2]
+	}
+	kind, name, _ := strings.Cut(id, "/")
+	return kind, name
+}
+
+// kubernetesRelation labels the edge from a resource to one it depends on
+func kubernetesRelation(from, to string) string {
+	switch {
+	case from == "Service":
+		return "selects"
+	case from == "Ingress" && to == "Service":
+		return "routes to"
+	case from == "Ingress":
+		return "TLS"
+	case from == "HorizontalPodAutoscaler":
+		return "scales"
+	case to == "PersistentVolumeClaim":
+		return "mounts"
+	}
+	return "uses"
+}
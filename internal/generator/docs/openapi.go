@@ -0,0 +1,235 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
+)
+
+// GenerateOpenAPIDoc writes the page of an OpenAPI package: the API overview
+// with its schemas and security schemes, or the endpoint reference of a tag
+func GenerateOpenAPIDoc(pkg analyzer.Package, filePath string, cfg docTypes.GeneratorConfig) error {
+	docDir := docUtils.PackageDocDir(pkg)
+	pkgDir := filepath.Join(cfg.OutputDir, docDir)
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# 🌐 API: `%s`\n\n", pkg.Name))
+	b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
+	b.WriteString(fmt.Sprintf("## 📄 File: `%s`\n\n", filepath.Base(filePath)))
+	b.WriteString(fmt.Sprintf("> 📍 Path: `%s`\n\n", docUtils.GetDisplayPath(filePath)))
+
+	var schemas, schemes []analyzer.Struct
+	for _, s := range pkg.Structs {
+		switch s.Kind {
+		case "info":
+			b.WriteString(formatAPIInfo(s))
+		case "tag":
+			if s.Doc.Summary != "" {
+				b.WriteString(s.Doc.Summary + "\n\n")
+			}
+		case "schema":
+			schemas = append(schemas, s)
+		case "securityScheme":
+			schemes = append(schemes, s)
+		}
+	}
+
+	if len(pkg.Funcs) > 0 {
+		b.WriteString(formatEndpoints(pkg.Funcs))
+	}
+
+	if len(schemas) > 0 {
+		b.WriteString(fmt.Sprintf("## 🧱 Schemas (%d)\n\n", len(schemas)))
+		for _, s := range schemas {
+			b.WriteString(fmt.Sprintf("### `%s`\n\n", s.Name))
+			if s.Doc.Summary != "" {
+				b.WriteString(s.Doc.Summary + "\n\n")
+			}
+			b.WriteString("| Property | Type | Required | Description |\n")
+			b.WriteString("|----------|------|----------|-------------|\n")
+			for _, f := range s.Fields {
+				typ := f.Type
+				if f.Value != "" {
+					typ += " (" + f.Value + ")"
+				}
+				required := ""
+				var flags []string
+				for _, m := range f.Modifiers {
+					if m == "required" {
+						required = "✅"
+					} else {
+						flags = append(flags, "_"+m+"_")
+					}
+				}
+				description := strings.TrimSpace(strings.Join(flags, " ") + " " + f.Doc.Summary)
+				b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", f.Name, tableCode(typ), required, tableText(description)))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(schemes) > 0 {
+		b.WriteString(fmt.Sprintf("## 🔐 Security Schemes (%d)\n\n", len(schemes)))
+		for _, s := range schemes {
+			b.WriteString(fmt.Sprintf("### `%s`\n\n", s.Name))
+			if s.Doc.Summary != "" {
+				b.WriteString(s.Doc.Summary + "\n\n")
+			}
+			for _, f := range s.Fields {
+				b.WriteString(fmt.Sprintf("- **%s:** `%s`\n", f.Name, f.Value))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return os.WriteFile(filepath.Join(pkgDir, "README.md"), []byte(b.String()), 0644)
+}
+
+func formatAPIInfo(s analyzer.Struct) string {
+	var b strings.Builder
+	if s.Doc.Summary != "" {
+		b.WriteString(s.Doc.Summary + "\n\n")
+	}
+	for _, f := range s.Fields {
+		line := fmt.Sprintf("- **%s:** `%s`", strings.ToUpper(f.Name[:1])+f.Name[1:], f.Value)
+		if f.Doc.Summary != "" {
+			line += " — " + f.Doc.Summary
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatEndpoints renders the endpoint index followed by one section per operation
+func formatEndpoints(ops []analyzer.Function) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## 🔗 Endpoints (%d)\n\n", len(ops)))
+	b.WriteString("| Method | Path | Summary |\n")
+	b.WriteString("|--------|------|---------|\n")
+	for _, op := range ops {
+		method, route, _ := strings.Cut(op.Name, " ")
+		summary, _, _ := strings.Cut(op.Doc.Summary, "\n")
+		b.WriteString(fmt.Sprintf("| `%s` | [`%s`](#%s) | %s |\n", method, route, operationAnchor(op.Name), tableText(summary)))
+	}
+	b.WriteString("\n")
+
+	for _, op := range ops {
+		b.WriteString(fmt.Sprintf("### `%s`\n\n", op.Name))
+		for _, m := range op.Modifiers {
+			if m == "deprecated" {
+				b.WriteString("> ⚠️ **Deprecated**\n\n")
+			}
+		}
+		// The summary includes the description; the tables below cover the rest
+		if op.Doc.Summary != "" {
+			b.WriteString(op.Doc.Summary + "\n\n")
+		}
+
+		if len(op.Security) > 0 {
+			b.WriteString(fmt.Sprintf("**Security:** %s\n\n", "`"+strings.Join(op.Security, "`, `")+"`"))
+		}
+
+		if len(op.Parameters) > 0 {
+			b.WriteString("**Parameters:**\n\n")
+			b.WriteString("| Name | In | Type | Required | Description |\n")
+			b.WriteString("|------|----|------|----------|-------------|\n")
+			for _, p := range op.Parameters {
+				required := ""
+				if p.Required {
+					required = "✅"
+				}
+				description := p.Description
+				if p.Default != "" {
+					description = strings.TrimSpace(description + " Default: `" + p.Default + "`")
+				}
+				b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n", p.Name, p.In, tableCode(p.Type), required, tableText(description)))
+			}
+			b.WriteString("\n")
+		}
+
+		if len(op.Results) > 0 {
+			b.WriteString("**Responses:**\n\n")
+			b.WriteString("| Status | Type | Description |\n")
+			b.WriteString("|--------|------|-------------|\n")
+			for _, r := range op.Results {
+				b.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", r.Name, tableCode(r.Type), tableText(r.Description)))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// FormatOperation renders an API operation as plain text for the AI
+func FormatOperation(f analyzer.Function) string {
+	var b strings.Builder
+	b.WriteString(f.Name + "\n")
+	if len(f.Parameters) > 0 {
+		b.WriteString("Parameters:\n")
+		for _, p := range f.Parameters {
+			line := fmt.Sprintf("  %s (%s, %s", p.Name, p.In, p.Type)
+			if p.Required {
+				line += ", required"
+			}
+			line += ")"
+			if p.Description != "" {
+				line += ": " + p.Description
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if len(f.Results) > 0 {
+		b.WriteString("Responses:\n")
+		for _, r := range f.Results {
+			line := "  " + r.Name
+			if r.Type != "" {
+				line += " " + r.Type
+			}
+			if r.Description != "" {
+				line += ": " + r.Description
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if len(f.Security) > 0 {
+		b.WriteString("Security: " + strings.Join(f.Security, ", ") + "\n")
+	}
+	return b.String()
+}
+
+// operationAnchor returns the GitHub anchor of an operation heading
+func operationAnchor(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// tableCode wraps a non-empty value in backticks for a table cell
+func tableCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+// tableText keeps text on one line and escapes pipes for a table cell
+func tableText(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
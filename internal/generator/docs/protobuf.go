@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+// FormatProtoMessage renders a message with its fields and oneofs. Nested
+// messages are rendered separately under their qualified names.
+func FormatProtoMessage(s analyzer.Struct) string {
+	var b strings.Builder
+	b.WriteString("message " + s.Name[strings.LastIndex(s.Name, ".")+1:] + " {\n")
+	for _, f := range s.Fields {
+		b.WriteString(formatProtoComment(f.Doc.Summary, "  "))
+		if f.Type == "oneof" {
+			b.WriteString("  oneof " + f.Name + " {\n")
+			for _, member := range f.Fields {
+				b.WriteString(formatProtoComment(member.Doc.Summary, "    "))
+				b.WriteString("    " + formatProtoField(member) + "\n")
+			}
+			b.WriteString("  }\n")
+			continue
+		}
+		b.WriteString("  " + formatProtoField(f) + "\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// FormatProtoService renders a gRPC service and its RPCs
+func FormatProtoService(i analyzer.Interface) string {
+	var b strings.Builder
+	b.WriteString("service " + i.Name + " {\n")
+	for _, m := range i.Methods {
+		b.WriteString("  " + FormatProtoRPC(m) + "\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// FormatProtoRPC renders an RPC, including its streaming mode
+func FormatProtoRPC(f analyzer.Function) string {
+	var request, response string
+	if len(f.Parameters) > 0 {
+		request = f.Parameters[0].Type
+	}
+	if len(f.Results) > 0 {
+		response = f.Results[0].Type
+	}
+	return fmt.Sprintf("rpc %s(%s) returns (%s);", f.Name, request, response)
+}
+
+// FormatProtoEnum renders an enum and its values
+func FormatProtoEnum(e analyzer.Enum) string {
+	var b strings.Builder
+	b.WriteString("enum " + e.Name[strings.LastIndex(e.Name, ".")+1:] + " {\n")
+	for _, m := range e.Members {
+		b.WriteString(formatProtoComment(m.Doc.Summary, "  "))
+		b.WriteString(fmt.Sprintf("  %s = %s;\n", m.Name, m.Value))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func formatProtoField(f analyzer.Field) string {
+	line := fmt.Sprintf("%s %s = %s", f.Type, f.Name, f.Value)
+	if len(f.Modifiers) > 0 {
+		line = strings.Join(f.Modifiers, " ") + " " + line
+	}
+	if f.Tag != "" {
+		line += " " + f.Tag
+	}
+	return line + ";"
+}
+
+// formatProtoComment renders a field or value comment as "//" lines
+func formatProtoComment(doc, indent string) string {
+	if doc == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(doc, "\n") {
+		b.WriteString(strings.TrimRight(indent+"// "+line, " ") + "\n")
+	}
+	return b.String()
+}
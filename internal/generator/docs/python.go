@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+// FormatPythonFunction renders a function or method as a Python stub
+func FormatPythonFunction(f analyzer.Function) string {
+	var b strings.Builder
+	for _, d := range f.Decorators {
+		b.WriteString("@" + d + "\n")
+	}
+	if f.Async {
+		b.WriteString("async ")
+	}
+	b.WriteString(fmt.Sprintf("def %s(%s)", f.Name, formatPythonParams(f.Parameters)))
+	if len(f.Results) > 0 && f.Results[0].Type != "" {
+		b.WriteString(" -> " + f.Results[0].Type)
+	}
+	b.WriteString(": ...")
+	return b.String()
+}
+
+// FormatPythonClass renders a class with its attributes and method stubs
+func FormatPythonClass(s analyzer.Struct) string {
+	var b strings.Builder
+	for _, d := range s.Decorators {
+		b.WriteString("@" + d + "\n")
+	}
+	b.WriteString("class " + s.Name)
+	if len(s.Bases) > 0 {
+		b.WriteString("(" + strings.Join(s.Bases, ", ") + ")")
+	}
+	b.WriteString(":\n")
+
+	for _, f := range s.Fields {
+		b.WriteString("    " + formatPythonParam(analyzer.Parameter{Name: f.Name, Type: f.Type, Default: f.Value}) + "\n")
+	}
+	for _, m := range s.Methods {
+		for _, line := range strings.Split(FormatPythonFunction(m), "\n") {
+			b.WriteString("    " + line + "\n")
+		}
+	}
+	if len(s.Fields) == 0 && len(s.Methods) == 0 {
+		b.WriteString("    ...\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func formatPythonParams(params []analyzer.Parameter) string {
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = formatPythonParam(p)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatPythonParam renders "name: type = default", or "name=default" when untyped
+func formatPythonParam(p analyzer.Parameter) string {
+	switch {
+	case p.Type != "" && p.Default != "":
+		return fmt.Sprintf("%s: %s = %s", p.Name, p.Type, p.Default)
+	case p.Type != "":
+		return p.Name + ": " + p.Type
+	case p.Default != "":
+		return p.Name + "=" + p.Default
+	}
+	return p.Name
+}
@@ -21,7 +21,7 @@ func GenerateProjectReadme(result *analyzer.AnalyzerResult, cfg cfg.GeneratorCon
 
 	if cfg.Project.RepoURL != "" {
 		b.WriteString(fmt.Sprintf(
-			"[![Go](https://img.shields.io/badge/Go-%E2%9D%A4%EF%B8%8F-blue)](%s) "+
+			"[![Go](https://img.shields.io/badge/Go-%%E2%%9D%%A4%%EF%%B8%%8F-blue)](%s) "+
 				"[![GitHub](https://img.shields.io/badge/GitHub-Repository-lightgrey)](%s)\n\n",
 			cfg.Project.RepoURL, cfg.Project.RepoURL,
 		))
@@ -50,26 +50,51 @@ func GenerateProjectReadme(result *analyzer.AnalyzerResult, cfg cfg.GeneratorCon
 	b.WriteString("## 📦 Packages\n\n")
 	b.WriteString("> Explore each documented package below:\n\n")
 	b.WriteString("<table>\n<tr>\n")
-	count := 0
+
+	// Packages sharing an import path, such as the CI/CD page, get one card
+	var locations []string
+	cards := make(map[string]analyzer.Package)
 	for _, file := range result.Files {
 		for _, pkg := range file.Packages {
-			docPath := filepath.Join(pkg.Name, "README.md")
-			b.WriteString("<td valign=\"top\" width=\"33%\">\n\n")
-			b.WriteString(fmt.Sprintf("### [%s](%s)\n", pkg.Name, docPath))
-			b.WriteString(fmt.Sprintf("<small>`%s`</small><br/>\n", file.Path))
-			b.WriteString(fmt.Sprintf("📘 %d structs<br/>\n", len(pkg.Structs)))
-			b.WriteString(fmt.Sprintf("🛠 %d functions<br/>\n", len(pkg.Funcs)))
-			b.WriteString(fmt.Sprintf("📊 %d%% documented\n", generator.CalculateDocCompletion(pkg)))
-			b.WriteString("</td>\n")
-
-			count++
-			if count%3 == 0 {
-				b.WriteString("</tr>\n<tr>\n")
+			location := file.Path
+			if pkg.ImportPath != "" {
+				location = pkg.ImportPath
 			}
+			card, ok := cards[location]
+			if !ok {
+				locations = append(locations, location)
+				cards[location] = pkg
+				continue
+			}
+			card.Structs = append(card.Structs, pkg.Structs...)
+			card.Funcs = append(card.Funcs, pkg.Funcs...)
+			cards[location] = card
+		}
+	}
+	count := 0
+	for _, location := range locations {
+		pkg := cards[location]
+		b.WriteString("<td valign=\"top\" width=\"33%\">\n\n")
+		b.WriteString(fmt.Sprintf("### [%s](%s)\n", pkg.Name, generator.PackageDocLink(pkg)))
+		b.WriteString(fmt.Sprintf("<small>`%s`</small><br/>\n", location))
+		b.WriteString(fmt.Sprintf("📘 %d structs<br/>\n", len(pkg.Structs)))
+		b.WriteString(fmt.Sprintf("🛠 %d functions<br/>\n", len(pkg.Funcs)))
+		b.WriteString(fmt.Sprintf("📊 %d%% documented\n", generator.CalculateDocCompletion(pkg)))
+		b.WriteString("</td>\n")
+
+		count++
+		if count%3 == 0 {
+			b.WriteString("</tr>\n<tr>\n")
 		}
 	}
 	b.WriteString("</tr>\n</table>\n\n")
 
+	// Kubernetes topology (optional, when resources link to each other)
+	if topology := FormatKubernetesTopology(result); topology != "" {
+		b.WriteString("## ☸️ Kubernetes Topology\n\n")
+		b.WriteString(topology + "\n")
+	}
+
 	// Quick Start (optional, dynamic from cfg.Project.QuickStart)
 	if len(cfg.Project.QuickStart) > 0 {
 		b.WriteString("## 🚀 Quick Start\n\n")
@@ -8,6 +8,7 @@ import (
 
 	"github.com/MRGHOSJ/docupocus/internal/analyzer"
 	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
 )
 
 func GenerateSidebar(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig) error {
@@ -15,10 +16,21 @@ func GenerateSidebar(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConf
 	b.WriteString("## Navigation\n\n")
 	b.WriteString("- [🏠 Home](../README.md)\n")
 
+	// Per-file packages share a page, so list each page once
+	seen := make(map[string]bool)
 	for _, file := range result.Files {
 		for _, pkg := range file.Packages {
-			docPath := filepath.Join(pkg.Name, "README.md")
-			b.WriteString(fmt.Sprintf("- [📦 %s](%s)\n", pkg.Name, docPath))
+			docPath := docUtils.PackageDocLink(pkg)
+			if seen[docPath] {
+				continue
+			}
+			seen[docPath] = true
+
+			label := pkg.Name
+			if pkg.ImportPath != "" {
+				label = pkg.ImportPath
+			}
+			b.WriteString(fmt.Sprintf("- [📦 %s](%s)\n", label, docPath))
 		}
 	}
 
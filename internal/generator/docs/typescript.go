@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+// FormatTSFunction renders a function or method as a TypeScript signature.
+// JavaScript renders the same way, without type annotations.
+func FormatTSFunction(f analyzer.Function) string {
+	var b strings.Builder
+	for _, d := range f.Decorators {
+		b.WriteString("@" + d + "\n")
+	}
+	if f.Exported {
+		b.WriteString("export ")
+	}
+
+	generator, accessor := false, false
+	for _, m := range f.Modifiers {
+		switch m {
+		case "*":
+			generator = true
+			continue
+		case "get", "set":
+			accessor = true
+		}
+		b.WriteString(m + " ")
+	}
+	if f.Async {
+		b.WriteString("async ")
+	}
+	if f.Receiver == "" && !accessor {
+		b.WriteString("function")
+		if generator {
+			b.WriteString("*")
+		}
+		b.WriteString(" ")
+	} else if generator {
+		b.WriteString("*")
+	}
+
+	b.WriteString(fmt.Sprintf("%s%s(%s)", f.Name, formatTSTypeParams(f.TypeParams), formatTSParams(f.Parameters)))
+	if len(f.Results) > 0 && f.Results[0].Type != "" {
+		b.WriteString(": " + f.Results[0].Type)
+	}
+	return b.String()
+}
+
+// FormatTSClass renders a class with its properties and method signatures
+func FormatTSClass(s analyzer.Struct) string {
+	var b strings.Builder
+	for _, d := range s.Decorators {
+		b.WriteString("@" + d + "\n")
+	}
+	if s.Exported {
+		b.WriteString("export ")
+	}
+	for _, m := range s.Modifiers {
+		b.WriteString(m + " ")
+	}
+	b.WriteString("class " + s.Name + formatTSTypeParams(s.TypeParams))
+	if len(s.Bases) > 0 {
+		b.WriteString(" extends " + strings.Join(s.Bases, ", "))
+	}
+	if len(s.Implements) > 0 {
+		b.WriteString(" implements " + strings.Join(s.Implements, ", "))
+	}
+	b.WriteString(" {\n")
+
+	for _, f := range s.Fields {
+		b.WriteString("  " + formatTSField(f) + ";\n")
+	}
+	for _, m := range s.Methods {
+		for _, line := range strings.Split(FormatTSFunction(m), "\n") {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// FormatTSInterface renders an interface with its property and method signatures
+func FormatTSInterface(i analyzer.Interface) string {
+	var b strings.Builder
+	if i.Exported {
+		b.WriteString("export ")
+	}
+	b.WriteString("interface " + i.Name + formatTSTypeParams(i.TypeParams))
+	if len(i.Embeds) > 0 {
+		b.WriteString(" extends " + strings.Join(i.Embeds, ", "))
+	}
+	b.WriteString(" {\n")
+	for _, f := range i.Fields {
+		b.WriteString("  " + formatTSField(f) + ";\n")
+	}
+	for _, m := range i.Methods {
+		sig := fmt.Sprintf("%s%s(%s)", m.Name, formatTSTypeParams(m.TypeParams), formatTSParams(m.Parameters))
+		if len(m.Results) > 0 && m.Results[0].Type != "" {
+			sig += ": " + m.Results[0].Type
+		}
+		b.WriteString("  " + sig + ";\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// FormatTSTypeDef renders a type alias
+func FormatTSTypeDef(t analyzer.TypeDef) string {
+	alias := fmt.Sprintf("type %s%s = %s", t.Name, formatTSTypeParams(t.TypeParams), t.Underlying)
+	if t.Exported {
+		return "export " + alias
+	}
+	return alias
+}
+
+// FormatEnum renders an enum and its members
+func FormatEnum(e analyzer.Enum) string {
+	var b strings.Builder
+	if e.Exported {
+		b.WriteString("export ")
+	}
+	if e.Const {
+		b.WriteString("const ")
+	}
+	b.WriteString("enum " + e.Name + " {\n")
+	for _, m := range e.Members {
+		if m.Value != "" {
+			b.WriteString(fmt.Sprintf("  %s = %s,\n", m.Name, m.Value))
+		} else {
+			b.WriteString(fmt.Sprintf("  %s,\n", m.Name))
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func formatTSField(f analyzer.Field) string {
+	line := f.Name
+	if len(f.Modifiers) > 0 {
+		line = strings.Join(f.Modifiers, " ") + " " + line
+	}
+	if f.Type != "" {
+		line += ": " + f.Type
+	}
+	if f.Value != "" {
+		line += " = " + f.Value
+	}
+	return line
+}
+
+func formatTSParams(params []analyzer.Parameter) string {
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = formatTSParam(p, ": ")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatTSTypeParams renders "<T extends Base = Default, U>"
+func formatTSTypeParams(params []analyzer.Parameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = formatTSParam(p, " extends ")
+	}
+	return "<" + strings.Join(parts, ", ") + ">"
+}
+
+// formatTSParam renders "name: type = default", with sep between name and type
+func formatTSParam(p analyzer.Parameter, sep string) string {
+	line := p.Name
+	if p.Type != "" {
+		line += sep + p.Type
+	}
+	if p.Default != "" {
+		line += " = " + p.Default
+	}
+	return line
+}
@@ -6,18 +6,21 @@ import (
 	"path/filepath"
 	"strings"
 
+	aiTypes "github.com/MRGHOSJ/docupocus/internal/ai/types"
 	"github.com/MRGHOSJ/docupocus/internal/analyzer"
 	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
 	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
 )
 
 func GenerateYAMLDoc(pkg analyzer.Package, filePath string, cfg docTypes.GeneratorConfig) error {
-	pkgDir := filepath.Join(cfg.OutputDir, pkg.Name)
+	docDir := docUtils.PackageDocDir(pkg)
+	pkgDir := filepath.Join(cfg.OutputDir, docDir)
 	if err := os.MkdirAll(pkgDir, 0755); err != nil {
 		return fmt.Errorf("failed to create package directory: %w", err)
 	}
 
 	readmePath := filepath.Join(pkgDir, "README.md")
+	lang := docUtils.GetLanguage(filePath)
 
 	var existingContent []byte
 	if _, err := os.Stat(readmePath); err == nil {
@@ -31,8 +34,8 @@ func GenerateYAMLDoc(pkg analyzer.Package, filePath string, cfg docTypes.Generat
 		b.Write(existingContent)
 		b.WriteString("\n---\n\n")
 	} else {
-		b.WriteString(fmt.Sprintf("# 📄 YAML Configuration: `%s`\n\n", pkg.Name))
-		b.WriteString("[← Back to Overview](../README.md)\n\n")
+		b.WriteString(fmt.Sprintf("# 📄 %s Configuration: `%s`\n\n", lang, pkg.Name))
+		b.WriteString(fmt.Sprintf("[← Back to Overview](%s)\n\n", docUtils.RootLink(docDir)))
 	}
 
 	b.WriteString(fmt.Sprintf("## 📄 File: `%s`\n\n", filepath.Base(filePath)))
@@ -42,31 +45,58 @@ func GenerateYAMLDoc(pkg analyzer.Package, filePath string, cfg docTypes.Generat
 		doc := s.DocYAML
 
 		// Expandable Resource Summary
-		if doc.Summary != "" {
+		if doc.Summary != "" || s.Doc.Summary != "" {
 			b.WriteString("🚀 Resource Summary\n\n")
 			b.WriteString(fmt.Sprintf("- **Kind:** `%s`\n", s.Name))
-			b.WriteString(fmt.Sprintf("- **Description:** %s\n\n", doc.Summary))
+			if s.Doc.Summary != "" {
+				b.WriteString(fmt.Sprintf("- **Comment:** %s\n", strings.Join(strings.Fields(s.Doc.Summary), " ")))
+			}
+			if doc.Summary != "" && doc.Summary != s.Doc.Summary {
+				b.WriteString(fmt.Sprintf("- **Description:** %s\n", doc.Summary))
+			}
+			b.WriteString("\n")
 		}
 
+		// Kubernetes resources this one depends on and the ones using it
+		b.WriteString(formatKubernetesLinks(s))
+
 		// Expandable Configuration Example
 		if len(s.Fields) > 0 {
 			normalizedFields := docUtils.NormalizeFields(s.Fields)
 			b.WriteString("<details>\n")
 			b.WriteString(fmt.Sprintf("<summary>⚙️ Configuration Example for `%s`</summary>\n\n", s.Name))
-			b.WriteString("```yaml\n")
-			b.WriteString(generateYAMLExample(normalizedFields, 0))
+			switch lang {
+			case "HCL":
+				b.WriteString("```hcl\n")
+				b.WriteString(generateHCLExample(s.Labels, normalizedFields))
+			case "Dockerfile":
+				b.WriteString("```dockerfile\n")
+				b.WriteString(generateDockerfileExample(normalizedFields))
+			case "Makefile":
+				b.WriteString("```makefile\n")
+				b.WriteString(generateMakefileExample(s, normalizedFields))
+			default:
+				b.WriteString("```yaml\n")
+				b.WriteString(generateYAMLExample(normalizedFields, 0))
+			}
 			b.WriteString("```\n")
 			b.WriteString("</details>\n\n")
 		}
 
 		// Expandable Field Reference (each field in its own collapsible)
-		if len(doc.Fields) > 0 {
+		if refs := fieldReferences(doc.Fields, s.Fields); len(refs) > 0 {
 			b.WriteString("<details>\n")
 			b.WriteString("<summary>📑 Field Reference</summary>\n\n")
-			for _, f := range doc.Fields {
+			for _, f := range refs {
 				b.WriteString("<details>\n")
 				b.WriteString(fmt.Sprintf("<summary>`%s`</summary>\n\n", f.Name))
 				b.WriteString(fmt.Sprintf("- **Type:** `%s`\n", f.Type))
+				if f.Comment != "" {
+					b.WriteString(fmt.Sprintf("- **Comment:** %s\n", f.Comment))
+				}
+				if f.Origin != "" {
+					b.WriteString(fmt.Sprintf("- **Source:** `%s`\n", f.Origin))
+				}
 				if f.Description != "" {
 					b.WriteString(fmt.Sprintf("- **Description:** %s\n", f.Description))
 				}
@@ -118,6 +148,51 @@ func GenerateYAMLDoc(pkg analyzer.Package, filePath string, cfg docTypes.Generat
 	return os.WriteFile(readmePath, []byte(b.String()), 0644)
 }
 
+// fieldReference is one entry of the Field Reference: the AI description of
+// a field and the comment written next to it in the source
+type fieldReference struct {
+	Name        string
+	Type        string
+	Comment     string
+	Origin      string
+	Description string
+}
+
+// fieldReferences lists the fields described by the AI, followed by the
+// commented or anchored fields it left out. Nested fields are named by their path, e.g.
+// "image.tag" or "ports[0]".
+func fieldReferences(described []aiTypes.YAMLField, fields []analyzer.Field) []fieldReference {
+	var refs []fieldReference
+	index := make(map[string]int)
+	for _, f := range described {
+		index[f.Name] = len(refs)
+		refs = append(refs, fieldReference{Name: f.Name, Type: f.Type, Description: f.Description})
+	}
+
+	var walk func(prefix string, fields []analyzer.Field)
+	walk = func(prefix string, fields []analyzer.Field) {
+		for _, f := range fields {
+			path := f.Name
+			if prefix != "" && !strings.HasPrefix(f.Name, "[") {
+				path = prefix + "." + f.Name
+			} else if prefix != "" {
+				path = prefix + f.Name
+			}
+			if comment := strings.Join(strings.Fields(f.DocYAML.Summary), " "); comment != "" || f.Origin != "" {
+				if i, ok := index[path]; ok {
+					refs[i].Comment = comment
+					refs[i].Origin = f.Origin
+				} else {
+					refs = append(refs, fieldReference{Name: path, Type: f.Type, Comment: comment, Origin: f.Origin})
+				}
+			}
+			walk(path, f.Fields)
+		}
+	}
+	walk("", fields)
+	return refs
+}
+
 func generateYAMLExample(fields []analyzer.Field, indentLevel int) string {
 	var b strings.Builder
 	baseIndent := strings.Repeat("  ", indentLevel)
@@ -127,7 +202,7 @@ func generateYAMLExample(fields []analyzer.Field, indentLevel int) string {
 		case "array":
 			// Write array key if present
 			if f.Name != "" {
-				b.WriteString(fmt.Sprintf("%s%s:\n", baseIndent, f.Name))
+				b.WriteString(fmt.Sprintf("%s%s:%s\n", baseIndent, f.Name, originNote(f)))
 			}
 
 			// If no items, add empty array placeholder
@@ -144,9 +219,9 @@ func generateYAMLExample(fields []analyzer.Field, indentLevel int) string {
 				// Case 1: item has nested fields -> complex object
 				if len(item.Fields) > 0 {
 					if item.Name != "" {
-						b.WriteString(fmt.Sprintf("%s:\n", item.Name))
+						b.WriteString(fmt.Sprintf("%s:%s\n", item.Name, originNote(item)))
 					} else {
-						b.WriteString("\n")
+						b.WriteString(strings.TrimSpace(originNote(item)) + "\n")
 					}
 					// recursively generate nested yaml with indentLevel+2 (one for itemIndent, one for nested)
 					b.WriteString(generateYAMLExample(item.Fields, indentLevel+2))
@@ -155,16 +230,16 @@ func generateYAMLExample(fields []analyzer.Field, indentLevel int) string {
 
 				// Case 2: simple key-value pair inside array item
 				if item.Name != "" {
-					b.WriteString(fmt.Sprintf("%s\n", fmt.Sprintf("%s: %s", item.Name, docUtils.GetValueOrPlaceholder(item))))
+					b.WriteString(fmt.Sprintf("%s%s\n", fmt.Sprintf("%s: %s", item.Name, docUtils.GetValueOrPlaceholder(item)), originNote(item)))
 				} else {
 					// Case 3: plain value in array item
-					b.WriteString(fmt.Sprintf("%s\n", docUtils.GetValueOrPlaceholder(item)))
+					b.WriteString(fmt.Sprintf("%s%s\n", docUtils.GetValueOrPlaceholder(item), originNote(item)))
 				}
 			}
 
 		case "map":
 			if f.Name != "" {
-				b.WriteString(fmt.Sprintf("%s%s:\n", baseIndent, f.Name))
+				b.WriteString(fmt.Sprintf("%s%s:%s\n", baseIndent, f.Name, originNote(f)))
 			}
 			if len(f.Fields) > 0 {
 				b.WriteString(generateYAMLExample(f.Fields, indentLevel+1))
@@ -173,13 +248,151 @@ func generateYAMLExample(fields []analyzer.Field, indentLevel int) string {
 			}
 
 		default: // scalar value
+			if len(f.Fields) > 0 {
+				// Other nested kinds, e.g. Compose services, render as maps
+				b.WriteString(fmt.Sprintf("%s%s:%s\n", baseIndent, f.Name, originNote(f)))
+				b.WriteString(generateYAMLExample(f.Fields, indentLevel+1))
+				continue
+			}
 			if f.Name != "" {
-				b.WriteString(fmt.Sprintf("%s%s: %s\n", baseIndent, f.Name, docUtils.GetValueOrPlaceholder(f)))
+				b.WriteString(fmt.Sprintf("%s%s: %s%s\n", baseIndent, f.Name, docUtils.GetValueOrPlaceholder(f), originNote(f)))
 			} else {
-				b.WriteString(fmt.Sprintf("%s%s\n", baseIndent, docUtils.GetValueOrPlaceholder(f)))
+				b.WriteString(fmt.Sprintf("%s%s%s\n", baseIndent, docUtils.GetValueOrPlaceholder(f), originNote(f)))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// generateHCLExample renders a block and its fields in HCL syntax. labels
+// holds the block type and labels; without them the fields are written as
+// loose attributes, as in a .tfvars file.
+func generateHCLExample(labels []string, fields []analyzer.Field) string {
+	if len(labels) == 0 {
+		return generateHCLBody(fields, 0)
+	}
+
+	header := labels[0]
+	for _, l := range labels[1:] {
+		header += fmt.Sprintf(" %q", l)
+	}
+	return header + " {\n" + generateHCLBody(fields, 1) + "}\n"
+}
+
+func generateHCLBody(fields []analyzer.Field, indentLevel int) string {
+	var b strings.Builder
+	indent := strings.Repeat("  ", indentLevel)
+
+	for _, f := range fields {
+		if f.Type == "block" {
+			b.WriteString(fmt.Sprintf("%s%s {\n", indent, f.Name))
+			b.WriteString(generateHCLBody(f.Fields, indentLevel+1))
+			b.WriteString(indent + "}\n")
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s%s = %s\n", indent, f.Name, hclValue(f, indentLevel)))
+	}
+	return b.String()
+}
+
+// hclValue renders a field value; maps and arrays are rebuilt from their
+// nested fields
+func hclValue(f analyzer.Field, indentLevel int) string {
+	indent := strings.Repeat("  ", indentLevel)
+	switch f.Type {
+	case "map":
+		if len(f.Fields) == 0 {
+			return "{}"
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, item := range f.Fields {
+			b.WriteString(fmt.Sprintf("%s  %s = %s\n", indent, item.Name, hclValue(item, indentLevel+1)))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	case "array":
+		items := make([]string, len(f.Fields))
+		for i, item := range f.Fields {
+			items[i] = hclValue(item, indentLevel)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	if f.Value == "" {
+		return `"" # TODO: Add value`
+	}
+	return f.Value
+}
+
+// generateDockerfileExample renders the instructions of a build stage
+func generateDockerfileExample(fields []analyzer.Field) string {
+	var b strings.Builder
+	for _, f := range fields {
+		if f.DocYAML.Summary != "" {
+			for _, line := range strings.Split(f.DocYAML.Summary, "\n") {
+				b.WriteString(strings.TrimRight("# "+line, " ") + "\n")
 			}
 		}
+		b.WriteString(strings.TrimSpace(f.Name+" "+f.Value) + "\n")
 	}
+	return b.String()
+}
 
+// generateMakefileExample renders a target rule with its recipe, or the
+// variable assignments of a Makefile
+func generateMakefileExample(s analyzer.Struct, fields []analyzer.Field) string {
+	var b strings.Builder
+	if s.Kind != "target" {
+		for _, f := range fields {
+			op := "="
+			var prefix []string
+			for _, m := range f.Modifiers {
+				if m == "export" || m == "override" {
+					prefix = append(prefix, m)
+				} else {
+					op = m
+				}
+			}
+			prefix = append(prefix, f.Name)
+			b.WriteString(fmt.Sprintf("%s %s %s\n", strings.Join(prefix, " "), op, f.Value))
+		}
+		return b.String()
+	}
+
+	var prereqs, recipe []string
+	for _, f := range fields {
+		for _, item := range f.Fields {
+			switch f.Name {
+			case "prerequisites":
+				prereqs = append(prereqs, item.Value)
+			case "recipe":
+				recipe = append(recipe, item.Value)
+			}
+		}
+	}
+	rule := s.Name + ":"
+	if len(prereqs) > 0 {
+		rule += " " + strings.Join(prereqs, " ")
+	}
+	if s.Doc.Summary != "" && !strings.Contains(s.Doc.Summary, "\n") {
+		rule += " ## " + s.Doc.Summary
+	}
+	b.WriteString(rule + "\n")
+	for _, line := range recipe {
+		b.WriteString("\t" + line + "\n")
+	}
 	return b.String()
 }
+
+// originNote marks a value taken from an anchor, e.g. "  # from <<: *defaults",
+// or one overriding a merged value
+func originNote(f analyzer.Field) string {
+	switch {
+	case f.Origin == "":
+		return ""
+	case strings.HasPrefix(f.Origin, "overrides "):
+		return "  # " + f.Origin
+	}
+	return "  # from " + f.Origin
+}
@@ -17,13 +17,20 @@ func GeneratePackageDocs(result *analyzer.AnalyzerResult, cfg docTypes.Generator
 		return err
 	}
 
-	codeRequests, yamlRequests := prepareAIRequests(result, cfg)
+	plan, err := planBuild(result, cfg)
+	if err != nil {
+		return err
+	}
+
+	codeRequests, yamlRequests := prepareAIRequests(result, cfg, plan)
 
-	if err := enhanceWithAI(codeRequests, yamlRequests, cfg); err != nil {
+	enhanced := enhanceWithAI(codeRequests, yamlRequests, cfg)
+
+	if err := generateFinalDocs(result, cfg, plan); err != nil {
 		return err
 	}
 
-	return generateFinalDocs(result, cfg)
+	return plan.saveManifest(result, cfg, enhanced)
 }
 
 func prepareOutputStructure(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig) error {
@@ -45,26 +52,54 @@ func prepareOutputStructure(result *analyzer.AnalyzerResult, cfg docTypes.Genera
 	return nil
 }
 
-func prepareAIRequests(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig) ([]docTypes.AICodeRequest, []docTypes.AIYAMLRequest) {
+func prepareAIRequests(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig, plan *buildPlan) ([]docTypes.AICodeRequest, []docTypes.AIYAMLRequest) {
 	var codeRequests []docTypes.AICodeRequest
 	var yamlRequests []docTypes.AIYAMLRequest
 
 	fmt.Println("🔍 Preparing AI enhancement requests...")
 	for fi := range result.Files {
 		file := result.Files[fi]
+		if plan.unchanged[file] {
+			fmt.Printf("♻️ Unchanged: %s\n", file.Path)
+			continue
+		}
 		fmt.Printf("📦 File: %s\n", file.Path)
 
 		for pi := range file.Packages {
 			pkg := &file.Packages[pi]
-			lang := docUtils.GetLanguage(file.Path)
+			lang := docUtils.GetFileLanguage(file)
 			fmt.Printf("  📚 Package: %s (Lang: %s)\n", pkg.Name, lang)
 
+			if lang == "OpenAPI" {
+				// Specs document themselves; only operations without a summary go to the AI
+				for fi := range pkg.Funcs {
+					if cfg.AIClient == nil || pkg.Funcs[fi].Doc.Summary != "" {
+						continue
+					}
+					codeRequests = append(codeRequests, docTypes.AICodeRequest{
+						Input:    docGenerator.FormatOperation(pkg.Funcs[fi]),
+						Language: lang,
+						Target:   &pkg.Funcs[fi].Doc,
+					})
+					fmt.Printf("    🔗 Operation: %s → Code AI request added\n", pkg.Funcs[fi].Name)
+				}
+				continue
+			}
+
 			for si := range pkg.Structs {
 				if cfg.AIClient == nil {
 					continue
 				}
-				if lang == "YAML" {
-					input := formatYAMLStruct(pkg.Structs[si])
+				if lang == "Helm" && pkg.Structs[si].Kind == "template" {
+					// Templates are documented by the values they reference
+					continue
+				}
+				if docUtils.IsConfigLanguage(lang) {
+					if yamlCommented(pkg.Structs[si]) {
+						fmt.Printf("    📝 YAML Struct: %s → documented by its comments\n", pkg.Structs[si].Name)
+						continue
+					}
+					input := formatYAMLStruct(lang, pkg.Structs[si])
 					pkg.Structs[si].DocYAML = aiTypes.YAMLDocumentation{}
 					yamlRequests = append(yamlRequests, docTypes.AIYAMLRequest{
 						Input:    input,
@@ -73,8 +108,7 @@ func prepareAIRequests(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorCo
 					})
 					fmt.Printf("    📄 YAML Struct: %s → YAML AI request added\n", pkg.Structs[si].Name)
 				} else {
-					input := docGenerator.FormatStruct(pkg.Structs[si])
-					pkg.Structs[si].Doc = aiTypes.Documentation{}
+					input := withSourceDoc(docGenerator.FormatStructAs(lang, pkg.Structs[si]), pkg.Structs[si].Doc)
 					codeRequests = append(codeRequests, docTypes.AICodeRequest{
 						Input:    input,
 						Language: lang,
@@ -84,18 +118,39 @@ func prepareAIRequests(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorCo
 				}
 			}
 
-			if lang != "YAML" {
+			if !docUtils.IsConfigLanguage(lang) && cfg.AIClient != nil {
+				// The AI result is merged into what the analyzer read from the source
+				addCodeRequest := func(input string, target *aiTypes.Documentation) {
+					codeRequests = append(codeRequests, docTypes.AICodeRequest{
+						Input:    withSourceDoc(input, *target),
+						Language: lang,
+						Target:   target,
+					})
+				}
+
+				for ii := range pkg.Interfaces {
+					addCodeRequest(docGenerator.FormatInterfaceAs(lang, pkg.Interfaces[ii]), &pkg.Interfaces[ii].Doc)
+					fmt.Printf("    🧩 Interface: %s → Code AI request added\n", pkg.Interfaces[ii].Name)
+				}
+				for ti := range pkg.Types {
+					addCodeRequest(docGenerator.FormatTypeDefAs(lang, pkg.Types[ti]), &pkg.Types[ti].Doc)
+					fmt.Printf("    🏷️ Type: %s → Code AI request added\n", pkg.Types[ti].Name)
+				}
+				for ei := range pkg.Enums {
+					addCodeRequest(docGenerator.FormatEnumAs(lang, pkg.Enums[ei]), &pkg.Enums[ei].Doc)
+					fmt.Printf("    🎛️ Enum: %s → Code AI request added\n", pkg.Enums[ei].Name)
+				}
+				for ci := range pkg.Consts {
+					addCodeRequest(docGenerator.FormatValueGroup(pkg.Consts[ci]), &pkg.Consts[ci].Doc)
+					fmt.Printf("    🔢 Const block (%d values) → Code AI request added\n", len(pkg.Consts[ci].Values))
+				}
+				for vi := range pkg.Vars {
+					addCodeRequest(docGenerator.FormatValueGroup(pkg.Vars[vi]), &pkg.Vars[vi].Doc)
+					fmt.Printf("    🌐 Var block (%d values) → Code AI request added\n", len(pkg.Vars[vi].Values))
+				}
 				for fi := range pkg.Funcs {
-					if cfg.AIClient != nil {
-						input := docGenerator.FormatFunction(pkg.Funcs[fi])
-						pkg.Funcs[fi].Doc = aiTypes.Documentation{}
-						codeRequests = append(codeRequests, docTypes.AICodeRequest{
-							Input:    input,
-							Language: lang,
-							Target:   &pkg.Funcs[fi].Doc,
-						})
-						fmt.Printf("    🔧 Function: %s → Code AI request added\n", pkg.Funcs[fi].Name)
-					}
+					addCodeRequest(docGenerator.FormatFunctionAs(lang, pkg.Funcs[fi]), &pkg.Funcs[fi].Doc)
+					fmt.Printf("    🔧 Function: %s → Code AI request added\n", pkg.Funcs[fi].Name)
 				}
 			}
 		}
@@ -103,22 +158,66 @@ func prepareAIRequests(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorCo
 	return codeRequests, yamlRequests
 }
 
-func enhanceWithAI(codeRequests []docTypes.AICodeRequest, yamlRequests []docTypes.AIYAMLRequest, cfg docTypes.GeneratorConfig) error {
-	if cfg.AIClient != nil && (len(codeRequests) > 0 || len(yamlRequests) > 0) {
+// enhanceWithAI reports whether every request came back from the AI
+func enhanceWithAI(codeRequests []docTypes.AICodeRequest, yamlRequests []docTypes.AIYAMLRequest, cfg docTypes.GeneratorConfig) bool {
+	if cfg.AIClient == nil {
+		return false
+	}
+	if len(codeRequests) > 0 || len(yamlRequests) > 0 {
 		fmt.Printf("🚀 Sending %d code + %d YAML requests to AI\n", len(codeRequests), len(yamlRequests))
-		processAIRequests(codeRequests, yamlRequests, cfg.AIClient)
+		if err := processAIRequests(codeRequests, yamlRequests, cfg.AIClient); err != nil {
+			return false
+		}
 		fmt.Println("✅ AI enhancement complete.")
 	}
-	return nil
+	return true
 }
 
-func generateFinalDocs(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig) error {
+func generateFinalDocs(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig, plan *buildPlan) error {
 	fmt.Println("📝 Generating documentation output...")
+	written := make(map[string]bool)
 	for _, file := range result.Files {
 		for _, pkg := range file.Packages {
-			lang := docUtils.GetLanguage(file.Path)
-			if lang == "YAML" {
-				fmt.Printf("📄 Generating YAML documentation for: %s\n", pkg.Name)
+			page := packagePage(pkg)
+			if !plan.dirty[page] {
+				continue
+			}
+			if !written[page] {
+				// Pages shared by several files are rebuilt from scratch
+				if err := removePage(cfg.OutputDir, page); err != nil {
+					return err
+				}
+				written[page] = true
+			}
+
+			lang := docUtils.GetFileLanguage(file)
+			if lang == "OpenAPI" {
+				fmt.Printf("📄 Generating API reference for: %s\n", pkg.Name)
+				if err := docGenerator.GenerateOpenAPIDoc(pkg, file.Path, cfg); err != nil {
+					return fmt.Errorf("failed to generate API docs for package %s: %w", pkg.Name, err)
+				}
+			} else if lang == "GitHub Actions" {
+				fmt.Printf("📄 Generating CI/CD documentation for: %s\n", file.Path)
+				if err := docGenerator.GenerateActionsDoc(pkg, file.Path, cfg); err != nil {
+					return fmt.Errorf("failed to generate CI/CD docs for %s: %w", file.Path, err)
+				}
+			} else if lang == "Helm" {
+				fmt.Printf("📄 Generating Helm chart documentation for: %s\n", pkg.Name)
+				if err := docGenerator.GenerateHelmDoc(pkg, file.Path, cfg); err != nil {
+					return fmt.Errorf("failed to generate Helm docs for chart %s: %w", pkg.Name, err)
+				}
+			} else if lang == "Ansible" {
+				fmt.Printf("📄 Generating Ansible documentation for: %s\n", file.Path)
+				if err := docGenerator.GenerateAnsibleDoc(pkg, file.Path, cfg); err != nil {
+					return fmt.Errorf("failed to generate Ansible docs for %s: %w", file.Path, err)
+				}
+			} else if lang == "Docker Compose" {
+				fmt.Printf("📄 Generating Docker Compose documentation for: %s\n", file.Path)
+				if err := docGenerator.GenerateComposeDoc(pkg, file.Path, cfg); err != nil {
+					return fmt.Errorf("failed to generate Docker Compose docs for %s: %w", file.Path, err)
+				}
+			} else if docUtils.IsConfigLanguage(lang) {
+				fmt.Printf("📄 Generating %s documentation for: %s\n", lang, pkg.Name)
 				if err := docGenerator.GenerateYAMLDoc(pkg, file.Path, cfg); err != nil {
 					return fmt.Errorf("failed to generate YAML docs for package %s: %w", pkg.Name, err)
 				}
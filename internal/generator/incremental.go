@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	"github.com/MRGHOSJ/docupocus/internal/generator/manifest"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+	docUtils "github.com/MRGHOSJ/docupocus/internal/generator/utils"
+)
+
+// buildPlan decides, from the previous manifest, which files keep their docs
+// and which pages can be left untouched
+type buildPlan struct {
+	previous *manifest.Manifest
+	// unchanged files reuse the docs recorded in the manifest
+	unchanged map[*analyzer.AnalyzedFile]bool
+	// dirty pages are rewritten from scratch
+	dirty map[string]bool
+	// pages lists, in order, the pages each file is written to
+	pages map[*analyzer.AnalyzedFile][]string
+}
+
+func planBuild(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig) (*buildPlan, error) {
+	previous, err := manifest.Load(cfg.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &buildPlan{
+		previous:  previous,
+		unchanged: make(map[*analyzer.AnalyzedFile]bool),
+		dirty:     make(map[string]bool),
+		pages:     make(map[*analyzer.AnalyzedFile][]string),
+	}
+
+	// Files that are new, changed or re-parsed make their pages dirty
+	contributors := make(map[string][]string)
+	for _, file := range result.Files {
+		plan.pages[file] = filePages(file)
+
+		entry, ok := previous.Lookup(file.Path)
+		reused := ok && !cfg.Rebuild && entry.Unchanged(file) && (entry.Enhanced || cfg.AIClient == nil)
+		if reused {
+			restoreDocs(entry.File, file)
+			plan.unchanged[file] = true
+		}
+
+		for _, page := range plan.pages[file] {
+			contributors[page] = append(contributors[page], file.Path)
+			// Re-parsed files may link to other packages, so only files the
+			// analyzer reused verbatim keep their page
+			if !reused || !file.Reused {
+				plan.dirty[page] = true
+			}
+		}
+	}
+
+	// Pages whose set of files changed, or that went missing, are dirty too
+	previousContributors := make(map[string][]string)
+	for _, entry := range previous.Entries {
+		if entry.File == nil {
+			continue
+		}
+		for _, page := range entry.Pages {
+			previousContributors[page] = append(previousContributors[page], entry.File.Path)
+		}
+	}
+	for page, files := range contributors {
+		if !equalStrings(files, previousContributors[page]) || !exists(filepath.Join(cfg.OutputDir, page)) {
+			plan.dirty[page] = true
+		}
+	}
+
+	return plan, nil
+}
+
+// filePages lists the pages, relative to the output directory, a file is written to
+func filePages(file *analyzer.AnalyzedFile) []string {
+	var pages []string
+	for _, pkg := range file.Packages {
+		pages = appendUnique(pages, packagePage(pkg))
+	}
+	return pages
+}
+
+// packagePage is the page a package is written to, relative to the output directory
+func packagePage(pkg analyzer.Package) string {
+	return filepath.ToSlash(filepath.Join(docUtils.PackageDocDir(pkg), "README.md"))
+}
+
+// saveManifest records this run and removes the pages of files that are gone
+func (p *buildPlan) saveManifest(result *analyzer.AnalyzerResult, cfg docTypes.GeneratorConfig, enhanced bool) error {
+	next := manifest.New()
+	current := make(map[string]bool)
+
+	for _, file := range result.Files {
+		entry := manifest.Entry{
+			File:     file,
+			Pages:    p.pages[file],
+			Enhanced: cfg.AIClient != nil && enhanced,
+		}
+		if p.unchanged[file] {
+			if prev, ok := p.previous.Lookup(file.Path); ok {
+				entry.Enhanced = prev.Enhanced
+			}
+		}
+		next.Add(entry)
+
+		for _, page := range entry.Pages {
+			current[page] = true
+		}
+	}
+
+	for page := range p.previous.Pages() {
+		if current[page] {
+			continue
+		}
+		fmt.Printf("🗑️ Removing stale page: %s\n", page)
+		if err := removePage(cfg.OutputDir, page); err != nil {
+			return err
+		}
+	}
+
+	return next.Save(cfg.OutputDir)
+}
+
+// removePage deletes a page and any directories it leaves empty
+func removePage(outputDir, page string) error {
+	path := filepath.Join(outputDir, filepath.FromSlash(page))
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale page %s: %w", page, err)
+	}
+
+	root := filepath.Clean(outputDir)
+	for dir := filepath.Dir(path); dir != root && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			// Not empty
+			break
+		}
+	}
+	return nil
+}
+
+// restoreDocs copies the documentation of a previous run onto a freshly
+// analyzed copy of the same, unchanged file
+func restoreDocs(prev, cur *analyzer.AnalyzedFile) {
+	if cur.Reused {
+		// Reused files already carry their docs
+		return
+	}
+	for pi := range cur.Packages {
+		if pi >= len(prev.Packages) {
+			return
+		}
+		from, to := &prev.Packages[pi], &cur.Packages[pi]
+
+		structs := make(map[string]analyzer.Struct)
+		for _, s := range from.Structs {
+			structs[s.Name] = s
+		}
+		for i := range to.Structs {
+			if s, ok := structs[to.Structs[i].Name]; ok {
+				to.Structs[i].Doc = s.Doc
+				to.Structs[i].DocYAML = s.DocYAML
+				restoreFunctionDocs(s.Methods, to.Structs[i].Methods)
+			}
+		}
+
+		interfaces := make(map[string]analyzer.Interface)
+		for _, iface := range from.Interfaces {
+			interfaces[iface.Name] = iface
+		}
+		for i := range to.Interfaces {
+			if iface, ok := interfaces[to.Interfaces[i].Name]; ok {
+				to.Interfaces[i].Doc = iface.Doc
+				restoreFunctionDocs(iface.Methods, to.Interfaces[i].Methods)
+			}
+		}
+
+		types := make(map[string]analyzer.TypeDef)
+		for _, t := range from.Types {
+			types[t.Name] = t
+		}
+		for i := range to.Types {
+			if t, ok := types[to.Types[i].Name]; ok {
+				to.Types[i].Doc = t.Doc
+				restoreFunctionDocs(t.Methods, to.Types[i].Methods)
+			}
+		}
+
+		enums := make(map[string]analyzer.Enum)
+		for _, e := range from.Enums {
+			enums[e.Name] = e
+		}
+		for i := range to.Enums {
+			if e, ok := enums[to.Enums[i].Name]; ok {
+				to.Enums[i].Doc = e.Doc
+				restoreValueDocs(e.Members, to.Enums[i].Members)
+			}
+		}
+
+		// Value groups have no name; unchanged sources keep them in order
+		for i := range to.Consts {
+			if i < len(from.Consts) {
+				to.Consts[i].Doc = from.Consts[i].Doc
+				restoreValueDocs(from.Consts[i].Values, to.Consts[i].Values)
+			}
+		}
+		for i := range to.Vars {
+			if i < len(from.Vars) {
+				to.Vars[i].Doc = from.Vars[i].Doc
+				restoreValueDocs(from.Vars[i].Values, to.Vars[i].Values)
+			}
+		}
+
+		restoreFunctionDocs(from.Funcs, to.Funcs)
+	}
+}
+
+// restoreFunctionDocs copies docs between functions with the same receiver and name
+func restoreFunctionDocs(from, to []analyzer.Function) {
+	funcs := make(map[string]analyzer.Function)
+	for _, fn := range from {
+		funcs[fn.Receiver+"."+fn.Name] = fn
+	}
+	for i := range to {
+		if fn, ok := funcs[to[i].Receiver+"."+to[i].Name]; ok {
+			to[i].Doc = fn.Doc
+		}
+	}
+}
+
+// restoreValueDocs copies docs between values with the same name
+func restoreValueDocs(from, to []analyzer.Value) {
+	values := make(map[string]analyzer.Value)
+	for _, v := range from {
+		values[v.Name] = v
+	}
+	for i := range to {
+		if v, ok := values[to[i].Name]; ok {
+			to[i].Doc = v.Doc
+		}
+	}
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	aiTypes "github.com/MRGHOSJ/docupocus/internal/ai/types"
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	"github.com/MRGHOSJ/docupocus/internal/generator/manifest"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+)
+
+// incrementalFile builds a small analyzed file, documented by doc
+func incrementalFile(doc func(name string) aiTypes.Documentation) *analyzer.AnalyzedFile {
+	method := analyzer.Function{Name: "Get", Receiver: "*Cache", Doc: doc("Get")}
+	return &analyzer.AnalyzedFile{
+		Path:     "cache/cache.go",
+		Analyzer: "GoAnalyzer",
+		Hashes:   map[string]string{"cache/cache.go": "abc"},
+		Packages: []analyzer.Package{{
+			Name:       "cache",
+			ImportPath: "example.com/cache",
+			Structs:    []analyzer.Struct{{Name: "Cache", Doc: doc("Cache"), Methods: []analyzer.Function{method}}},
+			Interfaces: []analyzer.Interface{{
+				Name:    "Store",
+				Doc:     doc("Store"),
+				Methods: []analyzer.Function{{Name: "Put", Receiver: "Store", Doc: doc("Put")}},
+			}},
+			Types: []analyzer.TypeDef{{
+				Name:    "Key",
+				Doc:     doc("Key"),
+				Methods: []analyzer.Function{{Name: "String", Receiver: "Key", Doc: doc("String")}},
+			}},
+			Enums: []analyzer.Enum{{
+				Name:    "Mode",
+				Doc:     doc("Mode"),
+				Members: []analyzer.Value{{Name: "Fast", Value: "0", Doc: doc("Fast")}},
+			}},
+			Funcs: []analyzer.Function{method},
+		}},
+	}
+}
+
+func TestReusedFileKeepsNestedDocs(t *testing.T) {
+	outputDir := t.TempDir()
+	documented := incrementalFile(func(name string) aiTypes.Documentation {
+		return aiTypes.Documentation{Summary: "About " + name, Returns: "something"}
+	})
+
+	previous := manifest.New()
+	previous.Add(manifest.Entry{File: documented, Pages: filePages(documented), Enhanced: true})
+	if err := previous.Save(outputDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same file, parsed again without any docs
+	current := incrementalFile(func(string) aiTypes.Documentation { return aiTypes.Documentation{} })
+	result := &analyzer.AnalyzerResult{Files: []*analyzer.AnalyzedFile{current}}
+	plan, err := planBuild(result, docTypes.GeneratorConfig{OutputDir: outputDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.unchanged[current] {
+		t.Fatal("unchanged file is not reused")
+	}
+
+	if !reflect.DeepEqual(current.Packages, documented.Packages) {
+		t.Errorf("restored packages =\n%+v\nwant\n%+v", current.Packages, documented.Packages)
+	}
+}
@@ -0,0 +1,170 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+// FileName is the manifest kept in the docs output directory
+const FileName = ".docupocus-manifest.json"
+
+const version = 3
+
+// Manifest records what the previous run produced so the next one only
+// re-analyzes, re-enhances and rewrites what changed
+type Manifest struct {
+	Version int     `json:"version"`
+	Tool    string  `json:"tool"` // build of docupocus that wrote the manifest
+	Entries []Entry `json:"entries"`
+
+	byPath map[string]*Entry
+	// foreign is set when another build of the tool wrote the manifest; its
+	// pages are still known, but nothing in it is reused
+	foreign bool
+}
+
+// Entry is one analyzed unit (a file, or an aggregated package) with the
+// documentation it ended up with and the pages it was written to
+type Entry struct {
+	File     *analyzer.AnalyzedFile `json:"file"`
+	Pages    []string               `json:"pages"`
+	Enhanced bool                   `json:"enhanced"` // Docs came back from the AI
+}
+
+// New returns an empty manifest
+func New() *Manifest {
+	m := &Manifest{Version: version, Tool: toolVersion()}
+	m.index()
+	return m
+}
+
+// Load reads the manifest from outputDir. A missing or outdated manifest
+// yields an empty one, so everything is rebuilt. Nothing is reused from a
+// manifest written by another build of the tool either.
+func Load(outputDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, FileName))
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil || m.Version != version {
+		fmt.Println("⚠️ Ignoring unreadable docs manifest, rebuilding everything")
+		return New(), nil
+	}
+	if tool := toolVersion(); m.Tool != tool {
+		fmt.Printf("⚠️ Docs manifest was written by docupocus %s, not %s, rebuilding everything\n", m.Tool, tool)
+		m.foreign = true
+	}
+	m.index()
+	return m, nil
+}
+
+// Save writes the manifest to outputDir
+func (m *Manifest) Save(outputDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return os.WriteFile(filepath.Join(outputDir, FileName), data, 0644)
+}
+
+// Add records a unit, replacing any earlier entry for the same path
+func (m *Manifest) Add(entry Entry) {
+	if existing, ok := m.byPath[entry.File.Path]; ok {
+		*existing = entry
+		return
+	}
+	m.Entries = append(m.Entries, entry)
+	m.index()
+}
+
+// Lookup returns the entry recorded for a unit path
+func (m *Manifest) Lookup(path string) (*Entry, bool) {
+	if m.foreign {
+		return nil, false
+	}
+	entry, ok := m.byPath[path]
+	return entry, ok
+}
+
+// Files returns every analyzed unit in the manifest that may be reused
+func (m *Manifest) Files() []*analyzer.AnalyzedFile {
+	if m.foreign {
+		return nil
+	}
+	files := make([]*analyzer.AnalyzedFile, 0, len(m.Entries))
+	for _, entry := range m.Entries {
+		if entry.File != nil {
+			files = append(files, entry.File)
+		}
+	}
+	return files
+}
+
+// Pages returns every page recorded in the manifest
+func (m *Manifest) Pages() map[string]bool {
+	pages := make(map[string]bool)
+	for _, entry := range m.Entries {
+		for _, page := range entry.Pages {
+			pages[page] = true
+		}
+	}
+	return pages
+}
+
+// Unchanged reports whether file has exactly the sources and content hashes
+// recorded for it, and came from the same analyzer
+func (e *Entry) Unchanged(file *analyzer.AnalyzedFile) bool {
+	if e.File.Analyzer != file.Analyzer {
+		return false
+	}
+	if len(e.File.Hashes) == 0 || len(e.File.Hashes) != len(file.Hashes) {
+		return false
+	}
+	for src, hash := range file.Hashes {
+		if e.File.Hashes[src] != hash {
+			return false
+		}
+	}
+	return true
+}
+
+// toolVersion identifies the running build by its module version, which
+// includes the VCS revision when built from a checkout
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	tool := "devel"
+	for _, setting := range info.Settings {
+		switch {
+		case setting.Key == "vcs.revision":
+			tool += "+" + setting.Value
+		case setting.Key == "vcs.modified" && setting.Value == "true":
+			tool += "-dirty"
+		}
+	}
+	return tool
+}
+
+func (m *Manifest) index() {
+	m.byPath = make(map[string]*Entry, len(m.Entries))
+	for i := range m.Entries {
+		if m.Entries[i].File != nil {
+			m.byPath[m.Entries[i].File.Path] = &m.Entries[i]
+		}
+	}
+}
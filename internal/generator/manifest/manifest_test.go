@@ -0,0 +1,52 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+func TestUnchanged(t *testing.T) {
+	recorded := &analyzer.AnalyzedFile{Path: "a.ts", Analyzer: "JSAnalyzer", Hashes: map[string]string{"a.ts": "1"}}
+	entry := Entry{File: recorded}
+
+	tests := []struct {
+		name string
+		file *analyzer.AnalyzedFile
+		want bool
+	}{
+		{"same content and analyzer", &analyzer.AnalyzedFile{Path: "a.ts", Analyzer: "JSAnalyzer", Hashes: map[string]string{"a.ts": "1"}}, true},
+		{"content changed", &analyzer.AnalyzedFile{Path: "a.ts", Analyzer: "JSAnalyzer", Hashes: map[string]string{"a.ts": "2"}}, false},
+		{"another analyzer took over", &analyzer.AnalyzedFile{Path: "a.ts", Analyzer: "TSAnalyzer", Hashes: map[string]string{"a.ts": "1"}}, false},
+	}
+	for _, tt := range tests {
+		if got := entry.Unchanged(tt.file); got != tt.want {
+			t.Errorf("%s: Unchanged() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFromAnotherBuild(t *testing.T) {
+	dir := t.TempDir()
+	m := New()
+	m.Tool = "v0.0.1"
+	m.Add(Entry{File: &analyzer.AnalyzedFile{Path: "a.go"}, Pages: []string{"a/README.md"}})
+	if err := m.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files := loaded.Files(); len(files) != 0 {
+		t.Errorf("reused %d files written by another build", len(files))
+	}
+	if _, ok := loaded.Lookup("a.go"); ok {
+		t.Error("looked up an entry written by another build")
+	}
+	// Its pages are still removed once they go stale
+	if !loaded.Pages()["a/README.md"] {
+		t.Error("pages of the previous build are forgotten")
+	}
+}
@@ -9,6 +9,10 @@ type GeneratorConfig struct {
 	AIClient  *ai.Client
 	OutputDir string
 	Project   ProjectMeta
+
+	// Rebuild ignores the manifest of the previous run and regenerates
+	// every page and every AI doc
+	Rebuild bool
 }
 
 type ProjectMeta struct {
@@ -45,6 +49,6 @@ type AICodeRequest struct {
 
 type AIYAMLRequest struct {
 	Input    string
-	Language string // "YAML"
+	Language string // "YAML", "HCL", "Dockerfile", "Makefile", "GitHub Actions", "Helm", "Ansible" or "Docker Compose"
 	Target   *aiTypes.YAMLDocumentation
 }
@@ -0,0 +1,269 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+)
+
+// Make targets worth running first, in the order a newcomer would run them
+var quickStartTargets = []string{"deps", "install", "setup", "build", "test", "lint", "run", "dev", "start", "serve"}
+
+// maxListedTargets caps the targets named in the Make feature description
+const maxListedTargets = 6
+
+// DetectTooling adds the features, Quick Start commands and tech stack
+// entries declared by the project's Dockerfiles and Makefiles
+func DetectTooling(projectDir string, result *analyzer.AnalyzerResult, project *docTypes.ProjectMeta) {
+	for _, file := range result.Files {
+		rel, err := filepath.Rel(projectDir, file.Path)
+		if err != nil {
+			rel = file.Path
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, pkg := range file.Packages {
+			switch GetFileLanguage(file) {
+			case "Dockerfile":
+				addDockerTooling(rel, pkg, project)
+			case "Makefile":
+				if !strings.HasSuffix(rel, ".mk") {
+					addMakeTooling(rel, pkg, project)
+				}
+			}
+		}
+	}
+}
+
+// addDockerTooling describes the build stages of a Dockerfile and how to
+// build and run its final image
+func addDockerTooling(rel string, pkg analyzer.Package, project *docTypes.ProjectMeta) {
+	var stages []analyzer.Struct
+	args := make(map[string]string)
+	for _, s := range pkg.Structs {
+		switch {
+		case s.Kind == "stage" && len(s.Bases) == 1:
+			stages = append(stages, s)
+		case s.Kind == "global":
+			// Global ARG defaults substitute into FROM lines
+			for _, f := range s.Fields {
+				for _, kv := range f.Fields {
+					args[kv.Name] = kv.Value
+				}
+			}
+		}
+	}
+	if len(stages) == 0 {
+		return
+	}
+
+	names := make(map[string]bool)
+	var images []string
+	for _, s := range stages {
+		image := os.Expand(s.Bases[0], func(name string) string {
+			if value, ok := args[name]; ok && value != "" {
+				return value
+			}
+			return "${" + name + "}"
+		})
+		if !names[image] && image != "scratch" {
+			images = append(images, image)
+			if !strings.Contains(image, "$") {
+				project.TechStack = appendUnique(project.TechStack, imageName(image))
+			}
+		}
+		names[s.Name] = true
+	}
+	project.TechStack = appendUnique(project.TechStack, "Docker")
+
+	final := stages[len(stages)-1]
+	var ports, env []string
+	var entrypoint, cmd string
+	for _, f := range final.Fields {
+		switch f.Name {
+		case "EXPOSE":
+			for _, p := range f.Fields {
+				ports = append(ports, p.Value)
+			}
+		case "ENV":
+			for _, kv := range f.Fields {
+				env = append(env, "`"+kv.Name+"`")
+			}
+		case "ENTRYPOINT", "CMD":
+			value := f.Value
+			if f.Type == "exec" {
+				var args []string
+				for _, a := range f.Fields {
+					args = append(args, a.Value)
+				}
+				value = strings.Join(args, " ")
+			}
+			if f.Name == "ENTRYPOINT" {
+				entrypoint = value
+			} else {
+				cmd = value
+			}
+		}
+	}
+	// CMD supplies default arguments to the ENTRYPOINT
+	command := strings.TrimSpace(entrypoint + " " + cmd)
+
+	description := "Image built from `" + strings.Join(images, "` → `") + "`"
+	if len(stages) > 1 {
+		description = fmt.Sprintf("%d-stage build: `%s`", len(stages), strings.Join(images, "` → `"))
+	}
+	if len(ports) > 0 {
+		description += ", exposing " + strings.Join(ports, ", ")
+	}
+	if len(env) > 0 {
+		description += ", configured through " + strings.Join(env, ", ")
+	}
+	if command != "" {
+		description += ", runs `" + command + "`"
+	}
+	project.Features = append(project.Features, docTypes.Feature{
+		Title:       "🐳 Docker Support",
+		Description: description + " (`" + rel + "`)",
+	})
+
+	image := imageTag(project.Name)
+	build := "docker build -t " + image + " ."
+	if rel != "Dockerfile" {
+		// Tag other Dockerfiles after their variant, e.g. Dockerfile.dev or worker.dockerfile
+		variant := strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(path.Base(rel)), "dockerfile."), ".dockerfile")
+		if variant != "dockerfile" && variant != "containerfile" {
+			image += "-" + imageTag(variant)
+		}
+		build = fmt.Sprintf("docker build -f %s -t %s .", rel, image)
+	}
+	run := "docker run --rm"
+	for _, p := range ports {
+		if strings.Contains(p, "$") {
+			continue
+		}
+		port, proto, _ := strings.Cut(p, "/")
+		mapping := port + ":" + port
+		if proto != "" && proto != "tcp" {
+			mapping += "/" + proto
+		}
+		run += " -p " + mapping
+	}
+	title := "🐳 Run with Docker"
+	if rel != "Dockerfile" {
+		title += " (" + rel + ")"
+	}
+	project.QuickStart = append(project.QuickStart, docTypes.QuickStartBlock{
+		Title:   title,
+		Shell:   "bash",
+		Command: build + "\n" + run + " " + image,
+	})
+}
+
+// addMakeTooling lists the documented targets of a Makefile and the ones to
+// run to get started
+func addMakeTooling(rel string, pkg analyzer.Package, project *docTypes.ProjectMeta) {
+	targets := make(map[string]bool)
+	var listed []string
+	defaultGoal := ""
+	for _, s := range pkg.Structs {
+		if s.Kind != "target" {
+			continue
+		}
+		targets[s.Name] = true
+		for _, m := range s.Modifiers {
+			if m == "default" {
+				defaultGoal = s.Name
+			}
+		}
+		help, _, _ := strings.Cut(s.Doc.Summary, "\n")
+		if help != "" {
+			listed = append(listed, fmt.Sprintf("`%s` (%s)", s.Name, help))
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	project.TechStack = appendUnique(project.TechStack, "Make")
+
+	if len(listed) == 0 {
+		for _, s := range pkg.Structs {
+			if targets[s.Name] {
+				listed = append(listed, "`"+s.Name+"`")
+			}
+		}
+	}
+	description := strings.Join(listed, ", ")
+	if len(listed) > maxListedTargets {
+		description = fmt.Sprintf("%s and %d more", strings.Join(listed[:maxListedTargets], ", "), len(listed)-maxListedTargets)
+	}
+	project.Features = append(project.Features, docTypes.Feature{
+		Title:       "🛠️ Make Targets",
+		Description: description + " (`" + rel + "`)",
+	})
+
+	invoke := "make"
+	if dir := filepath.ToSlash(filepath.Dir(rel)); dir != "." {
+		invoke += " -C " + dir
+	}
+	var commands []string
+	for _, t := range quickStartTargets {
+		if targets[t] {
+			commands = append(commands, invoke+" "+t)
+		}
+	}
+	if len(commands) == 0 {
+		commands = []string{invoke}
+		if defaultGoal != "" {
+			commands[0] += " # " + defaultGoal
+		}
+	}
+	if targets["help"] {
+		commands = append([]string{invoke + " help"}, commands...)
+	}
+	project.QuickStart = append(project.QuickStart, docTypes.QuickStartBlock{
+		Title:   "🛠️ Build with Make",
+		Shell:   "bash",
+		Command: strings.Join(commands, "\n"),
+	})
+}
+
+// imageName strips the tag and digest from an image reference
+func imageName(image string) string {
+	if at := strings.Index(image, "@"); at >= 0 {
+		image = image[:at]
+	}
+	if colon := strings.LastIndex(image, ":"); colon > strings.LastIndex(image, "/") {
+		image = image[:colon]
+	}
+	return image
+}
+
+// imageTag derives a local image tag from the project name, e.g.
+// "github.com/acme/api" becomes "api"
+func imageTag(name string) string {
+	name = strings.ToLower(name[strings.LastIndex(name, "/")+1:])
+	var b strings.Builder
+	for _, r := range name {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "app"
+	}
+	return b.String()
+}
+
+func appendUnique(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
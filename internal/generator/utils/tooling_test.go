@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+	docTypes "github.com/MRGHOSJ/docupocus/internal/generator/types"
+)
+
+func TestDetectTooling(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"Dockerfile": `ARG GO_VERSION=1.22
+FROM golang:${GO_VERSION} AS build
+RUN go build -o /app
+FROM alpine:3.19
+ENV PORT=8080 MODE=prod
+EXPOSE 8080 9090/udp
+ENTRYPOINT ["/app"]
+CMD ["serve"]
+`,
+		"worker.dockerfile": "FROM python:3.12-slim\nCMD python worker.py\n",
+		"Makefile": `.PHONY: help build test
+help: ## Show this help
+	@grep -E '^[a-z]+:' Makefile
+build: ## Compile the binary
+	go build ./...
+test: build ## Run the tests
+	go test ./...
+`,
+		"tools/Makefile": "gen:\n\tgo generate ./...\n",
+		"tools/rules.mk": "fmt:\n\tgofmt -w .\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := analyzer.AnalyzeProject(context.Background(), dir, analyzer.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	project := &docTypes.ProjectMeta{Name: "github.com/acme/API"}
+	DetectTooling(dir, result, project)
+
+	wantStack := []string{"golang", "alpine", "Docker", "python", "Make"}
+	if !reflect.DeepEqual(project.TechStack, wantStack) {
+		t.Errorf("tech stack = %v, want %v", project.TechStack, wantStack)
+	}
+
+	wantFeatures := []docTypes.Feature{
+		{Title: "🐳 Docker Support", Description: "2-stage build: `golang:1.22` → `alpine:3.19`, exposing 8080, 9090/udp, configured through `PORT`, `MODE`, runs `/app serve` (`Dockerfile`)"},
+		{Title: "🐳 Docker Support", Description: "Image built from `python:3.12-slim`, runs `python worker.py` (`worker.dockerfile`)"},
+		{Title: "🛠️ Make Targets", Description: "`help` (Show this help), `build` (Compile the binary), `test` (Run the tests) (`Makefile`)"},
+		{Title: "🛠️ Make Targets", Description: "`gen` (`tools/Makefile`)"},
+	}
+	if !reflect.DeepEqual(project.Features, wantFeatures) {
+		t.Errorf("features =\n%+v\nwant\n%+v", project.Features, wantFeatures)
+	}
+
+	wantQuickStart := []docTypes.QuickStartBlock{
+		{Title: "🐳 Run with Docker", Shell: "bash", Command: "docker build -t api .\ndocker run --rm -p 8080:8080 -p 9090:9090/udp api"},
+		{Title: "🐳 Run with Docker (worker.dockerfile)", Shell: "bash", Command: "docker build -f worker.dockerfile -t api-worker .\ndocker run --rm api-worker"},
+		{Title: "🛠️ Build with Make", Shell: "bash", Command: "make help\nmake build\nmake test"},
+		{Title: "🛠️ Build with Make", Shell: "bash", Command: "make -C tools # gen"},
+	}
+	if !reflect.DeepEqual(project.QuickStart, wantQuickStart) {
+		t.Errorf("quick start =\n%+v\nwant\n%+v", project.QuickStart, wantQuickStart)
+	}
+}
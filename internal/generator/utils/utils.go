@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -22,15 +23,71 @@ func GetLanguage(path string) string {
 		return "Go"
 	case strings.HasSuffix(path, ".py"):
 		return "Python"
-	case strings.HasSuffix(path, ".js"), strings.HasSuffix(path, ".ts"):
+	case strings.HasSuffix(path, ".ts"), strings.HasSuffix(path, ".tsx"),
+		strings.HasSuffix(path, ".mts"), strings.HasSuffix(path, ".cts"):
+		return "TypeScript"
+	case strings.HasSuffix(path, ".js"), strings.HasSuffix(path, ".mjs"),
+		strings.HasSuffix(path, ".cjs"), strings.HasSuffix(path, ".jsx"):
 		return "JavaScript"
 	case strings.HasSuffix(path, ".yaml"), strings.HasSuffix(path, ".yml"):
 		return "YAML"
+	case strings.HasSuffix(path, ".tf"), strings.HasSuffix(path, ".tfvars"), strings.HasSuffix(path, ".hcl"):
+		return "HCL"
+	case strings.HasSuffix(path, ".proto"):
+		return "Protobuf"
+	case analyzer.IsDockerfile(path):
+		return "Dockerfile"
+	case analyzer.IsMakefile(path):
+		return "Makefile"
 	default:
 		return "Unknown"
 	}
 }
 
+// IsConfigLanguage reports whether lang is documented as configuration,
+// through the YAML-style pages, rather than as code
+func IsConfigLanguage(lang string) bool {
+	switch lang {
+	case "YAML", "HCL", "Dockerfile", "Makefile", "GitHub Actions", "Helm", "Ansible", "Docker Compose":
+		return true
+	}
+	return false
+}
+
+// GetFileLanguage detects the language of an analyzed file from its sources,
+// since aggregated Go packages are keyed by directory rather than file. A
+// language set by the analyzer, such as OpenAPI, takes precedence.
+func GetFileLanguage(file *analyzer.AnalyzedFile) string {
+	if file.Language != "" {
+		return file.Language
+	}
+	if lang := GetLanguage(file.Path); lang != "Unknown" {
+		return lang
+	}
+	return GetLanguage(file.Sources()[0])
+}
+
+// PackageDocDir returns the output directory of a package page, relative to
+// the docs root. Packages with an import path are laid out by that path so
+// same-named packages never share a page.
+func PackageDocDir(pkg analyzer.Package) string {
+	if pkg.ImportPath != "" {
+		return filepath.FromSlash(pkg.ImportPath)
+	}
+	return pkg.Name
+}
+
+// PackageDocLink returns the Markdown link target of a package page
+func PackageDocLink(pkg analyzer.Package) string {
+	return path.Join(filepath.ToSlash(PackageDocDir(pkg)), "README.md")
+}
+
+// RootLink returns the link from a page in docDir back to the docs root README
+func RootLink(docDir string) string {
+	depth := len(strings.Split(filepath.ToSlash(filepath.Clean(docDir)), "/"))
+	return strings.Repeat("../", depth) + "README.md"
+}
+
 func NormalizeFields(fields []analyzer.Field) []analyzer.Field {
 	var normalized []analyzer.Field
 
@@ -96,22 +153,39 @@ func GetValueOrPlaceholder(f analyzer.Field) string {
 }
 
 func CalculateDocCompletion(pkg analyzer.Package) int {
-	totalItems := len(pkg.Structs) + len(pkg.Funcs)
-	if totalItems == 0 {
+	var docs []string
+	for _, s := range pkg.Structs {
+		docs = append(docs, s.Doc.Summary)
+	}
+	for _, i := range pkg.Interfaces {
+		docs = append(docs, i.Doc.Summary)
+	}
+	for _, t := range pkg.Types {
+		docs = append(docs, t.Doc.Summary)
+	}
+	for _, e := range pkg.Enums {
+		docs = append(docs, e.Doc.Summary)
+	}
+	for _, g := range pkg.Consts {
+		docs = append(docs, g.Doc.Summary)
+	}
+	for _, g := range pkg.Vars {
+		docs = append(docs, g.Doc.Summary)
+	}
+	for _, f := range pkg.Funcs {
+		docs = append(docs, f.Doc.Summary)
+	}
+
+	if len(docs) == 0 {
 		return 100
 	}
 
 	documentedItems := 0
-	for _, s := range pkg.Structs {
-		if s.Doc.Summary != "" {
-			documentedItems++
-		}
-	}
-	for _, f := range pkg.Funcs {
-		if f.Doc.Summary != "" {
+	for _, summary := range docs {
+		if summary != "" {
 			documentedItems++
 		}
 	}
 
-	return (documentedItems * 100) / totalItems
+	return (documentedItems * 100) / len(docs)
 }
@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/MRGHOSJ/docupocus/internal/analyzer"
+)
+
+func TestPackageDocDir(t *testing.T) {
+	tests := []struct {
+		pkg  analyzer.Package
+		dir  string
+		link string
+	}{
+		{analyzer.Package{Name: "util", ImportPath: "example.com/proj/a/util"}, "example.com/proj/a/util", "example.com/proj/a/util/README.md"},
+		{analyzer.Package{Name: "util", ImportPath: "example.com/proj/b/util"}, "example.com/proj/b/util", "example.com/proj/b/util/README.md"},
+		{analyzer.Package{Name: "util_test", ImportPath: "example.com/proj/a/util_test"}, "example.com/proj/a/util_test", "example.com/proj/a/util_test/README.md"},
+		{analyzer.Package{Name: "main"}, "main", "main/README.md"},
+	}
+	for _, tt := range tests {
+		if got := PackageDocDir(tt.pkg); got != filepath.FromSlash(tt.dir) {
+			t.Errorf("PackageDocDir(%s) = %s, want %s", tt.pkg.ImportPath, got, tt.dir)
+		}
+		if got := PackageDocLink(tt.pkg); got != tt.link {
+			t.Errorf("PackageDocLink(%s) = %s, want %s", tt.pkg.ImportPath, got, tt.link)
+		}
+	}
+}
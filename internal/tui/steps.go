@@ -31,7 +31,7 @@ func stepProjectDir(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.ProjectDir = "."
 		}
 		m.inputValue = ""
-		m.choices = []string{"openrouter", "ollama"}
+		m.choices = []string{"openrouter", "ollama", "openai-compatible"}
 		m.cursor = 0
 		m.step = 1
 	case "ctrl+c", "esc":
@@ -70,7 +70,7 @@ func stepAIModel(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "enter":
 		m.AiModel = strings.TrimSpace(m.inputValue)
 		m.inputValue = ""
-		if m.AiBackend == "ollama" {
+		if m.AiBackend == "ollama" || m.AiBackend == "openai-compatible" {
 			m.step = 3
 		} else {
 			m.step = 4
@@ -83,13 +83,18 @@ func stepAIModel(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
-// Handle step 3: aiEndpoint input (ollama)
+// Handle step 3: aiEndpoint input (ollama, openai-compatible)
 func stepAIEndpoint(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
 		m.AiEndpoint = strings.TrimSpace(m.inputValue)
 		m.inputValue = ""
-		m.step = 5
+		if m.AiBackend == "openai-compatible" {
+			// These servers may also require an API key
+			m.step = 4
+		} else {
+			m.step = 5
+		}
 	case "ctrl+c", "esc":
 		return m, tea.Quit
 	default:
@@ -98,7 +103,7 @@ func stepAIEndpoint(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
-// Handle step 4: aiAPIKey input (openrouter)
+// Handle step 4: aiAPIKey input (openrouter, openai-compatible)
 func stepAPIKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
@@ -168,6 +173,15 @@ func viewAIModel(m Model) string {
 }
 
 func viewAIEndpoint(m Model) string {
+	if m.AiBackend == "openai-compatible" {
+		return fmt.Sprintf(
+			"%s\n%s|\n\n%s\n%s",
+			QuestionStyle.Render("4. Enter AI Endpoint (OpenAI-compatible backend)"),
+			InputStyle.Render(m.inputValue),
+			"  Example: http://localhost:8000/v1",
+			"  Type the server's base URL and press Enter • Ctrl+C to Quit",
+		)
+	}
 	return fmt.Sprintf(
 		"%s\n%s|\n\n%s",
 		QuestionStyle.Render("4. Enter AI Endpoint (Ollama backend)"),
@@ -177,6 +191,14 @@ func viewAIEndpoint(m Model) string {
 }
 
 func viewAPIKey(m Model) string {
+	if m.AiBackend == "openai-compatible" {
+		return fmt.Sprintf(
+			"%s\n%s|\n\n%s",
+			QuestionStyle.Render("4. Enter AI API Key (leave empty if the server needs none)"),
+			InputStyle.Render(m.inputValue),
+			"  Type your API key and press Enter • Ctrl+C to Quit",
+		)
+	}
 	return fmt.Sprintf(
 		"%s\n%s|\n\n%s",
 		QuestionStyle.Render("4. Enter AI API Key (OpenRouter backend)"),
@@ -1,12 +1,17 @@
 package utils
 
 import (
+	"bytes"
 	"go/ast"
+	"go/printer"
+	"go/token"
 	"os"
-	"path/filepath"
 	"strings"
+	"unicode/utf8"
 )
 
+const maxValueLength = 80
+
 func Exists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
@@ -37,21 +42,109 @@ func ExprToRaw(expr ast.Expr) string {
 	switch e := expr.(type) {
 	case *ast.Ident:
 		return e.Name
+	case *ast.BasicLit:
+		return e.Value
 	case *ast.SelectorExpr:
 		return ExprToString(e.X) + "." + e.Sel.Name
 	case *ast.StarExpr:
 		return "*" + ExprToString(e.X)
+	case *ast.ParenExpr:
+		return "(" + ExprToString(e.X) + ")"
+	case *ast.UnaryExpr:
+		// Approximation elements in constraints, e.g. ~int
+		return e.Op.String() + ExprToString(e.X)
+	case *ast.BinaryExpr:
+		// Unions in constraints, e.g. ~int | ~string
+		return ExprToString(e.X) + " " + e.Op.String() + " " + ExprToString(e.Y)
 	case *ast.ArrayType:
-		return "[]" + ExprToString(e.Elt)
+		if e.Len == nil {
+			return "[]" + ExprToString(e.Elt)
+		}
+		if _, ok := e.Len.(*ast.Ellipsis); ok {
+			return "[...]" + ExprToString(e.Elt)
+		}
+		return "[" + ExprToString(e.Len) + "]" + ExprToString(e.Elt)
+	case *ast.Ellipsis:
+		return "..." + ExprToString(e.Elt)
 	case *ast.MapType:
 		return "map[" + ExprToString(e.Key) + "]" + ExprToString(e.Value)
+	case *ast.ChanType:
+		switch e.Dir {
+		case ast.SEND:
+			return "chan<- " + ExprToString(e.Value)
+		case ast.RECV:
+			return "<-chan " + ExprToString(e.Value)
+		default:
+			return "chan " + ExprToString(e.Value)
+		}
+	case *ast.IndexExpr:
+		return ExprToString(e.X) + "[" + ExprToString(e.Index) + "]"
+	case *ast.IndexListExpr:
+		indices := make([]string, len(e.Indices))
+		for i, index := range e.Indices {
+			indices[i] = ExprToString(index)
+		}
+		return ExprToString(e.X) + "[" + strings.Join(indices, ", ") + "]"
 	case *ast.FuncType:
-		return "func"
+		return "func" + SignatureToString(e)
+	case *ast.InterfaceType:
+		if e.Methods == nil || len(e.Methods.List) == 0 {
+			return "interface{}"
+		}
+		var elems []string
+		for _, m := range e.Methods.List {
+			if ft, ok := m.Type.(*ast.FuncType); ok && len(m.Names) > 0 {
+				elems = append(elems, m.Names[0].Name+SignatureToString(ft))
+				continue
+			}
+			elems = append(elems, ExprToString(m.Type))
+		}
+		return "interface{ " + strings.Join(elems, "; ") + " }"
+	case *ast.StructType:
+		if e.Fields == nil || len(e.Fields.List) == 0 {
+			return "struct{}"
+		}
+		return "struct{ " + FieldListToString(e.Fields, "; ") + " }"
 	default:
 		return ""
 	}
 }
 
+// SignatureToString renders the parameter and result lists of a function type,
+// e.g. "(ctx context.Context, in []string) ([]T, error)"
+func SignatureToString(ft *ast.FuncType) string {
+	sig := "(" + FieldListToString(ft.Params, ", ") + ")"
+	if ft.Results == nil || len(ft.Results.List) == 0 {
+		return sig
+	}
+	if len(ft.Results.List) == 1 && len(ft.Results.List[0].Names) == 0 {
+		return sig + " " + ExprToString(ft.Results.List[0].Type)
+	}
+	return sig + " (" + FieldListToString(ft.Results, ", ") + ")"
+}
+
+// FieldListToString renders parameters, results, type parameters or struct
+// fields, keeping grouped names together ("a, b int")
+func FieldListToString(fl *ast.FieldList, sep string) string {
+	if fl == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(fl.List))
+	for _, f := range fl.List {
+		typ := ExprToString(f.Type)
+		if len(f.Names) == 0 {
+			parts = append(parts, typ)
+			continue
+		}
+		names := make([]string, len(f.Names))
+		for i, name := range f.Names {
+			names[i] = name.Name
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+typ)
+	}
+	return strings.Join(parts, sep)
+}
+
 func RecvToString(recv *ast.FieldList) string {
 	if recv == nil || len(recv.List) == 0 {
 		return ""
@@ -59,18 +152,6 @@ func RecvToString(recv *ast.FieldList) string {
 	return ExprToString(recv.List[0].Type)
 }
 
-func HasFilesWithExtension(dir, ext string) bool {
-	found := false
-	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
-		if !d.IsDir() && strings.HasSuffix(d.Name(), ext) {
-			found = true
-			return filepath.SkipDir
-		}
-		return nil
-	})
-	return found
-}
-
 func FieldTagToString(tag *ast.BasicLit) string {
 	if tag == nil {
 		return ""
@@ -79,3 +160,21 @@ func FieldTagToString(tag *ast.BasicLit) string {
 	tagValue := strings.Trim(tag.Value, "`\"")
 	return tagValue
 }
+
+// ValueToString renders a value expression as a single line of source,
+// shortening long composite literals so they stay readable in docs
+func ValueToString(fset *token.FileSet, expr ast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return ""
+	}
+	value := strings.Join(strings.Fields(buf.String()), " ")
+	if utf8.RuneCountInString(value) > maxValueLength {
+		// Cut on a rune boundary so multi-byte characters stay whole
+		value = string([]rune(value)[:maxValueLength]) + "…"
+	}
+	return value
+}
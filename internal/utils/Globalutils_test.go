@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestValueToString(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"short", `"héllo"`, `"héllo"`},
+		{"multi-line literal", "[]int{\n\t1,\n\t2,\n}", "[]int{ 1, 2, }"},
+		{"ascii cut", `"` + strings.Repeat("a", 100) + `"`, `"` + strings.Repeat("a", maxValueLength-1) + "…"},
+		{"multi-byte cut", `"` + strings.Repeat("é", 100) + `"`, `"` + strings.Repeat("é", maxValueLength-1) + "…"},
+		{"emoji cut", `"` + strings.Repeat("🚀", 100) + `"`, `"` + strings.Repeat("🚀", maxValueLength-1) + "…"},
+	}
+	fset := token.NewFileSet()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parser.ParseExprFrom(fset, "", tt.src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ValueToString(fset, expr)
+			if !utf8.ValidString(got) {
+				t.Fatalf("ValueToString returned invalid UTF-8 %q", got)
+			}
+			if got != tt.want {
+				t.Errorf("ValueToString = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExprToString(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"chan<- int", "chan<- int"},
+		{"<-chan []byte", "<-chan []byte"},
+		{"chan (<-chan int)", "chan (<-chan int)"},
+		{"[4]byte", "[4]byte"},
+		{"[2 * N]*Node", "[2 * N]*Node"},
+		{"Map[K, V]", "Map[K, V]"},
+		{"cache.Store[string]", "cache.Store[string]"},
+		{"map[string]func() error", "map[string]func() error"},
+		{"func(...any)", "func(...any)"},
+		{"interface{ Len() int }", "interface{ Len() int }"},
+		{"struct{ X, Y int }", "struct{ X, Y int }"},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.src)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.src, err)
+		}
+		if got := ExprToString(expr); got != tt.want {
+			t.Errorf("ExprToString(%s) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestSignatureToString(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"func()", "()"},
+		{"func(a, b int) error", "(a, b int) error"},
+		{"func(format string, args ...any)", "(format string, args ...any)"},
+		{"func(ctx context.Context, in <-chan Item, out chan<- Item)", "(ctx context.Context, in <-chan Item, out chan<- Item)"},
+		{"func(m Map[K, V]) func() Map[K, V]", "(m Map[K, V]) func() Map[K, V]"},
+		{"func(keep func(int) bool) (n int, err error)", "(keep func(int) bool) (n int, err error)"},
+		{"func(sum [32]byte) ([]byte, error)", "(sum [32]byte) ([]byte, error)"},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.src)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.src, err)
+		}
+		ft, ok := expr.(*ast.FuncType)
+		if !ok {
+			t.Fatalf("%s parsed as %T", tt.src, expr)
+		}
+		if got := SignatureToString(ft); got != tt.want {
+			t.Errorf("SignatureToString(%s) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
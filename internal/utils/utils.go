@@ -19,32 +19,6 @@ func GetGitRemoteURL(projectDir string) string {
 	return strings.TrimSpace(string(out))
 }
 
-// --- detect languages based on file extensions ---
-func DetectLanguages(projectDir string) []string {
-	langs := make(map[string]bool)
-	filepath.WalkDir(projectDir, func(path string, d os.DirEntry, err error) error {
-		if err != nil || d.IsDir() {
-			return nil
-		}
-		switch {
-		case strings.HasSuffix(path, ".go"):
-			langs["Go"] = true
-		case strings.HasSuffix(path, ".py"):
-			langs["Python"] = true
-		case strings.HasSuffix(path, ".js"):
-			langs["JavaScript"] = true
-		case strings.HasSuffix(path, ".yaml"), strings.HasSuffix(path, ".yml"):
-			langs["YAML"] = true
-		}
-		return nil
-	})
-	var result []string
-	for l := range langs {
-		result = append(result, l)
-	}
-	return result
-}
-
 // --- parse go.mod for module name ---
 func ParseGoMod(projectDir string) string {
 	goModPath := filepath.Join(projectDir, "go.mod")
@@ -98,13 +72,10 @@ func DetectTechStack(projectDir string, langs []string) []string {
 	tech := make([]string, 0, len(langs))
 	tech = append(tech, langs...)
 
-	if _, err := os.Stat(filepath.Join(projectDir, "Dockerfile")); err == nil {
-		tech = append(tech, "Docker")
-	}
 	if _, err := os.Stat(filepath.Join(projectDir, ".github", "workflows")); err == nil {
 		tech = append(tech, "GitHub Actions")
 	}
-	// Add more detections as needed (Kubernetes manifests, Helm charts, etc)
+	// Docker and Make come from the analyzed Dockerfiles and Makefiles
 	return tech
 }
 
@@ -112,22 +83,11 @@ func DetectTechStack(projectDir string, langs []string) []string {
 func DetectFeaturesAndQuickstart(projectDir string, langs []string) ([]docTypes.Feature, []docTypes.QuickStartBlock) {
 	var features []docTypes.Feature
 	var quickstarts []docTypes.QuickStartBlock
+	npmDetected := false
 
 	for _, lang := range langs {
 		switch lang {
 		case "Go":
-			// Docker support
-			if _, err := os.Stat(filepath.Join(projectDir, "Dockerfile")); err == nil {
-				features = append(features, docTypes.Feature{
-					Title:       "🐳 Docker Support",
-					Description: "Containerized deployment using Docker",
-				})
-				quickstarts = append(quickstarts, docTypes.QuickStartBlock{
-					Title:   "🐳 Run with Docker",
-					Shell:   "bash",
-					Command: "docker build -t myapp .\ndocker run -p 8080:8080 myapp",
-				})
-			}
 			// CLI app
 			if _, err := os.Stat(filepath.Join(projectDir, "main.go")); err == nil {
 				features = append(features, docTypes.Feature{
@@ -141,12 +101,17 @@ func DetectFeaturesAndQuickstart(projectDir string, langs []string) ([]docTypes.
 				})
 			}
 
-		case "JavaScript":
+		case "JavaScript", "TypeScript":
+			// Mixed JavaScript/TypeScript projects share one package.json
+			if npmDetected {
+				continue
+			}
 			pkgName, _ := ParsePackageJSON(projectDir)
 			if pkgName != "" {
+				npmDetected = true
 				features = append(features, docTypes.Feature{
 					Title:       "📦 NPM Package",
-					Description: "JavaScript project managed with npm",
+					Description: lang + " project managed with npm",
 				})
 				quickstarts = append(quickstarts, docTypes.QuickStartBlock{
 					Title:   "▶️ Run with npm",
@@ -175,6 +140,30 @@ func DetectFeaturesAndQuickstart(projectDir string, langs []string) ([]docTypes.
 				Title:       "☸️ Kubernetes Configs",
 				Description: "Kubernetes manifests included",
 			})
+
+		case "Terraform":
+			features = append(features, docTypes.Feature{
+				Title:       "🏗️ Terraform Infrastructure",
+				Description: "Infrastructure defined as Terraform configuration",
+			})
+			quickstarts = append(quickstarts, docTypes.QuickStartBlock{
+				Title:   "🏗️ Plan Infrastructure",
+				Shell:   "bash",
+				Command: "terraform init\nterraform plan",
+			})
+
+		case "Protobuf":
+			features = append(features, docTypes.Feature{
+				Title:       "🛰️ Protobuf APIs",
+				Description: "Messages and gRPC services defined in .proto files",
+			})
+			if _, err := os.Stat(filepath.Join(projectDir, "buf.gen.yaml")); err == nil {
+				quickstarts = append(quickstarts, docTypes.QuickStartBlock{
+					Title:   "🛰️ Generate Code",
+					Shell:   "bash",
+					Command: "buf generate",
+				})
+			}
 		}
 	}
 